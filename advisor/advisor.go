@@ -0,0 +1,514 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package advisor recommends indexes for a workload of queries, and the
+// existing indexes it does not need.
+package advisor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+	"github.com/redhajuanda/sqlparser/semantics"
+)
+
+// maxNameLength is the maximum length of an index name.
+const maxNameLength = 64
+
+// Action is what a recommendation does with an index.
+type Action int8
+
+const (
+	// AddIndex adds an index that serves queries of the workload.
+	AddIndex Action = iota
+	// DropRedundant drops an index whose columns are a prefix of another
+	// index.
+	DropRedundant
+	// DropUnused drops an index no query of the workload can use.
+	DropUnused
+)
+
+func (a Action) String() string {
+	switch a {
+	case AddIndex:
+		return "add"
+	case DropRedundant:
+		return "drop redundant"
+	case DropUnused:
+		return "drop unused"
+	}
+	return fmt.Sprintf("Action(%d)", int8(a))
+}
+
+// Options configures Advise.
+type Options struct {
+	// MaxColumns is the maximum number of columns of a recommended index.
+	// Covering indexes are only recommended within it. It defaults to 5.
+	MaxColumns int
+}
+
+// Recommendation is an index to add or drop.
+type Recommendation struct {
+	Action Action
+	// Alter adds or drops the index.
+	Alter *sqlparser.AlterTable
+	// Covering is set for added indexes that hold every column the queries
+	// they serve read from the table, so the queries need not read its
+	// rows.
+	Covering bool
+	// Queries are the positions in the workload of the queries an added
+	// index serves.
+	Queries []int
+	// Reason justifies the recommendation.
+	Reason string
+}
+
+// String returns the statement of the recommendation and its reason.
+func (r *Recommendation) String() string {
+	return fmt.Sprintf("%s -- %s", sqlparser.String(r.Alter), r.Reason)
+}
+
+// Advise recommends indexes for the tables of a schema given the queries
+// of a workload: composite indexes for the queries no index serves, and
+// the drop of the indexes that are redundant or that no query can use.
+// The indexes of tables the workload does not use are left alone.
+func Advise(s *schema.Schema, workload []sqlparser.Statement, opts Options) ([]*Recommendation, error) {
+	if opts.MaxColumns <= 0 {
+		opts.MaxColumns = 5
+	}
+	a := &advisor{schema: s, opts: opts, used: map[*sqlparser.IndexDefinition]bool{}, dropped: map[*sqlparser.IndexDefinition]bool{}}
+	for i, stmt := range workload {
+		usages, err := semantics.ColumnUsages(s, stmt)
+		if err != nil {
+			return nil, fmt.Errorf("query %d: %w", i+1, err)
+		}
+		for _, usage := range usages {
+			a.query(i, usage, usages)
+		}
+	}
+
+	var recommendations []*Recommendation
+	for _, c := range a.merge() {
+		recommendations = append(recommendations, a.add(c))
+	}
+	for _, name := range a.tables {
+		recommendations = append(recommendations, a.redundant(name)...)
+	}
+	for _, name := range a.tables {
+		recommendations = append(recommendations, a.unused(name)...)
+	}
+	return recommendations, nil
+}
+
+// candidate is an index that serves queries of the workload.
+type candidate struct {
+	table sqlparser.TableName
+	// equality, ranges and sort are the columns of the index by how the
+	// queries use them.
+	equality, ranges, sort []string
+	// desc is set if the sort columns are in descending order.
+	desc bool
+	// extra are the columns added to cover the queries.
+	extra    []string
+	covering bool
+	queries  []int
+}
+
+func (c *candidate) columns() []string {
+	return slices.Concat(c.equality, c.ranges, c.sort, c.extra)
+}
+
+type advisor struct {
+	schema *schema.Schema
+	opts   Options
+	// tables are the tables the workload uses, in order.
+	tables     []sqlparser.TableName
+	candidates []*candidate
+	// used holds the existing indexes some query can use.
+	used map[*sqlparser.IndexDefinition]bool
+	// dropped holds the existing indexes recommended to be dropped.
+	dropped map[*sqlparser.IndexDefinition]bool
+}
+
+// query finds the index a query needs on a table.
+func (a *advisor) query(i int, usage *semantics.TableUsage, all []*semantics.TableUsage) {
+	table := a.schema.Table(usage.Table)
+	if table == nil {
+		return
+	}
+	if !slices.Contains(a.tables, usage.Table) {
+		a.tables = append(a.tables, usage.Table)
+	}
+
+	c := &candidate{table: usage.Table, queries: []int{i}}
+	var read, joins []string
+	var order, group []semantics.ColumnUsage
+	for _, use := range usage.Columns {
+		// Index columns are compared in lower case.
+		use.Column = strings.ToLower(use.Column)
+		if !slices.Contains(read, use.Column) {
+			read = append(read, use.Column)
+		}
+		if !use.Sargable || !indexable(table.Column(use.Column)) {
+			continue
+		}
+		switch use.Kind {
+		case semantics.Equality:
+			c.equality = appendNew(c.equality, use.Column)
+		case semantics.Join:
+			joins = appendNew(joins, use.Column)
+		case semantics.Range:
+			c.ranges = appendNew(c.ranges, use.Column)
+		case semantics.OrderBy:
+			order = append(order, use)
+		case semantics.GroupBy:
+			group = append(group, use)
+		}
+	}
+	// A table compared with values is likely read first, and looked up by
+	// its join columns otherwise.
+	if len(c.equality) == 0 && len(c.ranges) == 0 {
+		c.equality = joins
+	}
+	a.markUsed(table, slices.Concat(c.equality, joins), c.ranges, order, group)
+
+	// Equality columns come first, in the order of the table so that
+	// queries comparing the same columns get the same index. A single range
+	// column can follow, and the sort columns otherwise.
+	slices.SortFunc(c.equality, func(x, y string) int {
+		return columnIndex(table, x) - columnIndex(table, y)
+	})
+	c.ranges = slices.DeleteFunc(c.ranges, func(col string) bool { return slices.Contains(c.equality, col) })
+	if len(c.ranges) > 0 {
+		c.ranges = c.ranges[:1]
+	} else if sort, desc, ok := sortColumns(usage, order, group, all); ok {
+		c.sort, c.desc = slices.DeleteFunc(sort, func(col string) bool { return slices.Contains(c.equality, col) }), desc
+	}
+	if len(c.columns()) == 0 {
+		return
+	}
+	if len(c.columns()) > a.opts.MaxColumns {
+		c.ranges, c.sort = nil, nil
+		c.equality = c.equality[:min(len(c.equality), a.opts.MaxColumns)]
+	}
+
+	if a.served(table, c) {
+		return
+	}
+
+	// Secondary indexes hold the primary key, which the query can read too.
+	var rest []string
+	for _, col := range read {
+		if !slices.Contains(c.columns(), col) && !slices.Contains(primaryKey(table), col) {
+			rest = append(rest, col)
+		}
+	}
+	if len(c.columns())+len(rest) <= a.opts.MaxColumns && !slices.ContainsFunc(rest, func(col string) bool {
+		return !indexable(table.Column(col))
+	}) {
+		c.extra, c.covering = rest, true
+	}
+	a.candidates = append(a.candidates, c)
+}
+
+// sortColumns returns the ORDER BY, or else GROUP BY, columns of a query an
+// index on the table can return in order: all are columns of the table,
+// sorted in the same direction.
+func sortColumns(usage *semantics.TableUsage, order, group []semantics.ColumnUsage, all []*semantics.TableUsage) ([]string, bool, bool) {
+	kind, uses := semantics.OrderBy, order
+	if len(order) == 0 {
+		kind, uses = semantics.GroupBy, group
+	}
+	if len(uses) == 0 {
+		return nil, false, false
+	}
+	for _, other := range all {
+		for _, use := range other.Columns {
+			if use.Kind == kind && (other != usage || !use.Sargable || use.Desc != uses[0].Desc) {
+				return nil, false, false
+			}
+		}
+	}
+	var cols []string
+	for _, use := range uses {
+		cols = appendNew(cols, use.Column)
+	}
+	return cols, uses[0].Desc, true
+}
+
+// served returns whether an existing index serves a candidate: its first
+// columns are the equality columns in any order, followed by the range or
+// sort columns.
+func (a *advisor) served(table *schema.Table, c *candidate) bool {
+	for _, index := range table.Indexes() {
+		cols := indexColumns(index)
+		if len(cols) < len(c.columns()) {
+			continue
+		}
+		eq := slices.Clone(cols[:len(c.equality)])
+		slices.Sort(eq)
+		want := slices.Clone(c.equality)
+		slices.Sort(want)
+		if slices.Equal(eq, want) && slices.Equal(cols[len(c.equality):len(c.columns())], c.columns()[len(c.equality):]) {
+			return true
+		}
+	}
+	return false
+}
+
+// markUsed marks the existing indexes a query can use: the indexes whose
+// first column it compares with a value or sorts by.
+func (a *advisor) markUsed(table *schema.Table, equality, ranges []string, order, group []semantics.ColumnUsage) {
+	for _, index := range table.Indexes() {
+		cols := indexColumns(index)
+		if len(cols) == 0 {
+			continue
+		}
+		first := cols[0]
+		if slices.Contains(equality, first) || slices.Contains(ranges, first) ||
+			len(order) > 0 && order[0].Column == first || len(order) == 0 && len(group) > 0 && group[0].Column == first {
+			a.used[index] = true
+		}
+	}
+}
+
+// merge merges the candidates whose columns are a prefix of the columns of
+// another candidate of the table, which serves the queries of both.
+func (a *advisor) merge() []*candidate {
+	var merged []*candidate
+	for _, c := range a.candidates {
+		found := false
+		for i, m := range merged {
+			if m.table != c.table || m.desc != c.desc && len(m.sort) > 0 && len(c.sort) > 0 {
+				continue
+			}
+			switch {
+			case isPrefix(c.columns(), m.columns()) && (c.covering == m.covering || m.covering):
+				m.queries = append(m.queries, c.queries...)
+				found = true
+			case isPrefix(m.columns(), c.columns()) && (c.covering == m.covering || c.covering):
+				c.queries = append(m.queries, c.queries...)
+				merged[i] = c
+				found = true
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
+
+// add returns the recommendation to add a candidate index.
+func (a *advisor) add(c *candidate) *Recommendation {
+	table := a.schema.Table(c.table)
+	def := &sqlparser.IndexDefinition{Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeDefault, Name: sqlparser.NewIdentifierCI(indexName(table, c.columns()))}}
+	for _, col := range c.columns() {
+		column := &sqlparser.IndexColumn{Column: sqlparser.NewIdentifierCI(col)}
+		if c.desc && slices.Contains(c.sort, col) {
+			column.Direction = sqlparser.DescOrder
+		}
+		def.Columns = append(def.Columns, column)
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		name string
+		cols []string
+	}{{"equality", c.equality}, {"range", c.ranges}, {"sort", c.sort}, {"covering", c.extra}} {
+		if len(part.cols) > 0 {
+			parts = append(parts, fmt.Sprintf("%s on %s", part.name, strings.Join(part.cols, ", ")))
+		}
+	}
+	queries := make([]string, len(c.queries))
+	for i, q := range c.queries {
+		queries[i] = fmt.Sprint(q + 1)
+	}
+	return &Recommendation{
+		Action:   AddIndex,
+		Alter:    alter(c.table, &sqlparser.AddIndexDefinition{IndexDefinition: def}),
+		Covering: c.covering,
+		Queries:  c.queries,
+		Reason:   fmt.Sprintf("%s; serves queries %s", strings.Join(parts, ", "), strings.Join(queries, ", ")),
+	}
+}
+
+// redundant returns the recommendations to drop the indexes of a table
+// whose columns are a prefix of the columns of another index that is kept.
+// Unique indexes enforce a constraint and are kept.
+func (a *advisor) redundant(name sqlparser.TableName) []*Recommendation {
+	table := a.schema.Table(name)
+	var recommendations []*Recommendation
+	for _, index := range table.Indexes() {
+		if index.Info.IsUnique() || len(indexColumns(index)) == 0 {
+			continue
+		}
+		for _, other := range table.Indexes() {
+			if other == index || a.dropped[other] || !isPrefix(indexColumns(index), indexColumns(other)) {
+				continue
+			}
+			if !a.used[other] && !other.Info.IsUnique() && !a.foreignKeyNeeds(table, other) {
+				continue
+			}
+			// Of two indexes with the same columns, the first one is kept.
+			if len(indexColumns(other)) == len(indexColumns(index)) && !other.Info.IsUnique() && indexPosition(table, other) > indexPosition(table, index) {
+				continue
+			}
+			recommendations = append(recommendations, &Recommendation{
+				Action: DropRedundant,
+				Alter:  alter(name, &sqlparser.DropKey{Type: sqlparser.NormalKeyType, Name: index.Info.Name}),
+				Reason: fmt.Sprintf("columns are a prefix of index %s", indexLabel(other)),
+			})
+			a.dropped[index] = true
+			break
+		}
+	}
+	return recommendations
+}
+
+// unused returns the recommendations to drop the indexes of a table no
+// query can use. Unique indexes and the indexes foreign keys need are kept.
+func (a *advisor) unused(name sqlparser.TableName) []*Recommendation {
+	table := a.schema.Table(name)
+	var recommendations []*Recommendation
+	for _, index := range table.Indexes() {
+		if a.used[index] || a.dropped[index] || index.Info.IsUnique() || a.foreignKeyNeeds(table, index) {
+			continue
+		}
+		recommendations = append(recommendations, &Recommendation{
+			Action: DropUnused,
+			Alter:  alter(name, &sqlparser.DropKey{Type: sqlparser.NormalKeyType, Name: index.Info.Name}),
+			Reason: "no query of the workload can use it",
+		})
+	}
+	return recommendations
+}
+
+// foreignKeyNeeds returns whether an index is the only index of a table
+// whose first columns are the columns of one of its foreign keys.
+func (a *advisor) foreignKeyNeeds(table *schema.Table, index *sqlparser.IndexDefinition) bool {
+	for _, constraint := range table.ForeignKeys() {
+		fk, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition)
+		if !ok {
+			continue
+		}
+		var cols []string
+		for _, col := range fk.Source {
+			cols = append(cols, col.Lowered())
+		}
+		if !isPrefix(cols, indexColumns(index)) {
+			continue
+		}
+		others := slices.ContainsFunc(table.Indexes(), func(other *sqlparser.IndexDefinition) bool {
+			return other != index && isPrefix(cols, indexColumns(other))
+		})
+		if !others {
+			return true
+		}
+	}
+	return false
+}
+
+func alter(name sqlparser.TableName, option sqlparser.AlterOption) *sqlparser.AlterTable {
+	return &sqlparser.AlterTable{Table: name, AlterOptions: []sqlparser.AlterOption{option}, FullyParsed: true}
+}
+
+// indexColumns returns the lower case names of the columns of an index. It
+// returns nil for indexes on expressions or column prefixes, which the
+// advisor leaves alone.
+func indexColumns(index *sqlparser.IndexDefinition) []string {
+	switch index.Info.Type {
+	case sqlparser.IndexTypeFullText, sqlparser.IndexTypeSpatial:
+		return nil
+	}
+	cols := make([]string, 0, len(index.Columns))
+	for _, col := range index.Columns {
+		if col.Expression != nil || col.Length != nil {
+			return nil
+		}
+		cols = append(cols, col.Column.Lowered())
+	}
+	return cols
+}
+
+func primaryKey(table *schema.Table) []string {
+	if pk := table.PrimaryKey(); pk != nil {
+		return indexColumns(pk)
+	}
+	return nil
+}
+
+// indexable returns whether a column can be indexed without a prefix
+// length.
+func indexable(col *sqlparser.ColumnDefinition) bool {
+	if col == nil {
+		return false
+	}
+	switch strings.ToLower(col.Type.Type) {
+	case "tinytext", "text", "mediumtext", "longtext", "tinyblob", "blob", "mediumblob", "longblob", "json", "geometry":
+		return false
+	}
+	return true
+}
+
+// indexName returns a name for an index on the columns that no index of
+// the table has.
+func indexName(table *schema.Table, cols []string) string {
+	base := "idx_" + strings.Join(cols, "_")
+	if len(base) > maxNameLength-3 {
+		base = base[:maxNameLength-3]
+	}
+	name := base
+	for i := 2; table.Index(name) != nil; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
+}
+
+func indexLabel(index *sqlparser.IndexDefinition) string {
+	if index.Info.Type == sqlparser.IndexTypePrimary {
+		return "PRIMARY"
+	}
+	return index.Info.Name.String()
+}
+
+func indexPosition(table *schema.Table, index *sqlparser.IndexDefinition) int {
+	return slices.Index(table.Indexes(), index)
+}
+
+func columnIndex(table *schema.Table, name string) int {
+	return slices.IndexFunc(table.Columns(), func(col *sqlparser.ColumnDefinition) bool {
+		return col.Name.EqualString(name)
+	})
+}
+
+// isPrefix returns whether the columns are a prefix of other columns.
+func isPrefix(cols, other []string) bool {
+	return len(cols) > 0 && len(cols) <= len(other) && slices.Equal(cols, other[:len(cols)])
+}
+
+func appendNew(cols []string, col string) []string {
+	if slices.Contains(cols, col) {
+		return cols
+	}
+	return append(cols, col)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+func testSchema(t *testing.T) *schema.Schema {
+	t.Helper()
+	s, err := schema.NewFromSQL(sqlparser.NewTestParser(), `
+		create database shop;
+		use shop;
+		create table customer (
+			id int primary key,
+			name varchar(50),
+			email varchar(100),
+			country char(2),
+			bio text,
+			key idx_name (name),
+			key idx_name_email (name, email),
+			unique key uk_email (email),
+			key idx_country (country)
+		);
+		create table orders (
+			id bigint primary key,
+			customer_id int not null,
+			status varchar(10),
+			total decimal(10,2),
+			created_at datetime,
+			key idx_customer (customer_id),
+			key idx_status_total (status, total),
+			constraint fk_customer foreign key (customer_id) references customer (id)
+		);
+	`)
+	require.NoError(t, err)
+	return s
+}
+
+func parseAll(t *testing.T, queries ...string) []sqlparser.Statement {
+	t.Helper()
+	var stmts []sqlparser.Statement
+	for _, sql := range queries {
+		stmt, err := sqlparser.NewTestParser().Parse(sql)
+		require.NoError(t, err)
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+func TestAdvise(t *testing.T) {
+	testcases := []struct {
+		name            string
+		queries         []string
+		recommendations []string
+	}{{
+		name: "equality then range",
+		queries: []string{
+			"select id, total from orders where customer_id = 1 and created_at > '2024-01-01'",
+		},
+		recommendations: []string{
+			"alter table shop.orders add key idx_customer_id_created_at_total (customer_id, created_at, total) -- equality on customer_id, range on created_at, covering on total; serves queries 1",
+			"alter table shop.orders drop key idx_status_total -- no query of the workload can use it",
+		},
+	}, {
+		name: "equality then sort, merged across queries",
+		queries: []string{
+			"select * from orders where status = 'new' and customer_id = 2 order by created_at desc",
+			"select o.id from orders o where o.customer_id = 3 and o.status = 'paid'",
+			"select c.name from customer c join orders o on o.customer_id = c.id where c.country = 'NL'",
+		},
+		recommendations: []string{
+			"alter table shop.orders add key idx_customer_id_status_created_at_total (customer_id, `status`, created_at desc, total) -- equality on customer_id, status, sort on created_at, covering on total; serves queries 1, 2",
+			"alter table shop.customer drop key idx_name -- no query of the workload can use it",
+			"alter table shop.customer drop key idx_name_email -- no query of the workload can use it",
+		},
+	}, {
+		name: "served by existing indexes",
+		queries: []string{
+			"select name, email from customer where name = 'x' and email like 'a%'",
+			"select sum(total) from orders where status = 'new' and total > 10",
+			"select * from customer where upper(name) = 'X' and bio = 'y'",
+			"select country, count(*) from customer group by country",
+		},
+		recommendations: []string{
+			"alter table shop.customer drop key idx_name -- columns are a prefix of index idx_name_email",
+		},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			recommendations, err := Advise(testSchema(t), parseAll(t, tcase.queries...), Options{})
+			require.NoError(t, err)
+			var got []string
+			for _, r := range recommendations {
+				got = append(got, r.String())
+			}
+			assert.Equal(t, tcase.recommendations, got)
+		})
+	}
+}
+
+func TestAdviseErrors(t *testing.T) {
+	_, err := Advise(testSchema(t), parseAll(t, "select 1", "select missing from orders"), Options{})
+	assert.EqualError(t, err, "query 2: Unknown column 'missing' in 'field list' (errno 1054) (sqlstate 42S22)")
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser/dependencies/vt/vterrors"
+
+	vtrpcpb "github.com/redhajuanda/sqlparser/dependencies/vt/proto/vtrpc"
+)
+
+// sha256DigestVersion is the first MySQL version hashing digests with
+// SHA-256 instead of MD5.
+const sha256DigestVersion = "80000"
+
+// Digest is the digest of a statement, as performance_schema reports it in
+// events_statements_summary_by_digest.
+type Digest struct {
+	// Text is the normalized statement, as in the DIGEST_TEXT column.
+	Text string
+	// Hash is the hexadecimal hash of the normalized statement, with the
+	// algorithm of the DIGEST column for the parser's server version: a
+	// SHA-256 hash from MySQL 8.0 on and an MD5 hash before.
+	//
+	// Hash is the hash of Text, while MySQL hashes the numbers its parser
+	// gives to tokens, which differ between builds. Hash therefore groups
+	// statements as DIGEST does, having the same value for statements with
+	// the same DIGEST_TEXT, but its value is not the DIGEST value: match
+	// server digests by DIGEST_TEXT, or map their DIGEST values to Hash
+	// through it.
+	Hash string
+}
+
+// digestKind is the kind of a token of a digest. The kinds other than
+// digestToken are the reductions MySQL applies to values.
+type digestKind int
+
+const (
+	digestToken digestKind = iota
+	digestIdentifier
+	// digestValue is a literal or a placeholder: ?
+	digestValue
+	// digestValueList is a list of values: ...
+	digestValueList
+	// digestRow is a parenthesized value: (?)
+	digestRow
+	// digestRowList is a list of parenthesized values: (?) /* , ... */
+	digestRowList
+	// digestRowValues is a parenthesized list of values: (...)
+	digestRowValues
+	// digestRowValuesList is a list of parenthesized lists of values:
+	// (...) /* , ... */
+	digestRowValuesList
+)
+
+var digestTexts = map[digestKind]string{
+	digestValue:         "?",
+	digestValueList:     "...",
+	digestRow:           "(?)",
+	digestRowList:       "(?) /* , ... */",
+	digestRowValues:     "(...)",
+	digestRowValuesList: "(...) /* , ... */",
+}
+
+type digestItem struct {
+	kind digestKind
+	text string
+}
+
+// Digest returns the digest performance_schema computes for the first
+// statement of sql on a server of the parser's version. Like MySQL, it
+// works on the tokens of the statement, which need not parse:
+//   - literals and placeholders become ?, and signs before them are dropped;
+//   - lists of values become ..., so that IN (1, 2, 3) becomes IN (...);
+//   - lists of rows, as in VALUES, keep their first row followed by
+//     /* , ... */;
+//   - identifiers are backquoted and keywords are upper case. A keyword
+//     the statement uses as the name of a column, table or alias is an
+//     identifier, which takes the statement to parse;
+//   - comments are removed, and tokens are separated by a single space.
+func (p *Parser) Digest(sql string) (Digest, error) {
+	names := map[string]bool{}
+	if stmt, err := p.Parse(sql); err == nil {
+		names = digestNames(stmt)
+	}
+	tokenizer := p.NewStringTokenizer(sql)
+	var items []digestItem
+	for {
+		typ, val := tokenizer.Scan()
+		if typ == 0 || typ == ';' {
+			break
+		}
+		if typ == LEX_ERROR {
+			return Digest{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "syntax error at position %d near '%s'", tokenizer.Pos, val)
+		}
+		if typ == COMMENT {
+			continue
+		}
+		item := digestTokenItem(items, typ, val)
+		if item.kind == digestToken && typ >= 256 && names[strings.ToLower(val)] {
+			item = digestItem{kind: digestIdentifier, text: digestIdentifierText(val)}
+		}
+		items = addDigestItem(items, item)
+	}
+
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		if text, ok := digestTexts[item.kind]; ok {
+			texts = append(texts, text)
+		} else {
+			texts = append(texts, item.text)
+		}
+	}
+	digest := Digest{Text: strings.Join(texts, " ")}
+	if p.version >= sha256DigestVersion {
+		sum := sha256.Sum256([]byte(digest.Text))
+		digest.Hash = hex.EncodeToString(sum[:])
+	} else {
+		sum := md5.Sum([]byte(digest.Text))
+		digest.Hash = hex.EncodeToString(sum[:])
+	}
+	return digest, nil
+}
+
+// digestNames returns the lowercased names of the columns, tables and
+// aliases of a statement.
+func digestNames(stmt Statement) map[string]bool {
+	names := map[string]bool{}
+	add := func(ids ...string) {
+		for _, id := range ids {
+			if id != "" {
+				names[strings.ToLower(id)] = true
+			}
+		}
+	}
+	_ = Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *ColName:
+			add(node.Name.String(), node.Qualifier.Name.String(), node.Qualifier.Qualifier.String())
+		case TableName:
+			add(node.Name.String(), node.Qualifier.String())
+		case *AliasedExpr:
+			add(node.As.String())
+		case *AliasedTableExpr:
+			add(node.As.String())
+		case Columns:
+			for _, col := range node {
+				add(col.String())
+			}
+		}
+		return true, nil
+	}, stmt)
+	return names
+}
+
+// digestTokenItem returns the digest item of a token.
+func digestTokenItem(items []digestItem, typ int, val string) digestItem {
+	switch typ {
+	case STRING, NCHAR_STRING, INTEGRAL, FLOAT, DECIMAL, HEX, HEXNUM, BIT_LITERAL, VALUE_ARG, LIST_ARG, OFFSET_ARG:
+		return digestItem{kind: digestValue}
+	case NULL:
+		// NULL is a value except in IS NULL and IS NOT NULL.
+		if n := len(items); n > 0 && items[n-1].text == "IS" || n > 1 && items[n-1].text == "NOT" && items[n-2].text == "IS" {
+			return digestItem{text: "NULL"}
+		}
+		return digestItem{kind: digestValue}
+	case ID:
+		return digestItem{kind: digestIdentifier, text: digestIdentifierText(val)}
+	case AT_ID:
+		return digestItem{kind: digestIdentifier, text: "@" + digestIdentifierText(val)}
+	case AT_AT_ID:
+		// The scope of a system variable is a keyword, as in
+		// @@SESSION . `sql_mode`.
+		if scope, name, ok := strings.Cut(val, "."); ok {
+			return digestItem{kind: digestIdentifier, text: "@@" + strings.ToUpper(scope) + " . " + digestIdentifierText(name)}
+		}
+		return digestItem{kind: digestIdentifier, text: "@@" + digestIdentifierText(val)}
+	case NE:
+		return digestItem{text: "!="}
+	case LE:
+		return digestItem{text: "<="}
+	case GE:
+		return digestItem{text: ">="}
+	case NULL_SAFE_EQUAL:
+		return digestItem{text: "<=>"}
+	case SHIFT_LEFT:
+		return digestItem{text: "<<"}
+	case SHIFT_RIGHT:
+		return digestItem{text: ">>"}
+	case ASSIGNMENT_OPT:
+		return digestItem{text: ":="}
+	case JSON_EXTRACT_OP:
+		return digestItem{text: "->"}
+	case JSON_UNQUOTE_EXTRACT_OP:
+		return digestItem{text: "->>"}
+	}
+	switch {
+	case typ == AND && val == "":
+		return digestItem{text: "&&"}
+	case typ == OR && val == "":
+		return digestItem{text: "||"}
+	case typ < 256:
+		return digestItem{text: string(rune(typ))}
+	case strings.HasPrefix(val, "_"):
+		// Character set introducers, such as _utf8mb4.
+		return digestItem{text: strings.ToLower(val)}
+	}
+	return digestItem{text: strings.ToUpper(val)}
+}
+
+func digestIdentifierText(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// addDigestItem appends an item to the items of a digest, reducing values
+// and lists of values as MySQL does.
+func addDigestItem(items []digestItem, item digestItem) []digestItem {
+	last := func(i int) digestKind {
+		if i > len(items) {
+			return -1
+		}
+		return items[len(items)-i].kind
+	}
+	lastText := func(i int) string {
+		if i > len(items) {
+			return ""
+		}
+		return items[len(items)-i].text
+	}
+
+	switch {
+	case item.kind == digestValue:
+		// A sign before a value is dropped, unless it follows an operand
+		// and is a binary operator.
+		if sign := lastText(1); (sign == "-" || sign == "+") && last(1) == digestToken && !isDigestOperand(last(2), lastText(2)) {
+			items = items[:len(items)-1]
+		}
+		// ? , ? and ... , ? become ...
+		if lastText(1) == "," && last(1) == digestToken && (last(2) == digestValue || last(2) == digestValueList) {
+			items = items[:len(items)-2]
+			item.kind = digestValueList
+		}
+	case item.text == ")" && lastText(2) == "(" && last(2) == digestToken && (last(1) == digestValue || last(1) == digestValueList):
+		// ( ? ) becomes (?) and ( ... ) becomes (...).
+		item.kind, item.text = digestRow, ""
+		if last(1) == digestValueList {
+			item.kind = digestRowValues
+		}
+		items = items[:len(items)-2]
+		// (?) , (?) becomes (?) /* , ... */, and likewise for (...).
+		if lastText(1) == "," && last(1) == digestToken && (last(2) == item.kind || last(2) == item.kind+1) {
+			items = items[:len(items)-2]
+			item.kind++
+		}
+	}
+	return append(items, item)
+}
+
+// isDigestOperand returns whether a digest item ends an operand, after
+// which + and - are binary operators.
+func isDigestOperand(kind digestKind, text string) bool {
+	switch kind {
+	case -1:
+		return false
+	case digestToken:
+		return text == ")" || text == "NULL"
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest(t *testing.T) {
+	testcases := []struct {
+		input  string
+		output string
+	}{{
+		input:  "select * from t where id = 1",
+		output: "SELECT * FROM `t` WHERE `id` = ?",
+	}, {
+		input:  "SELECT a, b FROM db1.t1 WHERE name = 'x' AND c <> -5",
+		output: "SELECT `a` , `b` FROM `db1` . `t1` WHERE `name` = ? AND `c` != ?",
+	}, {
+		input:  "select a - 1, b from t",
+		output: "SELECT `a` - ? , `b` FROM `t`",
+	}, {
+		input:  "select * from t where id in (1, 2, 3)",
+		output: "SELECT * FROM `t` WHERE `id` IN (...)",
+	}, {
+		input:  "select * from t where id in (1)",
+		output: "SELECT * FROM `t` WHERE `id` IN (?)",
+	}, {
+		input:  "insert into t (a, b) values (1, 'a'), (2, 'b'), (3, 'c')",
+		output: "INSERT INTO `t` ( `a` , `b` ) VALUES (...) /* , ... */",
+	}, {
+		input:  "insert into t values (1), (2)",
+		output: "INSERT INTO `t` VALUES (?) /* , ... */",
+	}, {
+		input:  "select 1, 2",
+		output: "SELECT ...",
+	}, {
+		input:  "select count(*), upper(name) from `my table` /* comment */ where deleted_at is null and x = null",
+		output: "SELECT COUNT ( * ) , `upper` ( `name` ) FROM `my table` WHERE `deleted_at` IS NULL AND `x` = ?",
+	}, {
+		input:  "select a from t where b is not null limit 10, 20",
+		output: "SELECT `a` FROM `t` WHERE `b` IS NOT NULL LIMIT ...",
+	}, {
+		input:  "select @@version_comment limit 1",
+		output: "SELECT @@`version_comment` LIMIT ?",
+	}, {
+		input:  "set @a := 1, @@session.sql_mode = ''",
+		output: "SET @`a` := ? , @@SESSION . `sql_mode` = ?",
+	}, {
+		input:  "select * from t where a = ? and b <= :name; select 2",
+		output: "SELECT * FROM `t` WHERE `a` = ? AND `b` <= ?",
+	}, {
+		input:  "select _utf8mb4'x', x'ff', 0x1f, b'1', 1.5e3",
+		output: "SELECT _utf8mb4 ...",
+	}}
+
+	parser := NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.input, func(t *testing.T) {
+			digest, err := parser.Digest(tcase.input)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.output, digest.Text)
+		})
+	}
+}
+
+func TestDigestHash(t *testing.T) {
+	parser := NewTestParser()
+	a, err := parser.Digest("select * from t where id = 1")
+	require.NoError(t, err)
+	b, err := parser.Digest("SELECT *\nFROM t -- by id\nWHERE id = 42")
+	require.NoError(t, err)
+	c, err := parser.Digest("select * from t where id = 'x' or 1")
+	require.NoError(t, err)
+	assert.Len(t, a.Hash, 64)
+	assert.Equal(t, a.Hash, b.Hash)
+	assert.NotEqual(t, a.Hash, c.Hash)
+
+	parser, err = New(Options{MySQLServerVersion: "5.7.40"})
+	require.NoError(t, err)
+	d, err := parser.Digest("select * from t where id = 1")
+	require.NoError(t, err)
+	assert.Equal(t, a.Text, d.Text)
+	assert.Len(t, d.Hash, 32)
+}
+
+func TestDigestErrors(t *testing.T) {
+	_, err := NewTestParser().Digest("select 'abc")
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gogen
+
+import (
+	"fmt"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/ptr"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+	"github.com/redhajuanda/sqlparser/schema"
+	"github.com/redhajuanda/sqlparser/semantics"
+)
+
+// Column is a column of the result set of a query, or the value a bind
+// variable stands for.
+type Column struct {
+	// Name is the name of the column in the result set. For positional bind
+	// variables, it is the name semantics.Parameters suggests after the
+	// column the value is compared to or stored in, or empty.
+	Name string
+	// Type is the type of the column, or nil if it cannot be inferred.
+	Type     *sqlparser.ColumnType
+	Nullable bool
+}
+
+// boolType is the type of boolean values.
+var boolType = &sqlparser.ColumnType{Type: "tinyint", Length: ptr.Of(1)}
+
+// typeNames are the column types the types of values are written as.
+var typeNames = map[sqltypes.Type]string{
+	sqltypes.Int8:      "tinyint",
+	sqltypes.Uint8:     "tinyint",
+	sqltypes.Int16:     "smallint",
+	sqltypes.Uint16:    "smallint",
+	sqltypes.Int24:     "mediumint",
+	sqltypes.Uint24:    "mediumint",
+	sqltypes.Int32:     "int",
+	sqltypes.Uint32:    "int",
+	sqltypes.Int64:     "bigint",
+	sqltypes.Uint64:    "bigint",
+	sqltypes.Float32:   "float",
+	sqltypes.Float64:   "double",
+	sqltypes.Decimal:   "decimal",
+	sqltypes.Date:      "date",
+	sqltypes.Time:      "time",
+	sqltypes.Datetime:  "datetime",
+	sqltypes.Timestamp: "timestamp",
+	sqltypes.Year:      "year",
+	sqltypes.Char:      "char",
+	sqltypes.VarChar:   "varchar",
+	sqltypes.Text:      "text",
+	sqltypes.Binary:    "binary",
+	sqltypes.VarBinary: "varbinary",
+	sqltypes.Blob:      "blob",
+	sqltypes.Bit:       "bit",
+	sqltypes.Enum:      "enum",
+	sqltypes.Set:       "set",
+	sqltypes.TypeJSON:  "json",
+	sqltypes.Geometry:  "geometry",
+	sqltypes.Vector:    "vector",
+}
+
+// analyze binds a statement to a schema. It returns the result columns of
+// the statement, and the column each bind variable is compared to or
+// stored in, by name.
+func analyze(s *schema.Schema, stmt sqlparser.Statement) ([]*Column, map[string]*Column, error) {
+	switch stmt.(type) {
+	case sqlparser.SelectStatement, *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete:
+	default:
+		return nil, nil, fmt.Errorf("unsupported statement: %s", sqlparser.CanonicalString(stmt))
+	}
+	bindings, err := semantics.Bind(s, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	types := semantics.NewTypes(s, bindings)
+
+	var cols []*Column
+	if sel, ok := stmt.(sqlparser.SelectStatement); ok {
+		for _, col := range bindings.Result(sel) {
+			typ := types.Column(col)
+			c := &Column{Name: col.Name, Type: sqlType(typ), Nullable: typ.Nullable}
+			if c.Type != nil && isBoolean(bindings, col, map[*semantics.Column]bool{}) {
+				c.Type = boolType
+			}
+			cols = append(cols, c)
+		}
+	}
+
+	params, err := semantics.Parameters(s, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	args := map[string]*Column{}
+	for _, param := range params {
+		col := &Column{Type: sqlType(param.Type), Nullable: param.Type.Nullable}
+		if param.Suggested != param.Name {
+			col.Name = param.Suggested
+		}
+		args[param.Name] = col
+	}
+	return cols, args, nil
+}
+
+// sqlType returns the column type of the values of a type, or nil if the
+// type is unknown.
+func sqlType(typ semantics.Type) *sqlparser.ColumnType {
+	name, ok := typeNames[typ.Type]
+	if !ok {
+		return nil
+	}
+	return &sqlparser.ColumnType{Type: name, Unsigned: sqltypes.IsUnsigned(typ.Type)}
+}
+
+// isBoolean returns whether a column holds booleans: a BOOL or TINYINT(1)
+// column, or a column of a query whose expressions are conditions or
+// boolean columns.
+func isBoolean(b *semantics.Bindings, col *semantics.Column, seen map[*semantics.Column]bool) bool {
+	if col.Definition != nil {
+		return isBool(col.Definition.Type)
+	}
+	if seen[col] || len(col.Exprs) == 0 {
+		return false
+	}
+	seen[col] = true
+	for _, expr := range col.Exprs {
+		switch expr := expr.(type) {
+		case *sqlparser.ComparisonExpr, *sqlparser.BetweenExpr, *sqlparser.IsExpr, *sqlparser.ExistsExpr,
+			*sqlparser.AndExpr, *sqlparser.OrExpr, *sqlparser.XorExpr, *sqlparser.NotExpr, sqlparser.BoolVal:
+		case *sqlparser.ColName:
+			ref := b.Column(expr)
+			if ref == nil || !isBoolean(b, ref, seen) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gogen generates Go code from parsed SQL, such as model structs for
+// the tables defined by CREATE TABLE statements.
+package gogen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+)
+
+const (
+	sqlPkg    = "database/sql"
+	driverPkg = "database/sql/driver"
+)
+
+// initialisms are the name parts written in upper case, as golint wants
+// them.
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DB": true, "DNS": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "SKU": true, "SQL": true, "SSH": true, "TCP": true, "TTL": true,
+	"UDP": true, "UI": true, "URI": true, "URL": true, "UTF8": true, "UUID": true, "XML": true,
+}
+
+// ModelOptions configures the generated models.
+type ModelOptions struct {
+	// Package is the name of the generated package. It defaults to models.
+	Package string
+	// Tags are the keys of the struct tags set to the column name of each
+	// field. They default to db and json.
+	Tags []string
+}
+
+// Models returns a Go file with a struct for each table. Each struct has a
+// field per column and methods returning the table name, its columns and
+// its primary key, and scanning and returning the column values in order.
+//
+// Columns map to Go types through their query type:
+//
+//   - integers to the integer type of their size and signedness, and
+//     BOOL and TINYINT(1) to bool;
+//   - DECIMAL and TIME to string, as they do not fit a Go numeric or
+//     time.Time value;
+//   - DATE, DATETIME and TIMESTAMP to time.Time;
+//   - JSON to json.RawMessage, and binary, BIT and spatial types to []byte;
+//   - ENUM and SET to a named type with a constant per member, which
+//     implements sql.Scanner and driver.Valuer.
+//
+// Nullable columns use sql.Null, except for the slice types, which are nil
+// for NULL.
+func Models(opts ModelOptions, creates ...*sqlparser.CreateTable) (*jen.File, error) {
+	if opts.Package == "" {
+		opts.Package = "models"
+	}
+	if opts.Tags == nil {
+		opts.Tags = []string{"db", "json"}
+	}
+	f := jen.NewFile(opts.Package)
+	f.HeaderComment("Code generated by gogen. DO NOT EDIT.")
+	for _, create := range creates {
+		if create.TableSpec == nil {
+			return nil, fmt.Errorf("table %s: no column definitions", sqlparser.String(create.Table))
+		}
+		if err := model(f, opts, create); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// model adds the struct of a table and its methods to the file.
+func model(f *jen.File, opts ModelOptions, create *sqlparser.CreateTable) error {
+	table := create.Table.Name.String()
+	name := goName(table)
+	spec := create.TableSpec
+
+	var fields, scans, values, columns []jen.Code
+	// Fields must differ from each other and from the methods of the
+	// struct.
+	used := map[string]bool{"TableName": true, "Columns": true, "PrimaryKey": true, "ScanRow": true, "Values": true}
+	for _, col := range spec.Columns {
+		field := goName(col.Name.String())
+		for i := 2; used[field]; i++ {
+			field = fmt.Sprintf("%s%d", goName(col.Name.String()), i)
+		}
+		used[field] = true
+		typ, err := columnType(f, name+field, col.Type)
+		if err != nil {
+			return fmt.Errorf("table %s: column %s: %w", table, col.Name.String(), err)
+		}
+		// NULL is a nil SET, like the slice types.
+		if isNullable(col, spec) && col.Type.SQLType() != sqltypes.Set {
+			typ = nullable(typ, col.Type)
+		}
+		tags := map[string]string{}
+		for _, tag := range opts.Tags {
+			tags[tag] = col.Name.String()
+		}
+		code := jen.Id(field).Add(typ).Tag(tags)
+		if comment := columnComment(col); comment != "" {
+			code.Comment(comment)
+		}
+		fields = append(fields, code)
+		scans = append(scans, jen.Op("&").Id("m").Dot(field))
+		values = append(values, jen.Id("m").Dot(field))
+		columns = append(columns, jen.Lit(col.Name.String()))
+	}
+
+	f.Commentf("%s is a row of table %s.", name, table)
+	if comment := tableComment(spec); comment != "" {
+		f.Comment(comment)
+	}
+	f.Type().Id(name).Struct(fields...)
+
+	f.Comment("TableName returns the name of the table.")
+	f.Func().Params(jen.Id(name)).Id("TableName").Params().String().Block(
+		jen.Return(jen.Lit(table)),
+	)
+	f.Comment("Columns returns the columns of the table in order.")
+	f.Func().Params(jen.Id(name)).Id("Columns").Params().Index().String().Block(
+		jen.Return(jen.Index().String().Values(columns...)),
+	)
+	f.Comment("PrimaryKey returns the columns of the primary key of the table.")
+	pk := jen.Nil()
+	if cols := primaryKey(spec); len(cols) > 0 {
+		var lits []jen.Code
+		for _, col := range cols {
+			lits = append(lits, jen.Lit(col))
+		}
+		pk = jen.Index().String().Values(lits...)
+	}
+	f.Func().Params(jen.Id(name)).Id("PrimaryKey").Params().Index().String().Block(
+		jen.Return(pk),
+	)
+	f.Comment("ScanRow scans a row holding the columns of the table in order.")
+	f.Func().Params(jen.Id("m").Op("*").Id(name)).Id("ScanRow").Params(
+		jen.Id("row").Interface(jen.Id("Scan").Params(jen.Id("dest").Op("...").Any()).Error()),
+	).Error().Block(
+		jen.Return(jen.Id("row").Dot("Scan").Call(scans...)),
+	)
+	f.Comment("Values returns the values of the columns of the table in order.")
+	f.Func().Params(jen.Id("m").Op("*").Id(name)).Id("Values").Params().Index().Any().Block(
+		jen.Return(jen.Index().Any().Values(values...)),
+	)
+	return nil
+}
+
+// columnType returns the Go type of a column, adding the named type of an
+// ENUM or SET column to the file.
+func columnType(f *jen.File, name string, ct *sqlparser.ColumnType) (*jen.Statement, error) {
+	switch ct.SQLType() {
+	case sqltypes.Enum:
+		return enumType(f, name, ct)
+	case sqltypes.Set:
+		return setType(f, name, ct)
+	}
+	return GoType(ct)
+}
+
+// GoType returns the Go type values of a column type scan into when the
+// column is not nullable. ENUM and SET columns are strings.
+func GoType(ct *sqlparser.ColumnType) (*jen.Statement, error) {
+	if isBool(ct) {
+		return jen.Bool(), nil
+	}
+	switch ct.SQLType() {
+	case sqltypes.Int8:
+		return jen.Int8(), nil
+	case sqltypes.Uint8:
+		return jen.Uint8(), nil
+	case sqltypes.Int16, sqltypes.Year:
+		return jen.Int16(), nil
+	case sqltypes.Uint16:
+		return jen.Uint16(), nil
+	case sqltypes.Int24, sqltypes.Int32:
+		return jen.Int32(), nil
+	case sqltypes.Uint24, sqltypes.Uint32:
+		return jen.Uint32(), nil
+	case sqltypes.Int64:
+		return jen.Int64(), nil
+	case sqltypes.Uint64:
+		return jen.Uint64(), nil
+	case sqltypes.Float32:
+		return jen.Float32(), nil
+	case sqltypes.Float64:
+		return jen.Float64(), nil
+	case sqltypes.Decimal, sqltypes.Time, sqltypes.Char, sqltypes.VarChar, sqltypes.Text, sqltypes.Enum, sqltypes.Set:
+		return jen.String(), nil
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+		return jen.Qual("time", "Time"), nil
+	case sqltypes.TypeJSON:
+		return jen.Qual("encoding/json", "RawMessage"), nil
+	case sqltypes.Binary, sqltypes.VarBinary, sqltypes.Blob, sqltypes.Bit, sqltypes.Geometry, sqltypes.Vector:
+		return jen.Index().Byte(), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", ct.Type)
+}
+
+// isBool returns whether a column type holds booleans: BOOL, BOOLEAN and
+// TINYINT(1).
+func isBool(ct *sqlparser.ColumnType) bool {
+	switch strings.ToLower(ct.Type) {
+	case "bool", "boolean":
+		return true
+	case "tinyint":
+		return ct.Length != nil && *ct.Length == 1
+	}
+	return false
+}
+
+// isNullable returns whether a column can hold NULL. Columns are nullable
+// unless they are NOT NULL or part of the primary key.
+func isNullable(col *sqlparser.ColumnDefinition, spec *sqlparser.TableSpec) bool {
+	if opts := col.Type.Options; opts != nil && opts.Null != nil {
+		return *opts.Null
+	}
+	for _, pk := range primaryKey(spec) {
+		if col.Name.EqualString(pk) {
+			return false
+		}
+	}
+	return true
+}
+
+// nullable wraps the Go type of a nullable column in sql.Null, unless NULL
+// is already represented by nil.
+func nullable(typ *jen.Statement, ct *sqlparser.ColumnType) *jen.Statement {
+	switch ct.SQLType() {
+	case sqltypes.TypeJSON, sqltypes.Binary, sqltypes.VarBinary, sqltypes.Blob, sqltypes.Bit, sqltypes.Geometry,
+		sqltypes.Vector:
+		return typ
+	}
+	return jen.Qual(sqlPkg, "Null").Types(typ)
+}
+
+// primaryKey returns the columns of the primary key of a table, declared
+// either as a column option or as an index.
+func primaryKey(spec *sqlparser.TableSpec) []string {
+	for _, index := range spec.Indexes {
+		if index.Info.Type == sqlparser.IndexTypePrimary {
+			var cols []string
+			for _, col := range index.Columns {
+				cols = append(cols, col.Column.String())
+			}
+			return cols
+		}
+	}
+	for _, col := range spec.Columns {
+		if col.Type.Options != nil && col.Type.Options.KeyOpt == sqlparser.ColKeyPrimary {
+			return []string{col.Name.String()}
+		}
+	}
+	return nil
+}
+
+// enumType adds a named string type with a constant per member for an ENUM
+// column.
+func enumType(f *jen.File, name string, ct *sqlparser.ColumnType) (*jen.Statement, error) {
+	members, err := enumMembers(ct)
+	if err != nil {
+		return nil, err
+	}
+	f.Commentf("%s is a member of an ENUM column.", name)
+	f.Type().Id(name).String()
+	var consts, cases []jen.Code
+	for i, member := range members {
+		id := name + memberName(member, i, members)
+		consts = append(consts, jen.Id(id).Id(name).Op("=").Lit(member))
+		cases = append(cases, jen.Id(id))
+	}
+	f.Const().Defs(consts...)
+
+	f.Comment("Valid returns whether the value is a member of the ENUM.")
+	f.Func().Params(jen.Id("e").Id(name)).Id("Valid").Params().Bool().Block(
+		jen.Switch(jen.Id("e")).Block(jen.Case(cases...).Block(jen.Return(jen.True()))),
+		jen.Return(jen.False()),
+	)
+	scanner(f, name, "e",
+		jen.Op("*").Id("e").Op("=").Lit(""),
+		jen.Op("*").Id("e").Op("=").Id(name).Call(jen.Id("src")))
+	f.Comment("Value implements driver.Valuer.")
+	f.Func().Params(jen.Id("e").Id(name)).Id("Value").Params().Params(jen.Qual(driverPkg, "Value"), jen.Error()).Block(
+		jen.Return(jen.String().Call(jen.Id("e")), jen.Nil()),
+	)
+	return jen.Id(name), nil
+}
+
+// setType adds a named string slice type with a constant per member for a
+// SET column.
+func setType(f *jen.File, name string, ct *sqlparser.ColumnType) (*jen.Statement, error) {
+	members, err := enumMembers(ct)
+	if err != nil {
+		return nil, err
+	}
+	f.Commentf("%s holds the members of a SET column.", name)
+	f.Type().Id(name).Index().String()
+	var consts []jen.Code
+	for i, member := range members {
+		consts = append(consts, jen.Id(name+memberName(member, i, members)).Op("=").Lit(member))
+	}
+	f.Const().Defs(consts...)
+
+	scanner(f, name, "s",
+		jen.Op("*").Id("s").Op("=").Nil(),
+		jen.If(jen.Id("src").Op("==").Lit("")).Block(
+			jen.Op("*").Id("s").Op("=").Id(name).Values(),
+			jen.Return(jen.Nil()),
+		),
+		jen.Op("*").Id("s").Op("=").Qual("strings", "Split").Call(jen.Id("src"), jen.Lit(",")))
+	f.Comment("Value implements driver.Valuer.")
+	f.Func().Params(jen.Id("s").Id(name)).Id("Value").Params().Params(jen.Qual(driverPkg, "Value"), jen.Error()).Block(
+		jen.If(jen.Id("s").Op("==").Nil()).Block(jen.Return(jen.Nil(), jen.Nil())),
+		jen.Return(jen.Qual("strings", "Join").Call(jen.Id("s"), jen.Lit(",")), jen.Nil()),
+	)
+	return jen.Id(name), nil
+}
+
+// scanner adds a Scan method to a named type with the given receiver. It
+// converts strings and byte slices to a string named src and stores it with
+// the given statements; NULL is stored with onNull.
+func scanner(f *jen.File, name, recv string, onNull jen.Code, store ...jen.Code) {
+	body := []jen.Code{
+		jen.Var().Id("src").String(),
+		jen.Switch(jen.Id("v").Op(":=").Id("value").Assert(jen.Type())).Block(
+			jen.Case(jen.String()).Block(jen.Id("src").Op("=").Id("v")),
+			jen.Case(jen.Index().Byte()).Block(jen.Id("src").Op("=").String().Call(jen.Id("v"))),
+			jen.Case(jen.Nil()).Block(onNull, jen.Return(jen.Nil())),
+			jen.Default().Block(jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("cannot scan %T into "+name), jen.Id("value")))),
+		),
+	}
+	body = append(body, store...)
+	body = append(body, jen.Return(jen.Nil()))
+	f.Comment("Scan implements sql.Scanner.")
+	f.Func().Params(jen.Id(recv).Op("*").Id(name)).Id("Scan").Params(jen.Id("value").Any()).Error().Block(body...)
+}
+
+// enumMembers returns the unquoted members of an ENUM or SET column.
+func enumMembers(ct *sqlparser.ColumnType) ([]string, error) {
+	members := make([]string, 0, len(ct.EnumValues))
+	for _, value := range ct.EnumValues {
+		member, err := sqltypes.DecodeStringSQL(value)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// memberName returns the suffix of the constant of an ENUM or SET member.
+// Members whose names collide with an earlier member get their position
+// appended.
+func memberName(member string, i int, members []string) string {
+	name := func(member string) string {
+		if member == "" {
+			return "Empty"
+		}
+		return goName(member)
+	}
+	for j := 0; j < i; j++ {
+		if name(members[j]) == name(member) {
+			return fmt.Sprintf("%s%d", name(member), i+1)
+		}
+	}
+	return name(member)
+}
+
+// goName returns the exported Go name of a table, column or member name:
+// its words in camel case, with initialisms in upper case.
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	if b.Len() == 0 || unicode.IsDigit([]rune(b.String())[0]) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+func columnComment(col *sqlparser.ColumnDefinition) string {
+	if col.Type.Options == nil || col.Type.Options.Comment == nil {
+		return ""
+	}
+	return col.Type.Options.Comment.Val
+}
+
+func tableComment(spec *sqlparser.TableSpec) string {
+	for _, option := range spec.Options {
+		if strings.EqualFold(option.Name, "comment") && option.Value != nil {
+			return option.Value.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gogen
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func parseCreateTables(t *testing.T, sql string) []*sqlparser.CreateTable {
+	t.Helper()
+	parser := sqlparser.NewTestParser()
+	pieces, err := parser.SplitStatementToPieces(sql)
+	require.NoError(t, err)
+	var creates []*sqlparser.CreateTable
+	for _, piece := range pieces {
+		stmt, err := parser.ParseStrictDDL(piece)
+		require.NoError(t, err)
+		creates = append(creates, stmt.(*sqlparser.CreateTable))
+	}
+	return creates
+}
+
+func TestModels(t *testing.T) {
+	creates := parseCreateTables(t, `
+		create table customer (
+			id int unsigned not null auto_increment,
+			email varchar(100) not null comment 'login',
+			is_admin bool not null default false,
+			api_key binary(16),
+			born date,
+			score double,
+			primary key (id)
+		) comment 'registered customers';
+		create table order_items (
+			order_id bigint,
+			line smallint,
+			status enum('new', 'in-progress', '', 'in progress') not null default 'new',
+			flags set('gift', 'rush'),
+			price decimal(10, 2) not null,
+			meta json,
+			created_at datetime not null default current_timestamp,
+			delivered_at timestamp null,
+			duration time,
+			primary key (order_id, line)
+		);
+		create table audit (message text)`)
+
+	f, err := Models(ModelOptions{}, creates...)
+	require.NoError(t, err)
+	code := fmt.Sprintf("%#v", f)
+	_, err = parser.ParseFile(token.NewFileSet(), "models.go", code, parser.AllErrors)
+	require.NoError(t, err)
+
+	golden, err := os.ReadFile("testdata/models.go.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), code)
+}
+
+func TestModelsOptions(t *testing.T) {
+	creates := parseCreateTables(t, "create table t (id int primary key, `2fa` varchar(10))")
+	f, err := Models(ModelOptions{Package: "db", Tags: []string{"col"}}, creates...)
+	require.NoError(t, err)
+	code := fmt.Sprintf("%#v", f)
+	assert.Contains(t, code, "package db\n")
+	assert.Contains(t, code, "ID   int32            `col:\"id\"`\n")
+	assert.Contains(t, code, "X2fa sql.Null[string] `col:\"2fa\"`\n")
+}
+
+func TestModelsFieldNames(t *testing.T) {
+	creates := parseCreateTables(t, "create table t (user_id int not null, userID int not null, `values` int not null)")
+	f, err := Models(ModelOptions{Package: "db", Tags: []string{"db"}}, creates...)
+	require.NoError(t, err)
+	code := fmt.Sprintf("%#v", f)
+	_, err = parser.ParseFile(token.NewFileSet(), "models.go", code, parser.AllErrors)
+	require.NoError(t, err)
+	assert.Contains(t, code, "UserID  int32 `db:\"user_id\"`\n")
+	assert.Contains(t, code, "UserID2 int32 `db:\"userID\"`\n")
+	assert.Contains(t, code, "Values2 int32 `db:\"values\"`\n")
+	assert.Contains(t, code, "row.Scan(&m.UserID, &m.UserID2, &m.Values2)")
+}
+
+func TestGoName(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{in: "id", out: "ID"},
+		{in: "user_id", out: "UserID"},
+		{in: "api-url", out: "APIURL"},
+		{in: "createdAt", out: "CreatedAt"},
+		{in: "order items", out: "OrderItems"},
+		{in: "2fa", out: "X2fa"},
+		{in: "_", out: "X"},
+	}
+	for _, tcase := range testcases {
+		t.Run(tcase.in, func(t *testing.T) {
+			assert.Equal(t, tcase.out, goName(tcase.in))
+		})
+	}
+}
@@ -0,0 +1,512 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gogen
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/mysql/sqlerror"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// QueryCommand is the kind of function generated for a query, given after
+// its name in the annotation.
+type QueryCommand string
+
+const (
+	// QueryOne returns the only row of the result set.
+	QueryOne QueryCommand = "one"
+	// QueryMany returns all the rows of the result set.
+	QueryMany QueryCommand = "many"
+	// QueryExec returns only an error.
+	QueryExec QueryCommand = "exec"
+	// QueryExecResult returns the sql.Result.
+	QueryExecResult QueryCommand = "execresult"
+	// QueryExecRows returns the number of affected rows.
+	QueryExecRows QueryCommand = "execrows"
+	// QueryExecLastID returns the last insert ID.
+	QueryExecLastID QueryCommand = "execlastid"
+)
+
+// Query is an annotated query of a SQL file.
+type Query struct {
+	Name    string
+	Command QueryCommand
+	// SQL is the query with a ? placeholder for each bind variable, as the
+	// MySQL drivers for database/sql take it.
+	SQL string
+	// Params are the parameters of the generated function, one per bind
+	// variable name. Positional bind variables are named after the column
+	// they are compared to or stored in, with its qualifier if any.
+	Params []*Param
+	// Args are the indexes in Params of the value of each placeholder of
+	// SQL. A named bind variable used twice has two placeholders.
+	Args []int
+	// Columns are the columns of the result set.
+	Columns []*Column
+	// Pos is the position of the annotation.
+	Pos token.Position
+}
+
+// Param is a parameter of the function generated for a query.
+type Param struct {
+	// Name is the name of the Go parameter.
+	Name     string
+	Type     *sqlparser.ColumnType
+	Nullable bool
+}
+
+// PositionError is an error at a position of a SQL file.
+type PositionError struct {
+	Pos token.Position
+	// Query is the name of the query the error is in, if any.
+	Query string
+	Err   error
+}
+
+func (e *PositionError) Error() string {
+	if e.Query != "" {
+		return fmt.Sprintf("%s: %s: %s", e.Pos, e.Query, e.Err.Error())
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Err.Error())
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// annotation matches the line naming the query that follows it.
+var annotation = regexp.MustCompile(`^--\s*name:\s*(\S+)\s+:(\S+)\s*$`)
+
+var identifier = regexp.MustCompile(`^[\pL_][\pL\pN_]*$`)
+
+// ParseQueries returns the queries of a SQL file. Each query follows a
+// comment line giving its name and command, as in
+//
+//	-- name: GetUser :one
+//	SELECT id, email FROM users WHERE id = ?;
+//
+// The columns of the queries are resolved against the schema to infer the
+// types of the result columns and of the bind variables. Referring to an
+// unknown table or column is an error at its position in the file.
+func ParseQueries(parser *sqlparser.Parser, s *schema.Schema, filename, src string) ([]*Query, error) {
+	type block struct {
+		name, command string
+		pos           token.Position
+		offset        int
+		sql           string
+	}
+	var blocks []*block
+	offset := 0
+	for i, line := range strings.SplitAfter(src, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if m := annotation.FindStringSubmatch(trimmed); m != nil {
+			pos := token.Position{Filename: filename, Offset: offset, Line: i + 1, Column: strings.Index(line, "--") + 1}
+			blocks = append(blocks, &block{name: m[1], command: m[2], pos: pos, offset: offset + len(line)})
+		} else if len(blocks) > 0 {
+			blocks[len(blocks)-1].sql += line
+		} else if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+			return nil, &PositionError{
+				Pos: token.Position{Filename: filename, Offset: offset, Line: i + 1, Column: 1},
+				Err: errors.New("query without a name annotation"),
+			}
+		}
+		offset += len(line)
+	}
+
+	var queries []*Query
+	names := map[string]bool{}
+	consts := map[string]string{}
+	for _, b := range blocks {
+		fail := func(offset int, err error) error {
+			return &PositionError{Pos: position(filename, src, b.offset+offset), Query: b.name, Err: err}
+		}
+		if !identifier.MatchString(b.name) {
+			return nil, &PositionError{Pos: b.pos, Err: fmt.Errorf("invalid query name %q", b.name)}
+		}
+		if names[b.name] {
+			return nil, &PositionError{Pos: b.pos, Err: fmt.Errorf("duplicate query name %s", b.name)}
+		}
+		names[b.name] = true
+		// The SQL of a query is a constant named after the query.
+		if other, ok := consts[lowerName(b.name)]; ok {
+			return nil, &PositionError{Pos: b.pos, Err: fmt.Errorf("query name %s conflicts with %s", b.name, other)}
+		}
+		consts[lowerName(b.name)] = b.name
+		query := &Query{Name: b.name, Command: QueryCommand(b.command), Pos: b.pos}
+		switch query.Command {
+		case QueryOne, QueryMany, QueryExec, QueryExecResult, QueryExecRows, QueryExecLastID:
+		default:
+			return nil, &PositionError{Pos: b.pos, Query: b.name, Err: fmt.Errorf("unknown command :%s", b.command)}
+		}
+
+		pieces, err := parser.SplitStatementToPieces(b.sql)
+		if err != nil {
+			return nil, fail(0, err)
+		}
+		if len(pieces) != 1 {
+			return nil, &PositionError{Pos: b.pos, Query: b.name, Err: fmt.Errorf("expected one statement, got %d", len(pieces))}
+		}
+		stmt, err := parser.Parse(pieces[0])
+		if err != nil {
+			return nil, fail(0, err)
+		}
+
+		var types map[string]*Column
+		query.Columns, types, err = analyze(s, stmt)
+		if err != nil {
+			return nil, fail(locate(parser, b.sql, err), err)
+		}
+		switch query.Command {
+		case QueryOne, QueryMany:
+			if len(query.Columns) == 0 {
+				return nil, &PositionError{Pos: b.pos, Query: b.name, Err: fmt.Errorf(":%s query returns no rows", query.Command)}
+			}
+		}
+
+		var args []string
+		var listArg int
+		query.SQL, args, listArg = placeholders(parser, b.sql)
+		if listArg >= 0 {
+			return nil, fail(listArg, errors.New("list bind variables are not supported"))
+		}
+		query.Params, query.Args = params(args, types, lowerName(query.Name))
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+// placeholders returns a query with ? placeholders for its bind variables,
+// and the names of the bind variables in order. It returns the offset of
+// the first list bind variable, or -1 if there are none.
+func placeholders(parser *sqlparser.Parser, sql string) (string, []string, int) {
+	var b strings.Builder
+	var names []string
+	last := 0
+	sql = strings.TrimRightFunc(sql, func(r rune) bool { return unicode.IsSpace(r) || r == ';' })
+	tkn := parser.NewStringTokenizer(sql)
+	for {
+		typ, val := tkn.Scan()
+		switch typ {
+		case 0, sqlparser.LEX_ERROR:
+			b.WriteString(sql[last:])
+			return strings.TrimSpace(b.String()), names, -1
+		case sqlparser.LIST_ARG:
+			return "", nil, tkn.Pos - len(val)
+		case sqlparser.VALUE_ARG:
+			start := tkn.Pos - len(val)
+			if sql[tkn.Pos-1] == '?' {
+				start = tkn.Pos - 1
+			}
+			b.WriteString(sql[last:start])
+			b.WriteByte('?')
+			last = tkn.Pos
+			names = append(names, strings.TrimPrefix(val, ":"))
+		}
+	}
+}
+
+// locate returns the offset in a query of the table or column an error is
+// about, or 0.
+func locate(parser *sqlparser.Parser, sql string, err error) int {
+	var sqlErr *sqlerror.SQLError
+	if !errors.As(err, &sqlErr) {
+		return 0
+	}
+	switch sqlErr.Num {
+	case sqlerror.ERBadFieldError, sqlerror.ERNonUniq, sqlerror.ERNoSuchTable:
+	default:
+		return 0
+	}
+	// The messages quote the column or table as written, qualified or not.
+	_, name, ok := strings.Cut(sqlErr.Message, "'")
+	if !ok {
+		return 0
+	}
+	name, _, _ = strings.Cut(name, "'")
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	tkn := parser.NewStringTokenizer(sql)
+	for end := 0; ; end = tkn.Pos {
+		typ, val := tkn.Scan()
+		switch typ {
+		case 0, sqlparser.LEX_ERROR:
+			return 0
+		case sqlparser.STRING, sqlparser.COMMENT, sqlparser.VALUE_ARG:
+			continue
+		}
+		if strings.EqualFold(val, name) {
+			return end + len(sql[end:tkn.Pos]) - len(strings.TrimLeft(sql[end:tkn.Pos], " \t\r\n"))
+		}
+	}
+}
+
+// position returns the position of an offset of a file.
+func position(filename, src string, offset int) token.Position {
+	line := strings.Count(src[:offset], "\n") + 1
+	column := offset - strings.LastIndexByte(src[:offset], '\n')
+	return token.Position{Filename: filename, Offset: offset, Line: line, Column: column}
+}
+
+// reservedNames are the names the generated functions use for receivers and
+// local variables, which parameters must not shadow.
+var reservedNames = map[string]bool{
+	"ctx": true, "q": true, "row": true, "rows": true, "err": true, "i": true, "items": true, "res": true,
+}
+
+// params returns the parameters of a query with the given bind variables in
+// order, and the index of the parameter of each bind variable. Parameters
+// must not shadow constName, the constant holding the SQL of the query.
+func params(names []string, types map[string]*Column, constName string) ([]*Param, []int) {
+	var params []*Param
+	var args []int
+	index := map[string]int{}
+	used := map[string]bool{constName: true}
+	for _, name := range names {
+		if i, ok := index[name]; ok {
+			args = append(args, i)
+			continue
+		}
+		param := &Param{}
+		goName := name
+		if isPositional(name) {
+			goName = "arg"
+		}
+		if col := types[name]; col != nil {
+			param.Type, param.Nullable = col.Type, col.Nullable
+			if isPositional(name) && col.Name != "" {
+				goName = col.Name
+			}
+		}
+		param.Name = uniqueName(lowerName(goName), used)
+		index[name] = len(params)
+		args = append(args, len(params))
+		params = append(params, param)
+	}
+	return params, args
+}
+
+// isPositional returns whether a bind variable name is the one the parser
+// gives to a ? placeholder.
+func isPositional(name string) bool {
+	return len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == ""
+}
+
+// uniqueName returns a name not used yet, appending a number if needed, and
+// marks it used.
+func uniqueName(name string, used map[string]bool) string {
+	if token.IsKeyword(name) || reservedNames[name] {
+		name += "Arg"
+	}
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// lowerName returns the unexported Go name of a name, with a leading
+// initialism in lower case.
+func lowerName(name string) string {
+	runes := []rune(goName(name))
+	n := 1
+	for n < len(runes) && unicode.IsUpper(runes[n]) && (n+1 == len(runes) || unicode.IsUpper(runes[n+1]) || unicode.IsDigit(runes[n+1])) {
+		n++
+	}
+	return strings.ToLower(string(runes[:n])) + string(runes[n:])
+}
+
+// QueryOptions configures the generated query functions.
+type QueryOptions struct {
+	// Package is the name of the generated package. It defaults to
+	// queries.
+	Package string
+	// Tags are the keys of the struct tags set to the column name of each
+	// field of the row structs. They default to db and json.
+	Tags []string
+}
+
+// Queries returns a Go file with a method of the Queries type running each
+// query with database/sql. Queries with more than one result column return
+// a struct named after the query with a Row suffix; queries with one return
+// its type. Nullable columns and parameters use sql.Null, and values of
+// unknown type use any.
+func Queries(opts QueryOptions, queries ...*Query) (*jen.File, error) {
+	if opts.Package == "" {
+		opts.Package = "queries"
+	}
+	if opts.Tags == nil {
+		opts.Tags = []string{"db", "json"}
+	}
+	f := jen.NewFile(opts.Package)
+	f.HeaderComment("Code generated by gogen. DO NOT EDIT.")
+
+	f.Comment("DBTX is the subset of *sql.DB, *sql.Conn and *sql.Tx the queries use.")
+	f.Type().Id("DBTX").Interface(
+		jen.Id("ExecContext").Params(jen.Qual("context", "Context"), jen.String(), jen.Op("...").Any()).Params(jen.Qual(sqlPkg, "Result"), jen.Error()),
+		jen.Id("QueryContext").Params(jen.Qual("context", "Context"), jen.String(), jen.Op("...").Any()).Params(jen.Op("*").Qual(sqlPkg, "Rows"), jen.Error()),
+		jen.Id("QueryRowContext").Params(jen.Qual("context", "Context"), jen.String(), jen.Op("...").Any()).Op("*").Qual(sqlPkg, "Row"),
+	)
+	f.Comment("Queries runs the queries on a database handle.")
+	f.Type().Id("Queries").Struct(jen.Id("db").Id("DBTX"))
+	f.Comment("New returns the queries running on db.")
+	f.Func().Id("New").Params(jen.Id("db").Id("DBTX")).Op("*").Id("Queries").Block(
+		jen.Return(jen.Op("&").Id("Queries").Values(jen.Dict{jen.Id("db"): jen.Id("db")})),
+	)
+
+	for _, query := range queries {
+		if err := queryFunc(f, opts, query); err != nil {
+			return nil, &PositionError{Pos: query.Pos, Query: query.Name, Err: err}
+		}
+	}
+	return f, nil
+}
+
+// queryFunc adds the constant holding the SQL of a query, the struct of its
+// rows and the method running it to the file.
+func queryFunc(f *jen.File, opts QueryOptions, query *Query) error {
+	name := query.Name
+	constName := lowerName(name)
+	f.Const().Id(constName).Op("=").Lit(query.SQL)
+
+	params := []jen.Code{jen.Id("ctx").Qual("context", "Context")}
+	for _, param := range query.Params {
+		typ, err := valueType(param.Type, param.Nullable)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", param.Name, err)
+		}
+		params = append(params, jen.Id(param.Name).Add(typ))
+	}
+	args := []jen.Code{jen.Id("ctx"), jen.Id(constName)}
+	for _, i := range query.Args {
+		args = append(args, jen.Id(query.Params[i].Name))
+	}
+
+	// rowType is the type of the rows, and dest the pointers i is scanned
+	// into.
+	var rowType *jen.Statement
+	var dest []jen.Code
+	switch query.Command {
+	case QueryOne, QueryMany:
+		if len(query.Columns) == 1 {
+			typ, err := valueType(query.Columns[0].Type, query.Columns[0].Nullable)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", query.Columns[0].Name, err)
+			}
+			rowType = typ
+			dest = []jen.Code{jen.Op("&").Id("i")}
+			break
+		}
+		rowType = jen.Id(name + "Row")
+		var fields []jen.Code
+		used := map[string]bool{}
+		for _, col := range query.Columns {
+			typ, err := valueType(col.Type, col.Nullable)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", col.Name, err)
+			}
+			field := goName(col.Name)
+			for i := 2; used[field]; i++ {
+				field = fmt.Sprintf("%s%d", goName(col.Name), i)
+			}
+			used[field] = true
+			tags := map[string]string{}
+			for _, tag := range opts.Tags {
+				tags[tag] = col.Name
+			}
+			fields = append(fields, jen.Id(field).Add(typ).Tag(tags))
+			dest = append(dest, jen.Op("&").Id("i").Dot(field))
+		}
+		f.Commentf("%s is a row returned by %s.", name+"Row", name)
+		f.Type().Id(name + "Row").Struct(fields...)
+	}
+
+	recv := jen.Id("q").Op("*").Id("Queries")
+	switch query.Command {
+	case QueryOne:
+		f.Func().Params(recv).Id(name).Params(params...).Params(rowType.Clone(), jen.Error()).Block(
+			jen.Id("row").Op(":=").Id("q").Dot("db").Dot("QueryRowContext").Call(args...),
+			jen.Var().Id("i").Add(rowType.Clone()),
+			jen.Err().Op(":=").Id("row").Dot("Scan").Call(dest...),
+			jen.Return(jen.Id("i"), jen.Err()),
+		)
+	case QueryMany:
+		f.Func().Params(recv).Id(name).Params(params...).Params(jen.Index().Add(rowType.Clone()), jen.Error()).Block(
+			jen.List(jen.Id("rows"), jen.Err()).Op(":=").Id("q").Dot("db").Dot("QueryContext").Call(args...),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+			jen.Defer().Id("rows").Dot("Close").Call(),
+			jen.Var().Id("items").Index().Add(rowType.Clone()),
+			jen.For(jen.Id("rows").Dot("Next").Call()).Block(
+				jen.Var().Id("i").Add(rowType.Clone()),
+				jen.If(jen.Err().Op(":=").Id("rows").Dot("Scan").Call(dest...), jen.Err().Op("!=").Nil()).Block(
+					jen.Return(jen.Nil(), jen.Err()),
+				),
+				jen.Id("items").Op("=").Append(jen.Id("items"), jen.Id("i")),
+			),
+			jen.If(jen.Err().Op(":=").Id("rows").Dot("Err").Call(), jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Return(jen.Id("items"), jen.Nil()),
+		)
+	case QueryExec:
+		f.Func().Params(recv).Id(name).Params(params...).Error().Block(
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("q").Dot("db").Dot("ExecContext").Call(args...),
+			jen.Return(jen.Err()),
+		)
+	case QueryExecResult:
+		f.Func().Params(recv).Id(name).Params(params...).Params(jen.Qual(sqlPkg, "Result"), jen.Error()).Block(
+			jen.Return(jen.Id("q").Dot("db").Dot("ExecContext").Call(args...)),
+		)
+	case QueryExecRows, QueryExecLastID:
+		result := "RowsAffected"
+		if query.Command == QueryExecLastID {
+			result = "LastInsertId"
+		}
+		f.Func().Params(recv).Id(name).Params(params...).Params(jen.Int64(), jen.Error()).Block(
+			jen.List(jen.Id("res"), jen.Err()).Op(":=").Id("q").Dot("db").Dot("ExecContext").Call(args...),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Lit(0), jen.Err())),
+			jen.Return(jen.Id("res").Dot(result).Call()),
+		)
+	}
+	return nil
+}
+
+// valueType returns the Go type of a column or parameter, or any if its
+// type is unknown.
+func valueType(ct *sqlparser.ColumnType, null bool) (*jen.Statement, error) {
+	if ct == nil {
+		return jen.Any(), nil
+	}
+	typ, err := GoType(ct)
+	if err != nil {
+		return nil, err
+	}
+	if null {
+		typ = nullable(typ, ct)
+	}
+	return typ, nil
+}
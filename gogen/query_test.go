@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gogen
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+func blogSchema(t *testing.T) *schema.Schema {
+	t.Helper()
+	s, err := schema.NewFromSQL(sqlparser.NewTestParser(), `
+		create table users (
+			id bigint primary key auto_increment,
+			email varchar(100) not null,
+			name varchar(50),
+			status enum('active', 'banned') not null,
+			created_at datetime not null
+		);
+		create table posts (
+			id bigint primary key,
+			user_id bigint not null,
+			title text not null,
+			type varchar(10) not null,
+			score double
+		);
+		create table pinned (post_id bigint primary key, note text)`)
+	require.NoError(t, err)
+	return s
+}
+
+func TestQueries(t *testing.T) {
+	src, err := os.ReadFile("testdata/queries.sql")
+	require.NoError(t, err)
+	queries, err := ParseQueries(sqlparser.NewTestParser(), blogSchema(t), "queries.sql", string(src))
+	require.NoError(t, err)
+
+	f, err := Queries(QueryOptions{}, queries...)
+	require.NoError(t, err)
+	code := fmt.Sprintf("%#v", f)
+	_, err = parser.ParseFile(token.NewFileSet(), "queries.go", code, parser.AllErrors)
+	require.NoError(t, err)
+
+	golden, err := os.ReadFile("testdata/queries.go.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), code)
+}
+
+func TestParseQueries(t *testing.T) {
+	testcases := []struct {
+		sql     string
+		query   string
+		params  []string
+		args    []int
+		columns []string
+	}{{
+		sql:     "select id from users where id = ? or id = ?",
+		query:   "select id from users where id = ? or id = ?",
+		params:  []string{"id int64", "id1 int64"},
+		args:    []int{0, 1},
+		columns: []string{"id int64"},
+	}, {
+		sql:     "select name from users where id = :user_id or :user_id is null;",
+		query:   "select name from users where id = ? or ? is null",
+		params:  []string{"userID int64"},
+		args:    []int{0, 0},
+		columns: []string{"name sql.Null[string]"},
+	}, {
+		sql:     "select u.id, x.n from users u join (select user_id, count(*) n from posts group by user_id) x on x.user_id = u.id where x.n > ?",
+		query:   "select u.id, x.n from users u join (select user_id, count(*) n from posts group by user_id) x on x.user_id = u.id where x.n > ?",
+		params:  []string{"xn int64"},
+		args:    []int{0},
+		columns: []string{"id int64", "n int64"},
+	}, {
+		sql:     "select id from users union select user_id from posts where type = ? order by id limit ?",
+		query:   "select id from users union select user_id from posts where type = ? order by id limit ?",
+		params:  []string{"typeArg string", "limit uint64"},
+		args:    []int{0, 1},
+		columns: []string{"id int64"},
+	}, {
+		sql:     "select 1 + ?, ? from dual",
+		query:   "select 1 + ?, ? from dual",
+		params:  []string{"arg int64", "arg2 any"},
+		args:    []int{0, 1},
+		columns: []string{"1 + :v1 any", ":v2 any"},
+	}, {
+		sql:     "update users u join posts p on p.user_id = u.id set u.status = ?, p.score = ? where p.id = ?",
+		query:   "update users u join posts p on p.user_id = u.id set u.status = ?, p.score = ? where p.id = ?",
+		params:  []string{"uStatus string", "pScore sql.Null[float64]", "pid int64"},
+		args:    []int{0, 1, 2},
+		columns: nil,
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			annotation := "-- name: Q :many\n"
+			if tcase.columns == nil {
+				annotation = "-- name: Q :exec\n"
+			}
+			queries, err := ParseQueries(sqlparser.NewTestParser(), blogSchema(t), "q.sql", annotation+tcase.sql)
+			require.NoError(t, err)
+			require.Len(t, queries, 1)
+			query := queries[0]
+			assert.Equal(t, tcase.query, query.SQL)
+			var params []string
+			for _, param := range query.Params {
+				typ, err := valueType(param.Type, param.Nullable)
+				require.NoError(t, err)
+				params = append(params, fmt.Sprintf("%s %#v", param.Name, typ))
+			}
+			assert.Equal(t, tcase.params, params)
+			assert.Equal(t, tcase.args, query.Args)
+			var columns []string
+			for _, col := range query.Columns {
+				typ, err := valueType(col.Type, col.Nullable)
+				require.NoError(t, err)
+				columns = append(columns, fmt.Sprintf("%s %#v", col.Name, typ))
+			}
+			assert.Equal(t, tcase.columns, columns)
+		})
+	}
+}
+
+func TestParseQueriesConstName(t *testing.T) {
+	queries, err := ParseQueries(sqlparser.NewTestParser(), blogSchema(t), "q.sql", "-- name: Email :one\nselect email from users where id = :email")
+	require.NoError(t, err)
+	require.Len(t, queries[0].Params, 1)
+	assert.Equal(t, "email2", queries[0].Params[0].Name)
+
+	f, err := Queries(QueryOptions{}, queries...)
+	require.NoError(t, err)
+	assert.Contains(t, fmt.Sprintf("%#v", f), "q.db.QueryRowContext(ctx, email, email2)")
+}
+
+func TestParseQueriesErrors(t *testing.T) {
+	testcases := []struct {
+		src string
+		err string
+	}{{
+		src: "-- name: Get :one\nselect id,\n  nope from users",
+		err: "q.sql:3:3: Get: Unknown column 'nope' in 'field list' (errno 1054) (sqlstate 42S22)",
+	}, {
+		src: "\n-- name: Get :one\nselect p.id from users u join posts p on p.user_id = u.id where u.nope = 1",
+		err: "q.sql:3:67: Get: Unknown column 'u.nope' in 'where clause' (errno 1054) (sqlstate 42S22)",
+	}, {
+		src: "-- name: Get :one\nselect id from users u join posts p on p.user_id = u.id",
+		err: "q.sql:2:8: Get: Column 'id' in field list is ambiguous (errno 1052) (sqlstate 23000)",
+	}, {
+		src: "-- name: Get :one\nselect id from users where id in (select id from nope)",
+		err: "q.sql:2:50: Get: Table 'nope' doesn't exist (errno 1146) (sqlstate 42S02)",
+	}, {
+		src: "-- name: Del :exec\ndelete from users where id in ::ids",
+		err: "q.sql:2:31: Del: list bind variables are not supported",
+	}, {
+		src: "-- name: Del :exec\ndelete from users where",
+		err: "q.sql:2:1: Del: syntax error at position 24",
+	}, {
+		src: "-- name: Del :exec\ndelete from users; delete from posts",
+		err: "q.sql:1:1: Del: expected one statement, got 2",
+	}, {
+		src: "-- name: Del :one\ndelete from users",
+		err: "q.sql:1:1: Del: :one query returns no rows",
+	}, {
+		src: "  -- name: Del :all\ndelete from users",
+		err: "q.sql:1:3: Del: unknown command :all",
+	}, {
+		src: "-- name: Del :exec\ndelete from users\n-- name: Del :exec\ndelete from posts",
+		err: "q.sql:3:1: duplicate query name Del",
+	}, {
+		src: "-- name: GetUser :exec\ndelete from users\n-- name: getUser :exec\ndelete from posts",
+		err: "q.sql:3:1: query name getUser conflicts with GetUser",
+	}, {
+		src: "-- name: 1st :exec\ndelete from users",
+		err: "q.sql:1:1: invalid query name \"1st\"",
+	}, {
+		src: "-- users\ndelete from users",
+		err: "q.sql:2:1: query without a name annotation",
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.src, func(t *testing.T) {
+			_, err := ParseQueries(sqlparser.NewTestParser(), blogSchema(t), "q.sql", tcase.src)
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
+
+func TestLowerName(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out string
+	}{
+		{in: "id", out: "id"},
+		{in: "user_id", out: "userID"},
+		{in: "APIKey", out: "apiKey"},
+		{in: "api_key", out: "apiKey"},
+		{in: "GetUser", out: "getUser"},
+		{in: "UTF8Name", out: "utf8Name"},
+	}
+	for _, tcase := range testcases {
+		t.Run(tcase.in, func(t *testing.T) {
+			assert.Equal(t, tcase.out, lowerName(tcase.in))
+		})
+	}
+}
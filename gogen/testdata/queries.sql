@@ -0,0 +1,31 @@
+-- Queries of the blog.
+
+-- name: GetUser :one
+SELECT id, email, name FROM users WHERE id = ?;
+
+-- name: GetEmail :one
+select email from users where id = :id and (status = :status or :id = 0);
+
+-- name: ListPosts :many
+SELECT u.name, p.*, count(*) over () total
+FROM users u LEFT JOIN posts p ON p.user_id = u.id
+WHERE u.email LIKE ? AND p.score BETWEEN ? AND ? AND u.status IN (?, ?)
+ORDER BY p.id LIMIT ? OFFSET ?;
+
+-- name: CreateUser :execlastid
+INSERT INTO users (email, name, status, created_at) VALUES (?, ?, ?, now());
+
+-- name: RenameUser :execrows
+UPDATE users SET name = ? WHERE id = ?;
+
+-- name: DeletePosts :exec
+DELETE FROM posts WHERE user_id = ? AND id NOT IN (SELECT post_id FROM pinned);
+
+-- name: UpsertPin :execresult
+INSERT INTO pinned (post_id, note) VALUES (:post, :note) AS new
+ON DUPLICATE KEY UPDATE note = new.note;
+
+-- name: PostStats :one
+WITH recent AS (SELECT * FROM posts WHERE score > ?)
+SELECT sum(score), avg(user_id) average, max(title) AS top, cast(id AS char) AS id, exists (select 1 from pinned) pinned
+FROM recent WHERE `type` = ?;
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint checks SQL statements against rules, such as the use of
+// SELECT * or of UPDATE without WHERE.
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// ignoreDirective starts a comment suppressing the findings of rules, such
+// as /* lint:ignore select-star, order-by-rand */. Without rule names, it
+// suppresses all findings.
+const ignoreDirective = "lint:ignore"
+
+// Finding is a violation of a rule.
+type Finding struct {
+	// Rule is the name of the rule.
+	Rule     string
+	Severity schema.Severity
+	// Statement is the statement the finding is about.
+	Statement sqlparser.Statement
+	// Node is the offending node within the statement.
+	Node sqlparser.SQLNode
+	// Message explains the violation.
+	Message string
+}
+
+// String returns the finding as "severity: rule: message".
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Rule, f.Message)
+}
+
+// Rule checks the nodes of statements.
+type Rule interface {
+	// Name identifies the rule in the configuration and in suppression
+	// comments.
+	Name() string
+	// Severity is the default severity of the findings of the rule.
+	Severity() schema.Severity
+	// Check reports the findings of a node. It is called for every node of
+	// a statement, parents first.
+	Check(c *Context, node sqlparser.SQLNode)
+}
+
+// Config configures a Linter.
+type Config struct {
+	// Schema holds the table definitions the rules about nullability and
+	// types need. Those rules are skipped without it.
+	Schema *schema.Schema
+	// Disabled are the names of the rules not to check.
+	Disabled []string
+	// Severities overrides the severity of rules by name.
+	Severities map[string]schema.Severity
+	// MaxJoins is the number of joins of a query block above which
+	// too-many-joins reports it. It defaults to 5.
+	MaxJoins int
+	// MaxOffset is the OFFSET above which large-offset reports a query. It
+	// defaults to 1000.
+	MaxOffset int
+}
+
+// Linter checks statements against rules.
+type Linter struct {
+	config Config
+	rules  []Rule
+}
+
+// New returns a linter checking the built-in rules and the given rules
+// that the configuration does not disable.
+func New(config Config, rules ...Rule) *Linter {
+	if config.MaxJoins <= 0 {
+		config.MaxJoins = 5
+	}
+	if config.MaxOffset <= 0 {
+		config.MaxOffset = 1000
+	}
+	l := &Linter{config: config}
+	for _, rule := range append(builtinRules(config), rules...) {
+		if !slices.Contains(config.Disabled, rule.Name()) {
+			l.rules = append(l.rules, rule)
+		}
+	}
+	return l
+}
+
+// Rules returns the rules the linter checks.
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// LintSQL parses a statement and returns its findings. The comments around
+// the statement can suppress findings, as the comments within it do.
+func (l *Linter) LintSQL(parser *sqlparser.Parser, sql string) ([]*Finding, error) {
+	query, margins := sqlparser.SplitMarginComments(sql)
+	stmt, err := parser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return l.lint(stmt, ignored(nil, margins.Leading, margins.Trailing)), nil
+}
+
+// Lint returns the findings of a statement in the order of its nodes.
+// Comments of the statement and of its query blocks suppress the findings
+// within them.
+func (l *Linter) Lint(stmt sqlparser.Statement) []*Finding {
+	return l.lint(stmt, nil)
+}
+
+func (l *Linter) lint(stmt sqlparser.Statement, ignore []string) []*Finding {
+	c := &Context{Statement: stmt, Schema: l.config.Schema, config: l.config}
+	suppressed := [][]string{ignore}
+	_ = sqlparser.Rewrite(stmt, func(cursor *sqlparser.Cursor) bool {
+		node := cursor.Node()
+		if commented, ok := node.(sqlparser.Commented); ok && commented.GetParsedComments() != nil {
+			suppressed = append(suppressed, ignored(nil, commented.GetParsedComments().GetComments()...))
+		} else {
+			suppressed = append(suppressed, nil)
+		}
+		for _, rule := range l.rules {
+			if suppresses(suppressed, rule.Name()) {
+				continue
+			}
+			c.rule = rule
+			rule.Check(c, node)
+		}
+		c.parents = append(c.parents, node)
+		return true
+	}, func(cursor *sqlparser.Cursor) bool {
+		c.parents = c.parents[:len(c.parents)-1]
+		suppressed = suppressed[:len(suppressed)-1]
+		return true
+	})
+	return c.findings
+}
+
+// ignored appends the rules the lint:ignore directives of comments suppress
+// to a list. An empty name suppresses all rules.
+func ignored(names []string, comments ...string) []string {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			_, rest, ok := strings.Cut(line, ignoreDirective)
+			if !ok {
+				continue
+			}
+			rest = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(rest), "*/"))
+			fields := strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+			if len(fields) == 0 {
+				fields = []string{""}
+			}
+			names = append(names, fields...)
+		}
+	}
+	return names
+}
+
+func suppresses(suppressed [][]string, rule string) bool {
+	for _, names := range suppressed {
+		if slices.Contains(names, "") || slices.Contains(names, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// Context is the context of the node a rule checks.
+type Context struct {
+	// Statement is the statement being checked.
+	Statement sqlparser.Statement
+	// Schema holds the table definitions, or is nil.
+	Schema *schema.Schema
+
+	config   Config
+	rule     Rule
+	parents  []sqlparser.SQLNode
+	findings []*Finding
+	// analyses caches the results of analyses of the statement by name.
+	analyses map[string]any
+}
+
+// Parents returns the ancestors of the node being checked, the nearest
+// last.
+func (c *Context) Parents() []sqlparser.SQLNode {
+	return c.parents
+}
+
+// Parent returns the parent of the node being checked, or nil for the
+// statement.
+func (c *Context) Parent() sqlparser.SQLNode {
+	if len(c.parents) == 0 {
+		return nil
+	}
+	return c.parents[len(c.parents)-1]
+}
+
+// Report reports a finding of the rule being checked about a node.
+func (c *Context) Report(node sqlparser.SQLNode, format string, args ...any) {
+	severity, ok := c.config.Severities[c.rule.Name()]
+	if !ok {
+		severity = c.rule.Severity()
+	}
+	c.findings = append(c.findings, &Finding{
+		Rule:      c.rule.Name(),
+		Severity:  severity,
+		Statement: c.Statement,
+		Node:      node,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// analysis returns the cached result of an analysis of the statement,
+// running it the first time.
+func (c *Context) analysis(name string, analyze func() any) any {
+	if c.analyses == nil {
+		c.analyses = map[string]any{}
+	}
+	result, ok := c.analyses[name]
+	if !ok {
+		result = analyze()
+		c.analyses[name] = result
+	}
+	return result
+}
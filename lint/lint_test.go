@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+func TestSuppression(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		findings []string
+	}{{
+		sql:      "select * from customer order by rand()",
+		findings: []string{"warning: select-star", "warning: order-by-rand"},
+	}, {
+		sql:      "select /* lint:ignore */ * from customer order by rand()",
+		findings: nil,
+	}, {
+		sql:      "select /* lint:ignore select-star */ * from customer order by rand()",
+		findings: []string{"warning: order-by-rand"},
+	}, {
+		sql:      "select /* lint:ignore select-star, order-by-rand */ * from customer order by rand()",
+		findings: nil,
+	}, {
+		sql:      "/* lint:ignore order-by-rand */ select * from customer order by rand()",
+		findings: []string{"warning: select-star"},
+	}, {
+		sql:      "select * from customer where id in (select /* lint:ignore */ id from orders order by rand())",
+		findings: []string{"warning: select-star"},
+	}, {
+		sql:      "delete /* lint:ignore missing-where */ from orders",
+		findings: nil,
+	}}
+
+	l := New(Config{})
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			found, err := l.LintSQL(sqlparser.NewTestParser(), tcase.sql)
+			require.NoError(t, err)
+			var got []string
+			for _, finding := range found {
+				got = append(got, finding.Severity.String()+": "+finding.Rule)
+			}
+			assert.Equal(t, tcase.findings, got)
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	stmt, err := sqlparser.NewTestParser().Parse("select * from orders order by id limit 500, 10")
+	require.NoError(t, err)
+
+	found := New(Config{}).Lint(stmt)
+	require.Len(t, found, 1)
+	assert.Equal(t, "select-star", found[0].Rule)
+	assert.Equal(t, stmt, found[0].Statement)
+	assert.IsType(t, &sqlparser.StarExpr{}, found[0].Node)
+
+	found = New(Config{
+		Disabled:   []string{"select-star"},
+		Severities: map[string]schema.Severity{"large-offset": schema.SeverityCritical},
+		MaxOffset:  100,
+	}).Lint(stmt)
+	require.Len(t, found, 1)
+	assert.Equal(t, "critical: large-offset: OFFSET 500 reads and discards 500 rows; paginate by the last key seen instead", found[0].String())
+	assert.IsType(t, &sqlparser.Limit{}, found[0].Node)
+}
+
+// noDistinct reports SELECT DISTINCT.
+type noDistinct struct{}
+
+func (noDistinct) Name() string              { return "no-distinct" }
+func (noDistinct) Severity() schema.Severity { return schema.SeverityInfo }
+func (noDistinct) Check(c *Context, node sqlparser.SQLNode) {
+	if sel, ok := node.(*sqlparser.Select); ok && sel.Distinct {
+		c.Report(sel, "DISTINCT in a query block nested in %d nodes", len(c.Parents()))
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	l := New(Config{Disabled: []string{"select-star"}}, noDistinct{})
+	assert.Len(t, l.Rules(), 11)
+
+	found, err := l.LintSQL(sqlparser.NewTestParser(), "select id from customer where id in (select distinct customer_id from orders)")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "info: no-distinct: DISTINCT in a query block nested in 4 nodes", found[0].String())
+
+	l = New(Config{Disabled: []string{"no-distinct"}}, noDistinct{})
+	assert.Len(t, l.Rules(), 11)
+}
@@ -0,0 +1,416 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+	"github.com/redhajuanda/sqlparser/semantics"
+)
+
+// rule is a built-in rule.
+type rule struct {
+	name     string
+	severity schema.Severity
+	check    func(c *Context, node sqlparser.SQLNode)
+}
+
+func (r *rule) Name() string                             { return r.name }
+func (r *rule) Severity() schema.Severity                { return r.severity }
+func (r *rule) Check(c *Context, node sqlparser.SQLNode) { r.check(c, node) }
+
+// builtinRules returns the built-in rules.
+func builtinRules(config Config) []Rule {
+	return []Rule{
+		&rule{"select-star", schema.SeverityWarning, checkSelectStar},
+		&rule{"missing-where", schema.SeverityCritical, checkMissingWhere},
+		&rule{"leading-wildcard", schema.SeverityWarning, checkLeadingWildcard},
+		&rule{"null-comparison", schema.SeverityCritical, checkNullComparison},
+		&rule{"order-by-rand", schema.SeverityWarning, checkOrderByRand},
+		&rule{"implicit-cross-join", schema.SeverityWarning, checkCrossJoin},
+		&rule{"not-in-nullable", schema.SeverityWarning, checkNotInNullable},
+		&rule{"non-sargable", schema.SeverityWarning, checkNonSargable},
+		&rule{"too-many-joins", schema.SeverityInfo, func(c *Context, node sqlparser.SQLNode) {
+			checkTooManyJoins(c, node, config.MaxJoins)
+		}},
+		&rule{"large-offset", schema.SeverityWarning, func(c *Context, node sqlparser.SQLNode) {
+			checkLargeOffset(c, node, config.MaxOffset)
+		}},
+		&rule{"sql-calc-found-rows", schema.SeverityWarning, checkCalcFoundRows},
+	}
+}
+
+// checkSelectStar reports stars of select lists, except in EXISTS
+// subqueries whose select list does not matter.
+func checkSelectStar(c *Context, node sqlparser.SQLNode) {
+	star, ok := node.(*sqlparser.StarExpr)
+	if !ok {
+		return
+	}
+	for _, parent := range c.Parents() {
+		if _, ok := parent.(*sqlparser.ExistsExpr); ok {
+			return
+		}
+	}
+	c.Report(star, "%s selects all columns; list the columns the query needs", sqlparser.String(star))
+}
+
+// checkMissingWhere reports UPDATE and DELETE statements that change every
+// row of their tables.
+func checkMissingWhere(c *Context, node sqlparser.SQLNode) {
+	switch stmt := node.(type) {
+	case *sqlparser.Update:
+		if stmt.Where == nil && stmt.Limit == nil {
+			c.Report(stmt, "UPDATE without WHERE or LIMIT changes every row")
+		}
+	case *sqlparser.Delete:
+		if stmt.Where == nil && stmt.Limit == nil {
+			c.Report(stmt, "DELETE without WHERE or LIMIT deletes every row")
+		}
+	}
+}
+
+// checkLeadingWildcard reports LIKE patterns starting with a wildcard,
+// which no index can serve.
+func checkLeadingWildcard(c *Context, node sqlparser.SQLNode) {
+	cmp, ok := node.(*sqlparser.ComparisonExpr)
+	if !ok || cmp.Operator != sqlparser.LikeOp && cmp.Operator != sqlparser.NotLikeOp {
+		return
+	}
+	if lit, ok := cmp.Right.(*sqlparser.Literal); ok && lit.Type == sqlparser.StrVal && (strings.HasPrefix(lit.Val, "%") || strings.HasPrefix(lit.Val, "_")) {
+		c.Report(cmp, "pattern '%s' starts with a wildcard and cannot use an index", lit.Val)
+	}
+}
+
+// checkNullComparison reports comparisons with NULL, which are never true.
+func checkNullComparison(c *Context, node sqlparser.SQLNode) {
+	cmp, ok := node.(*sqlparser.ComparisonExpr)
+	if !ok || cmp.Operator == sqlparser.NullSafeEqualOp {
+		return
+	}
+	for _, side := range []sqlparser.Expr{cmp.Left, cmp.Right} {
+		if _, ok := side.(*sqlparser.NullVal); ok {
+			c.Report(cmp, "%s is never true; use IS NULL or IS NOT NULL", sqlparser.String(cmp))
+			return
+		}
+	}
+}
+
+// checkOrderByRand reports ORDER BY RAND(), which sorts every row.
+func checkOrderByRand(c *Context, node sqlparser.SQLNode) {
+	order, ok := node.(*sqlparser.Order)
+	if !ok {
+		return
+	}
+	if fn, ok := order.Expr.(*sqlparser.FuncExpr); ok && fn.Name.Lowered() == "rand" {
+		c.Report(order, "ORDER BY RAND() reads and sorts every row")
+	}
+}
+
+// checkCrossJoin reports query blocks joining tables that no join or WHERE
+// condition relates. Tables are related by comparisons of their qualified
+// columns.
+func checkCrossJoin(c *Context, node sqlparser.SQLNode) {
+	sel, ok := node.(*sqlparser.Select)
+	if !ok {
+		return
+	}
+	var names []string
+	var conditions []sqlparser.Expr
+	// related holds groups of tables joined by NATURAL or USING joins,
+	// which relate them all.
+	var related [][]string
+	if sel.Where != nil {
+		conditions = append(conditions, sel.Where.Expr)
+	}
+	for _, expr := range sel.From {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.AliasedTableExpr:
+				names = append(names, aliasName(node))
+				return false, nil
+			case *sqlparser.JoinTableExpr:
+				if node.Condition != nil && node.Condition.On != nil {
+					conditions = append(conditions, node.Condition.On)
+				}
+				switch {
+				case node.Condition != nil && len(node.Condition.Using) > 0,
+					node.Join == sqlparser.NaturalJoinType, node.Join == sqlparser.NaturalLeftJoinType, node.Join == sqlparser.NaturalRightJoinType:
+					related = append(related, tableNames(node))
+				}
+			}
+			return true, nil
+		}, expr)
+	}
+	if len(names) < 2 {
+		return
+	}
+
+	// Union the tables related by a condition.
+	group := map[string]string{}
+	var find func(string) string
+	find = func(name string) string {
+		if parent, ok := group[name]; ok && parent != name {
+			return find(parent)
+		}
+		return name
+	}
+	for _, condition := range conditions {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.Subquery:
+				return false, nil
+			case *sqlparser.ComparisonExpr:
+				var qualifiers []string
+				_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+					if col, ok := node.(*sqlparser.ColName); ok && !col.Qualifier.IsEmpty() {
+						qualifiers = append(qualifiers, col.Qualifier.Name.String())
+					}
+					return true, nil
+				}, node)
+				related = append(related, qualifiers)
+				return false, nil
+			}
+			return true, nil
+		}, condition)
+	}
+	for _, tables := range related {
+		for i := 1; i < len(tables); i++ {
+			group[find(tables[i])] = find(tables[0])
+		}
+	}
+	for _, name := range names[1:] {
+		if find(name) != find(names[0]) {
+			c.Report(sel, "%s is joined to %s without a condition, returning every combination of their rows", name, names[0])
+			return
+		}
+	}
+}
+
+// tableNames returns the names of the tables of a join.
+func tableNames(join *sqlparser.JoinTableExpr) []string {
+	var names []string
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if table, ok := node.(*sqlparser.AliasedTableExpr); ok {
+			names = append(names, aliasName(table))
+			return false, nil
+		}
+		return true, nil
+	}, join)
+	return names
+}
+
+// aliasName returns the name a table of a FROM clause is referred to by.
+func aliasName(table *sqlparser.AliasedTableExpr) string {
+	if !table.As.IsEmpty() {
+		return table.As.String()
+	}
+	if name, ok := table.Expr.(sqlparser.TableName); ok {
+		return name.Name.String()
+	}
+	return ""
+}
+
+// checkNotInNullable reports NOT IN subqueries returning a nullable column:
+// a NULL value makes NOT IN false for every row.
+func checkNotInNullable(c *Context, node sqlparser.SQLNode) {
+	cmp, ok := node.(*sqlparser.ComparisonExpr)
+	if !ok || cmp.Operator != sqlparser.NotInOp {
+		return
+	}
+	sub, ok := cmp.Right.(*sqlparser.Subquery)
+	if !ok {
+		return
+	}
+	types := c.types()
+	if types == nil {
+		return
+	}
+	if results := types.Result(sub.Select); len(results) == 1 && results[0].Type.Nullable {
+		c.Report(cmp, "NOT IN subquery returns the nullable column %s; a NULL makes the condition false for every row, use NOT EXISTS", results[0].Name)
+	}
+}
+
+// checkNonSargable reports predicates that cannot use an index on their
+// column: a function or expression wraps the column, or the column is
+// converted to the type of the value compared with it, which takes the
+// schema to know.
+func checkNonSargable(c *Context, node sqlparser.SQLNode) {
+	var left sqlparser.Expr
+	var values []sqlparser.Expr
+	switch node := node.(type) {
+	case *sqlparser.ComparisonExpr:
+		switch node.Operator {
+		case sqlparser.EqualOp, sqlparser.NullSafeEqualOp, sqlparser.LessThanOp, sqlparser.LessEqualOp,
+			sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp, sqlparser.InOp, sqlparser.LikeOp:
+		default:
+			return
+		}
+		left, values = node.Left, []sqlparser.Expr{node.Right}
+	case *sqlparser.BetweenExpr:
+		left, values = node.Left, []sqlparser.Expr{node.From, node.To}
+	default:
+		return
+	}
+	if !inFilter(c.Parents()) {
+		return
+	}
+	for _, value := range values {
+		if hasColumns(value) {
+			return
+		}
+	}
+
+	if _, ok := left.(*sqlparser.ColName); !ok && hasColumns(left) {
+		c.Report(node, "%s wraps its columns in an expression, so no index on them can be used", sqlparser.String(left))
+		return
+	}
+	converted, ok := c.analysis("conversions", func() any {
+		return conversions(c)
+	}).(map[sqlparser.Expr]string)
+	if ok && converted[node.(sqlparser.Expr)] != "" {
+		c.Report(node, "column %s is converted to the type of the value it is compared with, so no index on it can be used", converted[node.(sqlparser.Expr)])
+	}
+}
+
+// conversions returns the predicates whose bare column is converted, with
+// the name of the column.
+func conversions(c *Context) map[sqlparser.Expr]string {
+	if c.Schema == nil {
+		return nil
+	}
+	usages, err := semantics.ColumnUsages(c.Schema, c.Statement)
+	if err != nil {
+		return nil
+	}
+	converted := map[sqlparser.Expr]string{}
+	for _, table := range usages {
+		for _, use := range table.Columns {
+			if use.Sargable || use.Kind != semantics.Equality && use.Kind != semantics.Range {
+				continue
+			}
+			var left sqlparser.Expr
+			switch expr := use.Expr.(type) {
+			case *sqlparser.ComparisonExpr:
+				if expr.Operator == sqlparser.LikeOp {
+					// Patterns are checked by leading-wildcard.
+					continue
+				}
+				left = expr.Left
+			case *sqlparser.BetweenExpr:
+				left = expr.Left
+			}
+			if col, ok := left.(*sqlparser.ColName); ok && col.Name.EqualString(use.Column) {
+				converted[use.Expr] = sqlparser.String(col)
+			}
+		}
+	}
+	return converted
+}
+
+// inFilter returns whether a node is in a WHERE, HAVING or ON clause.
+func inFilter(parents []sqlparser.SQLNode) bool {
+	for i := len(parents) - 1; i >= 0; i-- {
+		switch parents[i].(type) {
+		case *sqlparser.Where, *sqlparser.JoinCondition:
+			return true
+		case *sqlparser.Subquery, *sqlparser.AliasedExpr:
+			return false
+		}
+	}
+	return false
+}
+
+func hasColumns(expr sqlparser.Expr) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node.(type) {
+		case *sqlparser.Subquery:
+			return false, nil
+		case *sqlparser.ColName:
+			found = true
+		}
+		return !found, nil
+	}, expr)
+	return found
+}
+
+// checkTooManyJoins reports query blocks joining more tables than the
+// maximum.
+func checkTooManyJoins(c *Context, node sqlparser.SQLNode, max int) {
+	sel, ok := node.(*sqlparser.Select)
+	if !ok {
+		return
+	}
+	tables := 0
+	for _, expr := range sel.From {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node.(type) {
+			case *sqlparser.AliasedTableExpr, *sqlparser.JSONTableExpr:
+				tables++
+				return false, nil
+			}
+			return true, nil
+		}, expr)
+	}
+	if tables-1 > max {
+		c.Report(sel, "the query joins %d tables, more than %d joins", tables, max)
+	}
+}
+
+// checkLargeOffset reports OFFSET pagination skipping more rows than the
+// maximum, which reads all the rows it skips.
+func checkLargeOffset(c *Context, node sqlparser.SQLNode, max int) {
+	limit, ok := node.(*sqlparser.Limit)
+	if !ok || limit.Offset == nil {
+		return
+	}
+	lit, ok := limit.Offset.(*sqlparser.Literal)
+	if !ok || lit.Type != sqlparser.IntVal {
+		return
+	}
+	if offset, err := strconv.ParseUint(lit.Val, 10, 64); err == nil && offset > uint64(max) {
+		c.Report(limit, "OFFSET %d reads and discards %d rows; paginate by the last key seen instead", offset, offset)
+	}
+}
+
+// checkCalcFoundRows reports SQL_CALC_FOUND_ROWS, which is deprecated and
+// reads every row.
+func checkCalcFoundRows(c *Context, node sqlparser.SQLNode) {
+	if sel, ok := node.(*sqlparser.Select); ok && sel.SQLCalcFoundRows {
+		c.Report(sel, "SQL_CALC_FOUND_ROWS is deprecated and reads every matching row; run a separate COUNT(*) query")
+	}
+}
+
+// types returns the types of the expressions of the statement, or nil
+// without a schema or if the statement does not bind.
+func (c *Context) types() *semantics.Types {
+	types, _ := c.analysis("types", func() any {
+		if c.Schema == nil {
+			return nil
+		}
+		types, err := semantics.Infer(c.Schema, c.Statement)
+		if err != nil {
+			return nil
+		}
+		return types
+	}).(*semantics.Types)
+	return types
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+func testSchema(t *testing.T) *schema.Schema {
+	t.Helper()
+	s, err := schema.NewFromSQL(sqlparser.NewTestParser(), `
+		create table customer (
+			id int primary key,
+			name varchar(50),
+			email varchar(100) not null,
+			phone varchar(20),
+			created_at datetime
+		);
+		create table orders (
+			id bigint primary key,
+			customer_id int,
+			coupon_id int,
+			total decimal(10,2)
+		);
+	`)
+	require.NoError(t, err)
+	return s
+}
+
+// findings returns the findings of a query as strings.
+func findings(t *testing.T, l *Linter, sql string) []string {
+	t.Helper()
+	found, err := l.LintSQL(sqlparser.NewTestParser(), sql)
+	require.NoError(t, err)
+	var out []string
+	for _, finding := range found {
+		out = append(out, finding.String())
+	}
+	return out
+}
+
+func TestRules(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		findings []string
+	}{{
+		sql:      "select id, name from customer where id = 1",
+		findings: nil,
+	}, {
+		sql:      "select * from customer",
+		findings: []string{"warning: select-star: * selects all columns; list the columns the query needs"},
+	}, {
+		sql:      "select c.* from customer c where exists (select * from orders o where o.customer_id = c.id)",
+		findings: []string{"warning: select-star: c.* selects all columns; list the columns the query needs"},
+	}, {
+		sql:      "update customer set name = 'x'",
+		findings: []string{"critical: missing-where: UPDATE without WHERE or LIMIT changes every row"},
+	}, {
+		sql:      "delete from orders",
+		findings: []string{"critical: missing-where: DELETE without WHERE or LIMIT deletes every row"},
+	}, {
+		sql:      "delete from orders limit 100",
+		findings: nil,
+	}, {
+		sql:      "select id from customer where name like '%son'",
+		findings: []string{"warning: leading-wildcard: pattern '%son' starts with a wildcard and cannot use an index"},
+	}, {
+		sql:      "select id from customer where name like 'john%'",
+		findings: nil,
+	}, {
+		sql:      "select id from customer where phone = null",
+		findings: []string{"critical: null-comparison: phone = null is never true; use IS NULL or IS NOT NULL"},
+	}, {
+		sql:      "select id from customer where phone <=> null",
+		findings: nil,
+	}, {
+		sql:      "select id from customer order by rand() limit 1",
+		findings: []string{"warning: order-by-rand: ORDER BY RAND() reads and sorts every row"},
+	}, {
+		sql:      "select c.id from customer c, orders o",
+		findings: []string{"warning: implicit-cross-join: o is joined to c without a condition, returning every combination of their rows"},
+	}, {
+		sql:      "select c.id from customer c, orders o where o.customer_id = c.id",
+		findings: nil,
+	}, {
+		sql:      "select c.id from customer c join orders o on o.customer_id = c.id join orders p on p.total > 10",
+		findings: []string{"warning: implicit-cross-join: p is joined to c without a condition, returning every combination of their rows"},
+	}, {
+		sql:      "select c.id from customer c join orders o using (id)",
+		findings: nil,
+	}, {
+		sql:      "select id from customer where id not in (select customer_id from orders)",
+		findings: []string{"warning: not-in-nullable: NOT IN subquery returns the nullable column customer_id; a NULL makes the condition false for every row, use NOT EXISTS"},
+	}, {
+		sql:      "select id from orders where customer_id not in (select id from customer)",
+		findings: nil,
+	}, {
+		sql:      "select id from customer where year(created_at) = 2024",
+		findings: []string{"warning: non-sargable: year(created_at) wraps its columns in an expression, so no index on them can be used"},
+	}, {
+		sql:      "select id from orders where total * 2 > 100",
+		findings: []string{"warning: non-sargable: total * 2 wraps its columns in an expression, so no index on them can be used"},
+	}, {
+		sql:      "select id from customer where phone = 5551234",
+		findings: []string{"warning: non-sargable: column phone is converted to the type of the value it is compared with, so no index on it can be used"},
+	}, {
+		sql:      "select upper(name) from customer where created_at >= '2024-01-01'",
+		findings: nil,
+	}, {
+		sql:      "select a.id from customer a join orders b on b.customer_id = a.id join orders c on c.customer_id = a.id join orders d on d.customer_id = a.id join orders e on e.customer_id = a.id join orders f on f.customer_id = a.id join orders g on g.customer_id = a.id",
+		findings: []string{"info: too-many-joins: the query joins 7 tables, more than 5 joins"},
+	}, {
+		sql:      "select id from orders order by id limit 5000, 20",
+		findings: []string{"warning: large-offset: OFFSET 5000 reads and discards 5000 rows; paginate by the last key seen instead"},
+	}, {
+		sql:      "select id from orders order by id limit 20 offset 100",
+		findings: nil,
+	}, {
+		sql:      "select sql_calc_found_rows id from orders limit 10",
+		findings: []string{"warning: sql-calc-found-rows: SQL_CALC_FOUND_ROWS is deprecated and reads every matching row; run a separate COUNT(*) query"},
+	}}
+
+	l := New(Config{Schema: testSchema(t)})
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			assert.Equal(t, tcase.findings, findings(t, l, tcase.sql))
+		})
+	}
+}
+
+func TestRulesWithoutSchema(t *testing.T) {
+	l := New(Config{})
+	assert.Empty(t, findings(t, l, "select id from customer where id not in (select customer_id from orders)"))
+	assert.Empty(t, findings(t, l, "select id from customer where phone = 5551234"))
+	assert.Equal(t, []string{
+		"warning: non-sargable: date(created_at) wraps its columns in an expression, so no index on them can be used",
+	}, findings(t, l, "select id from customer where date(created_at) = '2024-01-01'"))
+}
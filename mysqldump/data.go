@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqldump
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/mysql/decimal"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+)
+
+// DataSection holds the data of a single table: the INSERT batches together
+// with the LOCK TABLES, ALTER TABLE ... DISABLE KEYS and UNLOCK TABLES
+// statements that mysqldump wraps around them. Rows are read lazily with
+// Next, so only one INSERT batch is held in memory at any time.
+type DataSection struct {
+	Pos      Position
+	Database string
+	Table    string
+	// Lock is the LOCK TABLES statement that opened the section, if any.
+	Lock *sqlparser.LockTables
+	// KeysDisabled is set if the section contained ALTER TABLE ... DISABLE KEYS.
+	KeysDisabled bool
+	// Unlocked is set once the UNLOCK TABLES statement closing the section
+	// has been read.
+	Unlocked bool
+	// Columns is the column list of the current INSERT batch, which is only
+	// present for dumps taken with --complete-insert.
+	Columns sqlparser.Columns
+	// Batches is the number of INSERT statements read so far.
+	Batches int
+	// Rows is the number of rows read so far, including the rows skipped
+	// when moving on to the next section.
+	Rows int
+
+	reader  *Reader
+	pending *sqlparser.Insert
+	values  sqlparser.Values
+	done    bool
+}
+
+func (r *Reader) newDataSection(st *statement, name sqlparser.TableName) *DataSection {
+	return &DataSection{
+		Pos:      st.position(),
+		Database: r.qualifier(name),
+		Table:    name.Name.String(),
+		reader:   r,
+	}
+}
+
+// Next returns the next row of the table. When all rows have been read,
+// io.EOF is returned.
+func (d *DataSection) Next() ([]sqltypes.Value, error) {
+	for len(d.values) == 0 {
+		if err := d.advance(); err != nil {
+			return nil, err
+		}
+	}
+	tuple := d.values[0]
+	d.values = d.values[1:]
+
+	row := make([]sqltypes.Value, 0, len(tuple))
+	for _, expr := range tuple {
+		val, err := ExprToValue(expr)
+		if err != nil {
+			return nil, fmt.Errorf("table %s, row %d: %w", d.Table, d.Rows+1, err)
+		}
+		row = append(row, val)
+	}
+	d.Rows++
+	return row, nil
+}
+
+// advance moves to the next INSERT batch of the section.
+func (d *DataSection) advance() error {
+	for {
+		if d.pending != nil {
+			d.Batches++
+			d.Columns = d.pending.Columns
+			d.values = d.pending.Rows.(sqlparser.Values)
+			d.pending = nil
+			return nil
+		}
+		if d.done {
+			return io.EOF
+		}
+
+		st, err := d.reader.peek()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				d.done = true
+				continue
+			}
+			return err
+		}
+		switch stmt := st.stmt.(type) {
+		case *sqlparser.Insert:
+			if _, ok := stmt.Rows.(sqlparser.Values); ok && d.sameTable(stmt.Table) {
+				d.pending = stmt
+				d.reader.consume()
+				continue
+			}
+		case *sqlparser.AlterTable:
+			if isKeyState(stmt) && d.reader.qualifier(stmt.Table) == d.Database && stmt.Table.Name.String() == d.Table {
+				d.keyState(stmt)
+				d.reader.consume()
+				continue
+			}
+		case *sqlparser.UnlockTables:
+			d.reader.consume()
+			d.Unlocked = true
+		}
+		d.done = true
+	}
+}
+
+func (d *DataSection) keyState(alter *sqlparser.AlterTable) {
+	if !alter.AlterOptions[0].(*sqlparser.KeyState).Enable {
+		d.KeysDisabled = true
+	}
+}
+
+func (d *DataSection) sameTable(table *sqlparser.AliasedTableExpr) bool {
+	name, ok := table.Expr.(sqlparser.TableName)
+	if !ok {
+		return false
+	}
+	return d.reader.qualifier(name) == d.Database && name.Name.String() == d.Table
+}
+
+// drain skips the remaining rows of the section.
+func (d *DataSection) drain() error {
+	for {
+		d.Rows += len(d.values)
+		d.values = nil
+		if err := d.advance(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ExprToValue converts a value of an INSERT statement to a sqltypes.Value.
+// Besides the literals handled by sqlparser.LiteralToValue it supports NULL,
+// negative numbers and character set introducers such as _binary, which
+// mysqldump uses to write table data.
+func ExprToValue(expr sqlparser.Expr) (sqltypes.Value, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.Literal:
+		switch expr.Type {
+		case sqlparser.BitNum:
+			// The tokenizer keeps the 0b prefix of b'...' literals.
+			return sqlparser.LiteralToValue(sqlparser.NewBitLiteral(strings.TrimPrefix(expr.Val, "0b")))
+		case sqlparser.DecimalVal:
+			return decimalToValue(expr.Val)
+		}
+		return sqlparser.LiteralToValue(expr)
+	case *sqlparser.NullVal:
+		return sqltypes.NULL, nil
+	case sqlparser.BoolVal:
+		if expr {
+			return sqltypes.NewInt64(1), nil
+		}
+		return sqltypes.NewInt64(0), nil
+	case *sqlparser.IntroducerExpr:
+		val, err := ExprToValue(expr.Expr)
+		if err != nil {
+			return sqltypes.Value{}, err
+		}
+		if strings.EqualFold(expr.CharacterSet, "_binary") && val.IsText() {
+			return sqltypes.NewVarBinary(val.ToString()), nil
+		}
+		return val, nil
+	case *sqlparser.UnaryExpr:
+		lit, ok := expr.Expr.(*sqlparser.Literal)
+		if !ok {
+			break
+		}
+		switch expr.Operator {
+		case sqlparser.UPlusOp:
+			return ExprToValue(lit)
+		case sqlparser.UMinusOp:
+			return negativeLiteralToValue(lit)
+		}
+	}
+	return sqltypes.Value{}, fmt.Errorf("unsupported value: %s", sqlparser.String(expr))
+}
+
+func negativeLiteralToValue(lit *sqlparser.Literal) (sqltypes.Value, error) {
+	switch lit.Type {
+	case sqlparser.IntVal:
+		ival, err := strconv.ParseInt("-"+lit.Val, 10, 64)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return sqltypes.NewDecimal("-" + lit.Val), nil
+			}
+			return sqltypes.Value{}, err
+		}
+		return sqltypes.NewInt64(ival), nil
+	case sqlparser.FloatVal:
+		return sqlparser.LiteralToValue(sqlparser.NewFloatLiteral("-" + lit.Val))
+	case sqlparser.DecimalVal:
+		return decimalToValue("-" + lit.Val)
+	}
+	return sqltypes.Value{}, fmt.Errorf("unsupported value: -%s", sqlparser.String(lit))
+}
+
+// decimalToValue validates a decimal literal and keeps its text as is, so
+// that the scale of the dumped value is preserved.
+func decimalToValue(val string) (sqltypes.Value, error) {
+	if _, err := decimal.NewFromMySQL([]byte(val)); err != nil {
+		return sqltypes.Value{}, err
+	}
+	return sqltypes.NewDecimal(val), nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqldump
+
+import (
+	"errors"
+	"io"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+type (
+	// Dump is the structured model of a dump file, as built by Load.
+	Dump struct {
+		// Settings are the session settings found at the head of the dump,
+		// before any database, table or data section.
+		Settings []*sqlparser.Set
+		// Databases holds the databases in the order they first appear. A
+		// dump of a single database without USE statements is held in a
+		// database with an empty name.
+		Databases []*Database
+	}
+
+	// Database holds the objects of a single database.
+	Database struct {
+		Name     string
+		Create   *sqlparser.CreateDatabase
+		Tables   []*Table
+		Views    []*View
+		Routines []*RoutineSection
+	}
+
+	// Table is a table definition together with a summary of its data.
+	Table struct {
+		Name string
+		// Create is nil if the dump holds no definition for the table,
+		// e.g. when it was taken with --no-create-info.
+		Create *sqlparser.CreateTable
+		// Rows and Batches count the rows and INSERT statements dumped for
+		// the table.
+		Rows    int
+		Batches int
+		// Locked is set if the data was wrapped in LOCK TABLES.
+		Locked   bool
+		Triggers []*RoutineSection
+	}
+
+	// View is a view definition.
+	View struct {
+		Create *sqlparser.CreateView
+	}
+)
+
+// Load reads a complete dump into a Dump. Table data is counted but not
+// kept; use a Reader to access the rows.
+func Load(parser *sqlparser.Parser, r io.Reader) (*Dump, error) {
+	reader := NewReader(parser, r)
+	dump := &Dump{}
+	header := true
+	for {
+		section, err := reader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return dump, nil
+			}
+			return nil, err
+		}
+
+		switch section := section.(type) {
+		case *SettingsSection:
+			if header {
+				dump.Settings = append(dump.Settings, section.Statements...)
+			}
+			continue
+		case *DatabaseSection:
+			db := dump.database(section.Name)
+			if section.Create != nil {
+				db.Create = section.Create
+			}
+		case *TableSection:
+			if section.ViewPlaceholder {
+				break
+			}
+			dump.database(section.Database).table(section.Name).Create = section.Create
+		case *ViewSection:
+			if section.Placeholder {
+				break
+			}
+			db := dump.database(section.Database)
+			db.Views = append(db.Views, &View{Create: section.Create})
+		case *DataSection:
+			for {
+				if _, err := section.Next(); err != nil {
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					return nil, err
+				}
+			}
+			table := dump.database(section.Database).table(section.Table)
+			table.Rows += section.Rows
+			table.Batches += section.Batches
+			table.Locked = table.Locked || section.Lock != nil
+		case *RoutineSection:
+			if section.Drop {
+				break
+			}
+			db := dump.database(section.Database)
+			if section.Kind == "TRIGGER" {
+				table := db.table(section.Table)
+				table.Triggers = append(table.Triggers, section)
+				break
+			}
+			db.Routines = append(db.Routines, section)
+		}
+		header = false
+	}
+}
+
+// Database returns the database with the given name, or nil.
+func (d *Dump) Database(name string) *Database {
+	for _, db := range d.Databases {
+		if db.Name == name {
+			return db
+		}
+	}
+	return nil
+}
+
+func (d *Dump) database(name string) *Database {
+	if db := d.Database(name); db != nil {
+		return db
+	}
+	db := &Database{Name: name}
+	d.Databases = append(d.Databases, db)
+	return db
+}
+
+// Table returns the table with the given name, or nil.
+func (db *Database) Table(name string) *Table {
+	for _, table := range db.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+func (db *Database) table(name string) *Table {
+	if table := db.Table(name); table != nil {
+		return table
+	}
+	table := &Table{Name: name}
+	db.Tables = append(db.Tables, table)
+	return table
+}
+
+// View returns the view with the given name, or nil.
+func (db *Database) View(name string) *View {
+	for _, view := range db.Views {
+		if view.Create.ViewName.Name.String() == name {
+			return view
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqldump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestLoad(t *testing.T) {
+	dump, err := Load(sqlparser.NewTestParser(), openDump(t))
+	require.NoError(t, err)
+
+	require.Len(t, dump.Settings, 10)
+	assert.Equal(t, "set NAMES 'utf8mb4'", sqlparser.String(dump.Settings[3]))
+
+	require.Len(t, dump.Databases, 1)
+	db := dump.Database("shop")
+	require.NotNil(t, db)
+	require.NotNil(t, db.Create)
+
+	require.Len(t, db.Tables, 2)
+	customers := db.Table("customers")
+	require.NotNil(t, customers)
+	assert.Equal(t, 3, customers.Rows)
+	assert.Equal(t, 2, customers.Batches)
+	assert.True(t, customers.Locked)
+	require.Len(t, customers.Triggers, 1)
+	assert.Equal(t, "customers_bi", customers.Triggers[0].Name)
+
+	orders := db.Table("orders")
+	require.NotNil(t, orders)
+	assert.Equal(t, 0, orders.Rows)
+	assert.Len(t, orders.Create.TableSpec.Constraints, 1)
+
+	require.Len(t, db.Views, 1)
+	view := db.View("big_customers")
+	require.NotNil(t, view)
+	assert.Equal(t, "select customers.id as id, customers.`name` as `name` from customers where customers.balance > 100", sqlparser.String(view.Create.Select))
+
+	require.Len(t, db.Routines, 1)
+	assert.Equal(t, "PROCEDURE", db.Routines[0].Kind)
+	assert.Equal(t, ";;", db.Routines[0].Delimiter)
+}
+
+func TestLoadWithoutDatabase(t *testing.T) {
+	dump, err := Load(sqlparser.NewTestParser(), strings.NewReader(`
+CREATE TABLE t (id int);
+INSERT INTO t VALUES (1),(2);
+INSERT INTO other.u VALUES (3);
+`))
+	require.NoError(t, err)
+	require.Len(t, dump.Databases, 2)
+	assert.Equal(t, 2, dump.Database("").Table("t").Rows)
+	other := dump.Database("other").Table("u")
+	assert.Nil(t, other.Create)
+	assert.Equal(t, 1, other.Rows)
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mysqldump reads the output of mysqldump and groups its statements
+// into sections: session settings, databases, tables, views, table data and
+// stored routines.
+package mysqldump
+
+import (
+	"io"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/vt/vterrors"
+)
+
+// Reader walks a dump file one section at a time. Only a single statement
+// is held in memory at any time, so arbitrarily large dumps can be read.
+type Reader struct {
+	parser   *sqlparser.Parser
+	sr       *sqlparser.StatementReader
+	database string
+
+	// pending is a statement that was read ahead while grouping a
+	// section and must be returned before reading further.
+	pending *statement
+	// structure is the last "-- ... structure for ..." comment that
+	// mysqldump writes ahead of table and view definitions.
+	structure string
+	// data is the data section last returned by Next, which must be
+	// drained before the next section can be read.
+	data *DataSection
+	err  error
+}
+
+// statement is a single parsed statement of the dump.
+type statement struct {
+	piece *sqlparser.StatementPiece
+	// stmt is nil if the statement could not be parsed.
+	stmt sqlparser.Statement
+	err  error
+}
+
+func (st *statement) position() Position {
+	return Position{Offset: st.piece.Start, Line: st.piece.Line}
+}
+
+// NewReader returns a Reader that reads a dump from r.
+func NewReader(parser *sqlparser.Parser, r io.Reader) *Reader {
+	return &Reader{
+		parser: parser,
+		sr:     sqlparser.NewStatementReader(r),
+	}
+}
+
+// Database returns the current database, as set by the last USE statement.
+func (r *Reader) Database() string {
+	return r.database
+}
+
+// Next returns the next section of the dump. Any rows left in the previously
+// returned DataSection are skipped. When the end of the dump is reached,
+// io.EOF is returned.
+func (r *Reader) Next() (Section, error) {
+	if r.data != nil {
+		if err := r.data.drain(); err != nil {
+			return nil, err
+		}
+		r.data = nil
+	}
+
+	st, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	if st.stmt == nil {
+		return r.routine(st)
+	}
+
+	switch stmt := st.stmt.(type) {
+	case *sqlparser.Set:
+		section := &SettingsSection{Pos: st.position(), Database: r.database, Statements: []*sqlparser.Set{stmt}}
+		for {
+			next, err := r.peek()
+			if err != nil || next.stmt == nil {
+				break
+			}
+			set, ok := next.stmt.(*sqlparser.Set)
+			if !ok {
+				break
+			}
+			section.Statements = append(section.Statements, set)
+			r.consume()
+		}
+		return section, nil
+	case *sqlparser.CreateDatabase:
+		section := &DatabaseSection{Pos: st.position(), Name: stmt.DBName.String(), Create: stmt}
+		if next, err := r.peek(); err == nil {
+			if use, ok := next.stmt.(*sqlparser.Use); ok && use.DBName.String() == section.Name {
+				r.consume()
+				r.database = section.Name
+				section.Use = true
+			}
+		}
+		return section, nil
+	case *sqlparser.Use:
+		r.database = stmt.DBName.String()
+		return &DatabaseSection{Pos: st.position(), Name: r.database, Use: true}, nil
+	case *sqlparser.CreateTable:
+		return &TableSection{
+			Pos:             st.position(),
+			Database:        r.qualifier(stmt.Table),
+			Name:            stmt.Table.Name.String(),
+			Create:          stmt,
+			ViewPlaceholder: strings.Contains(r.structure, "Temporary table structure for view"),
+		}, nil
+	case *sqlparser.CreateView:
+		return &ViewSection{
+			Pos:         st.position(),
+			Database:    r.qualifier(stmt.ViewName),
+			Name:        stmt.ViewName.Name.String(),
+			Create:      stmt,
+			Placeholder: strings.Contains(r.structure, "Temporary view structure for view"),
+		}, nil
+	case *sqlparser.LockTables:
+		if len(stmt.Tables) != 1 {
+			break
+		}
+		table, ok := stmt.Tables[0].Table.(*sqlparser.AliasedTableExpr)
+		if !ok {
+			break
+		}
+		name, ok := table.Expr.(sqlparser.TableName)
+		if !ok {
+			break
+		}
+		r.data = r.newDataSection(st, name)
+		r.data.Lock = stmt
+		return r.data, nil
+	case *sqlparser.AlterTable:
+		if !isKeyState(stmt) {
+			break
+		}
+		r.data = r.newDataSection(st, stmt.Table)
+		r.data.keyState(stmt)
+		return r.data, nil
+	case *sqlparser.Insert:
+		if _, ok := stmt.Rows.(sqlparser.Values); !ok {
+			break
+		}
+		name, ok := stmt.Table.Expr.(sqlparser.TableName)
+		if !ok {
+			break
+		}
+		r.data = r.newDataSection(st, name)
+		r.data.pending = stmt
+		return r.data, nil
+	}
+	return &StatementSection{Pos: st.position(), Database: r.database, Statement: st.stmt}, nil
+}
+
+// qualifier returns the database of the given table name, defaulting to the
+// current database.
+func (r *Reader) qualifier(name sqlparser.TableName) string {
+	if name.Qualifier.NotEmpty() {
+		return name.Qualifier.String()
+	}
+	return r.database
+}
+
+// read returns the next statement, either the one read ahead by peek or a
+// new one from the stream.
+func (r *Reader) read() (*statement, error) {
+	st, err := r.peek()
+	if err != nil {
+		return nil, err
+	}
+	r.consume()
+	return st, nil
+}
+
+// consume marks the statement returned by peek as read.
+func (r *Reader) consume() {
+	text := r.pending.piece.Text
+	leading := strings.TrimSuffix(text, sqlparser.StripLeadingComments(text))
+	if strings.Contains(leading, "structure for") {
+		r.structure = leading
+	}
+	r.pending = nil
+}
+
+// peek reads the next statement without consuming it.
+func (r *Reader) peek() (*statement, error) {
+	if r.pending != nil {
+		return r.pending, nil
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	piece, err := r.sr.Next()
+	if err != nil {
+		r.err = err
+		return nil, err
+	}
+	st := &statement{piece: piece}
+	st.stmt, st.err = r.parser.Parse(piece.Text)
+	if st.err != nil {
+		st.stmt = nil
+	}
+	r.pending = st
+	return st, nil
+}
+
+// routine builds a RoutineSection for a statement that could not be parsed,
+// or returns the parse error if it is not a routine definition.
+func (r *Reader) routine(st *statement) (Section, error) {
+	section, ok := r.parseRoutine(st.piece.Text)
+	if !ok {
+		return nil, vterrors.Wrapf(st.err, "line %d", st.piece.Line)
+	}
+	section.Pos = st.position()
+	section.Delimiter = st.piece.Delimiter
+	section.Text = st.piece.Text
+	if section.Database == "" {
+		section.Database = r.database
+	}
+	return section, nil
+}
+
+// parseRoutine recognizes the head of a CREATE or DROP statement for a
+// stored routine, trigger or event, e.g.
+//
+//	CREATE DEFINER=`root`@`localhost` PROCEDURE `db`.`p`(...)
+//	CREATE TRIGGER t BEFORE INSERT ON tbl FOR EACH ROW ...
+//	DROP FUNCTION IF EXISTS f
+func (r *Reader) parseRoutine(text string) (*RoutineSection, bool) {
+	tokenizer := r.parser.NewStringTokenizer(text)
+	next := func() (int, string) {
+		for {
+			typ, val := tokenizer.Scan()
+			if typ != sqlparser.COMMENT {
+				return typ, val
+			}
+		}
+	}
+
+	section := &RoutineSection{}
+	switch typ, _ := next(); typ {
+	case sqlparser.CREATE:
+	case sqlparser.DROP:
+		section.Drop = true
+	default:
+		return nil, false
+	}
+
+	// Skip over DEFINER, ALGORITHM and similar clauses up to the object kind.
+	const maxClauseTokens = 32
+	for i := 0; ; i++ {
+		typ, _ := next()
+		switch typ {
+		case sqlparser.PROCEDURE:
+			section.Kind = "PROCEDURE"
+		case sqlparser.FUNCTION:
+			section.Kind = "FUNCTION"
+		case sqlparser.TRIGGER:
+			section.Kind = "TRIGGER"
+		case sqlparser.EVENT:
+			section.Kind = "EVENT"
+		case 0, sqlparser.LEX_ERROR, '(':
+			return nil, false
+		}
+		if section.Kind != "" {
+			break
+		}
+		if i == maxClauseTokens {
+			return nil, false
+		}
+	}
+
+	typ, val := next()
+	if typ == sqlparser.IF {
+		// IF [NOT] EXISTS
+		for typ != sqlparser.EXISTS && typ != 0 {
+			typ, _ = next()
+		}
+		typ, val = next()
+	}
+	var following int
+	section.Database, section.Name, following = scanName(next, typ, val)
+	if section.Name == "" {
+		return nil, false
+	}
+
+	if section.Kind == "TRIGGER" && !section.Drop {
+		for following != sqlparser.ON && following != 0 && following != sqlparser.LEX_ERROR {
+			following, _ = next()
+		}
+		if following == sqlparser.ON {
+			typ, val = next()
+			_, section.Table, _ = scanName(next, typ, val)
+		}
+	}
+	return section, true
+}
+
+// scanName reads a possibly qualified name starting at the given token and
+// returns the token that follows it.
+func scanName(next func() (int, string), typ int, val string) (qualifier, name string, following int) {
+	if typ == 0 || typ == sqlparser.LEX_ERROR || val == "" {
+		return "", "", 0
+	}
+	name = val
+	following, _ = next()
+	if following == '.' {
+		qualifier = name
+		_, name = next()
+		following, _ = next()
+	}
+	return qualifier, name, following
+}
+
+func isKeyState(alter *sqlparser.AlterTable) bool {
+	if len(alter.AlterOptions) != 1 {
+		return false
+	}
+	_, ok := alter.AlterOptions[0].(*sqlparser.KeyState)
+	return ok
+}
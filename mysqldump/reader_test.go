@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqldump
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+)
+
+func openDump(t *testing.T) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/dump.sql")
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+// describe returns a short description of a section, used to compare the
+// sequence of sections returned by the reader.
+func describe(section Section) string {
+	switch s := section.(type) {
+	case *SettingsSection:
+		return fmt.Sprintf("settings(%d)", len(s.Statements))
+	case *DatabaseSection:
+		return fmt.Sprintf("database %s create=%t use=%t", s.Name, s.Create != nil, s.Use)
+	case *TableSection:
+		return fmt.Sprintf("table %s.%s placeholder=%t", s.Database, s.Name, s.ViewPlaceholder)
+	case *ViewSection:
+		return fmt.Sprintf("view %s.%s placeholder=%t", s.Database, s.Name, s.Placeholder)
+	case *DataSection:
+		return fmt.Sprintf("data %s.%s", s.Database, s.Table)
+	case *RoutineSection:
+		return fmt.Sprintf("routine %s %s.%s drop=%t", s.Kind, s.Database, s.Name, s.Drop)
+	case *StatementSection:
+		return sqlparser.String(s.Statement)
+	}
+	return fmt.Sprintf("%T", section)
+}
+
+func TestReader(t *testing.T) {
+	reader := NewReader(sqlparser.NewTestParser(), openDump(t))
+	var sections []string
+	for {
+		section, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		sections = append(sections, describe(section))
+	}
+
+	assert.Equal(t, []string{
+		"settings(10)",
+		"database shop create=true use=true",
+		"drop table if exists customers",
+		"settings(2)",
+		"table shop.customers placeholder=false",
+		"settings(1)",
+		"data shop.customers",
+		"settings(8)",
+		"routine TRIGGER shop.customers_bi drop=false",
+		"settings(4)",
+		"drop table if exists orders",
+		"settings(2)",
+		"table shop.orders placeholder=false",
+		"settings(1)",
+		"data shop.orders",
+		"drop table if exists big_customers",
+		"drop view if exists big_customers",
+		"settings(2)",
+		"view shop.big_customers placeholder=true",
+		"settings(1)",
+		"routine PROCEDURE shop.top_customers drop=true",
+		"routine PROCEDURE shop.top_customers drop=false",
+		"database shop create=false use=true",
+		"drop view if exists big_customers",
+		"settings(1)",
+		"view shop.big_customers placeholder=false",
+		"settings(9)",
+	}, sections)
+}
+
+func TestReaderData(t *testing.T) {
+	reader := NewReader(sqlparser.NewTestParser(), openDump(t))
+	var data *DataSection
+	for data == nil {
+		section, err := reader.Next()
+		require.NoError(t, err)
+		data, _ = section.(*DataSection)
+	}
+
+	var rows [][]sqltypes.Value
+	for {
+		row, err := data.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+
+	assert.Equal(t, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("Ann; O'Neil"), sqltypes.NewDecimal("-12.50"), sqltypes.NewVarBinary("ab")},
+		{sqltypes.NewInt64(2), sqltypes.NewVarChar("Bob"), sqltypes.NULL, sqltypes.NULL},
+		{sqltypes.NewInt64(3), sqltypes.NewVarChar("Cy"), sqltypes.NewDecimal("0.00"), sqltypes.NewVarBinary("\x01\x02")},
+	}, rows)
+	assert.Equal(t, "customers", data.Table)
+	assert.NotNil(t, data.Lock)
+	assert.True(t, data.KeysDisabled)
+	assert.True(t, data.Unlocked)
+	assert.Equal(t, 2, data.Batches)
+	assert.Equal(t, 3, data.Rows)
+}
+
+func TestReaderSkipsUnreadRows(t *testing.T) {
+	dump := "INSERT INTO t VALUES (1),(2);\nINSERT INTO t VALUES (3);\nINSERT INTO u VALUES (4);\n"
+	reader := NewReader(sqlparser.NewTestParser(), strings.NewReader(dump))
+
+	section, err := reader.Next()
+	require.NoError(t, err)
+	first := section.(*DataSection)
+	row, err := first.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []sqltypes.Value{sqltypes.NewInt64(1)}, row)
+
+	section, err = reader.Next()
+	require.NoError(t, err)
+	second := section.(*DataSection)
+	assert.Equal(t, 3, first.Rows)
+	assert.Equal(t, 2, first.Batches)
+	assert.Equal(t, "u", second.Table)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 1, second.Rows)
+}
+
+func TestReaderAliasedTable(t *testing.T) {
+	dump := "LOCK TABLES `t` AS `a` WRITE;\nINSERT INTO t VALUES (1);\nINSERT INTO t VALUES (2);\nUNLOCK TABLES;\n"
+	reader := NewReader(sqlparser.NewTestParser(), strings.NewReader(dump))
+
+	section, err := reader.Next()
+	require.NoError(t, err)
+	data := section.(*DataSection)
+	assert.Equal(t, "t", data.Table)
+	for {
+		_, err := data.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, data.Rows)
+	assert.True(t, data.Unlocked)
+}
+
+func TestReaderErrors(t *testing.T) {
+	testcases := []struct {
+		dump string
+		err  string
+	}{{
+		dump: "select 1;\nselect from;",
+		err:  "line 2: syntax error at position 12 near 'from'",
+	}, {
+		dump: "select 1;\nselect 'abc",
+		err:  "unterminated quoted string starting at offset 17",
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.dump, func(t *testing.T) {
+			reader := NewReader(sqlparser.NewTestParser(), strings.NewReader(tcase.dump))
+			var err error
+			for err == nil {
+				_, err = reader.Next()
+			}
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
+
+func TestExprToValue(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out sqltypes.Value
+		err string
+	}{
+		{in: "1", out: sqltypes.NewInt64(1)},
+		{in: "-1", out: sqltypes.NewInt64(-1)},
+		{in: "18446744073709551615", out: sqltypes.NewUint64(18446744073709551615)},
+		{in: "-9223372036854775809", out: sqltypes.NewDecimal("-9223372036854775809")},
+		{in: "1.50", out: sqltypes.NewDecimal("1.50")},
+		{in: "-1e3", out: sqltypes.NewFloat64(-1000)},
+		{in: "'abc'", out: sqltypes.NewVarChar("abc")},
+		{in: "_binary 'abc'", out: sqltypes.NewVarBinary("abc")},
+		{in: "_utf8mb4 'abc'", out: sqltypes.NewVarChar("abc")},
+		{in: "0x6162", out: sqltypes.NewVarBinary("ab")},
+		{in: "b'1100001'", out: sqltypes.NewVarBinary("a")},
+		{in: "null", out: sqltypes.NULL},
+		{in: "true", out: sqltypes.NewInt64(1)},
+		{in: "now()", err: "unsupported value: now()"},
+	}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.in, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tcase.in)
+			require.NoError(t, err)
+			out, err := ExprToValue(expr)
+			if tcase.err != "" {
+				require.EqualError(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tcase.out, out)
+		})
+	}
+}
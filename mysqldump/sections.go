@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqldump
+
+import (
+	"github.com/redhajuanda/sqlparser"
+)
+
+type (
+	// Section is a part of a dump file, as returned by Reader.Next.
+	Section interface {
+		iSection()
+		Position() Position
+	}
+
+	// Position is the location of a section in the dump file.
+	Position struct {
+		// Offset is the byte offset of the first statement of the section.
+		Offset int64
+		// Line is the 1-based line of the first statement of the section.
+		Line int
+	}
+
+	// SettingsSection holds consecutive SET statements, such as the
+	// /*!40101 SET ... */ session settings at the head of a dump.
+	SettingsSection struct {
+		Pos        Position
+		Database   string
+		Statements []*sqlparser.Set
+	}
+
+	// DatabaseSection is emitted for CREATE DATABASE and USE statements.
+	// mysqldump writes them back to back, in which case they are grouped
+	// in a single section.
+	DatabaseSection struct {
+		Pos    Position
+		Name   string
+		Create *sqlparser.CreateDatabase
+		Use    bool
+	}
+
+	// TableSection holds a CREATE TABLE statement.
+	TableSection struct {
+		Pos      Position
+		Database string
+		Name     string
+		Create   *sqlparser.CreateTable
+		// ViewPlaceholder is set for the stand-in tables that mysqldump
+		// creates for views so that dependent views can be restored.
+		ViewPlaceholder bool
+	}
+
+	// ViewSection holds a CREATE VIEW statement.
+	ViewSection struct {
+		Pos      Position
+		Database string
+		Name     string
+		Create   *sqlparser.CreateView
+		// Placeholder is set for the temporary view structure that
+		// mysqldump creates before the final view definition.
+		Placeholder bool
+	}
+
+	// RoutineSection holds a stored procedure, function, trigger or event.
+	// The parser does not support compound statements, so the definition
+	// is kept as text.
+	RoutineSection struct {
+		Pos      Position
+		Database string
+		// Kind is one of PROCEDURE, FUNCTION, TRIGGER or EVENT.
+		Kind string
+		Name string
+		// Table is the table a trigger is defined on.
+		Table string
+		// Drop is set for DROP statements.
+		Drop bool
+		// Text is the statement text, without its delimiter.
+		Text string
+		// Delimiter is the delimiter that was active for the statement.
+		Delimiter string
+	}
+
+	// StatementSection holds any other statement found in the dump.
+	StatementSection struct {
+		Pos       Position
+		Database  string
+		Statement sqlparser.Statement
+	}
+)
+
+func (*SettingsSection) iSection()  {}
+func (*DatabaseSection) iSection()  {}
+func (*TableSection) iSection()     {}
+func (*ViewSection) iSection()      {}
+func (*RoutineSection) iSection()   {}
+func (*StatementSection) iSection() {}
+func (*DataSection) iSection()      {}
+
+// Position implements the Section interface.
+func (s *SettingsSection) Position() Position { return s.Pos }
+
+// Position implements the Section interface.
+func (s *DatabaseSection) Position() Position { return s.Pos }
+
+// Position implements the Section interface.
+func (s *TableSection) Position() Position { return s.Pos }
+
+// Position implements the Section interface.
+func (s *ViewSection) Position() Position { return s.Pos }
+
+// Position implements the Section interface.
+func (s *RoutineSection) Position() Position { return s.Pos }
+
+// Position implements the Section interface.
+func (s *StatementSection) Position() Position { return s.Pos }
+
+// Position implements the Section interface.
+func (s *DataSection) Position() Position { return s.Pos }
@@ -0,0 +1,430 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package querylog reads the slow query log and the general query log of
+// MySQL and aggregates their queries by fingerprint into a report, in the
+// manner of pt-query-digest.
+package querylog
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/vt/vterrors"
+
+	vtrpcpb "github.com/redhajuanda/sqlparser/dependencies/vt/proto/vtrpc"
+)
+
+// Format is the format of a query log.
+type Format int
+
+const (
+	// SlowLog is the format of the slow query log, with a block of
+	// "# Key: value" lines before each statement.
+	SlowLog Format = iota
+	// GeneralLog is the format of the general query log, with a line per
+	// command of each connection.
+	GeneralLog
+)
+
+// Entry is an entry of a query log.
+type Entry struct {
+	// Line is the 1-based line at which the entry starts.
+	Line int
+	// Time is the time the entry was logged. Times without a time zone are
+	// taken to be UTC.
+	Time time.Time
+	// User and Host identify the account of the connection.
+	User, Host string
+	// ThreadID is the id of the connection.
+	ThreadID uint64
+	// Database is the default database of the connection.
+	Database string
+	// Command is the command of the connection, such as Query, Connect or
+	// Quit. Slow log entries of administrator commands have the name of the
+	// command, and all the others are Query.
+	Command string
+	// Query is the statement text, without its terminating delimiter. It is
+	// empty for commands other than Query and Execute.
+	Query string
+
+	// QueryTime, LockTime, RowsSent and RowsExamined are the statistics of
+	// the slow log. They are zero in the general log.
+	QueryTime    time.Duration
+	LockTime     time.Duration
+	RowsSent     uint64
+	RowsExamined uint64
+}
+
+// Reader reads the entries of a query log one at a time.
+type Reader struct {
+	format Format
+	r      *bufio.Reader
+	line   int
+	// pending is a line that was read ahead and must be returned by the
+	// next call to readLine.
+	pending *string
+
+	// database and time are the default database and the time of the last
+	// slow log entry, which MySQL omits when they do not change.
+	database string
+	time     time.Time
+	// sessions are the open connections of the general log, by thread id.
+	sessions map[uint64]*session
+	err      error
+}
+
+// session is a connection of the general log.
+type session struct {
+	user, host, database string
+}
+
+// NewReader returns a Reader that reads a log of the given format from r.
+func NewReader(r io.Reader, format Format) *Reader {
+	return &Reader{
+		format:   format,
+		r:        bufio.NewReaderSize(r, 64*1024),
+		sessions: map[uint64]*session{},
+	}
+}
+
+// Next returns the next entry of the log. When there are no more entries,
+// io.EOF is returned.
+func (r *Reader) Next() (*Entry, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var entry *Entry
+	var err error
+	if r.format == SlowLog {
+		entry, err = r.nextSlow()
+	} else {
+		entry, err = r.nextGeneral()
+	}
+	if err != nil {
+		r.err = err
+		return nil, err
+	}
+	return entry, nil
+}
+
+// readLine returns the next line without its line terminator, and false at
+// the end of the log.
+func (r *Reader) readLine() (string, bool, error) {
+	if r.pending != nil {
+		line := *r.pending
+		r.pending = nil
+		r.line++
+		return line, true, nil
+	}
+	line, err := r.r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, err
+	}
+	if line == "" && err != nil {
+		return "", false, nil
+	}
+	r.line++
+	return strings.TrimRight(line, "\r\n"), true, nil
+}
+
+func (r *Reader) unread(line string) {
+	r.pending = &line
+	r.line--
+}
+
+// isHeader reports whether a line is part of the header MySQL writes when
+// it opens a log.
+func isHeader(line string) bool {
+	return strings.HasSuffix(line, "started with:") ||
+		strings.HasPrefix(line, "Tcp port:") ||
+		strings.HasPrefix(line, "Time ") && strings.Contains(line, "Id Command")
+}
+
+func (r *Reader) errorf(format string, args ...any) error {
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "line %d: "+format, append([]any{r.line}, args...)...)
+}
+
+// nextSlow reads a slow log entry: "# Time", "# User@Host" and
+// "# Query_time" lines, followed by the statement.
+func (r *Reader) nextSlow() (*Entry, error) {
+	var entry *Entry
+	var text []string
+	// done reports whether the entry has its statement, so that the next
+	// metadata line starts another entry.
+	done := func() bool {
+		return entry != nil && (len(text) > 0 || entry.Command != "Query")
+	}
+	for {
+		line, ok, err := r.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		switch {
+		case isHeader(line):
+			if done() {
+				return r.finishSlow(entry, text)
+			}
+		case strings.HasPrefix(line, "# Time:"), strings.HasPrefix(line, "# User@Host:"):
+			if done() {
+				r.unread(line)
+				return r.finishSlow(entry, text)
+			}
+			if entry == nil {
+				entry = &Entry{Line: r.line, Command: "Query"}
+			}
+			if err := r.parseSlowMetadata(entry, line); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "# administrator command: ") && entry != nil:
+			// The command follows the SET timestamp statement of the entry.
+			entry.Command = strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(line, "# administrator command: ")), ";")
+		case strings.HasPrefix(line, "# ") && entry != nil && !done():
+			if err := r.parseSlowMetadata(entry, line); err != nil {
+				return nil, err
+			}
+		case entry != nil:
+			text = append(text, line)
+		}
+	}
+	if entry == nil {
+		return nil, io.EOF
+	}
+	return r.finishSlow(entry, text)
+}
+
+// parseSlowMetadata parses a "# Key: value" line of a slow log entry.
+func (r *Reader) parseSlowMetadata(entry *Entry, line string) error {
+	line = strings.TrimPrefix(line, "# ")
+	if value, ok := strings.CutPrefix(line, "Time:"); ok {
+		t, err := parseTime(strings.TrimSpace(value))
+		if err != nil {
+			return r.errorf("invalid time %q", strings.TrimSpace(value))
+		}
+		entry.Time = t
+		return nil
+	}
+	if value, ok := strings.CutPrefix(line, "User@Host:"); ok {
+		// app[app] @ web1 [10.0.0.5]  Id:    42
+		value, id, _ := strings.Cut(value, "Id:")
+		user, host, _ := strings.Cut(value, "@")
+		if name, _, ok := strings.Cut(strings.TrimSpace(user), "["); ok {
+			user = name
+		}
+		entry.User = strings.TrimSpace(user)
+		host, ip, _ := strings.Cut(host, "[")
+		entry.Host = strings.TrimSpace(host)
+		if entry.Host == "" {
+			entry.Host = strings.TrimSuffix(strings.TrimSpace(ip), "]")
+		}
+		if id = strings.TrimSpace(id); id != "" {
+			threadID, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				return r.errorf("invalid thread id %q", id)
+			}
+			entry.ThreadID = threadID
+		}
+		return nil
+	}
+
+	// Query_time: 1.234567  Lock_time: 0.000123 Rows_sent: 10  Rows_examined: 50000
+	fields := strings.Fields(line)
+	for i := 0; i+1 < len(fields); i++ {
+		key, ok := strings.CutSuffix(fields[i], ":")
+		if !ok {
+			continue
+		}
+		value := fields[i+1]
+		var err error
+		switch key {
+		case "Query_time":
+			entry.QueryTime, err = parseSeconds(value)
+		case "Lock_time":
+			entry.LockTime, err = parseSeconds(value)
+		case "Rows_sent":
+			entry.RowsSent, err = strconv.ParseUint(value, 10, 64)
+		case "Rows_examined":
+			entry.RowsExamined, err = strconv.ParseUint(value, 10, 64)
+		case "Thread_id":
+			entry.ThreadID, err = strconv.ParseUint(value, 10, 64)
+		case "Schema":
+			entry.Database = value
+		}
+		if err != nil {
+			return r.errorf("invalid %s %q", key, value)
+		}
+		i++
+	}
+	return nil
+}
+
+// finishSlow sets the statement of a slow log entry from its text, which
+// can start with the USE and SET timestamp statements MySQL logs along
+// with it.
+func (r *Reader) finishSlow(entry *Entry, text []string) (*Entry, error) {
+	var timestamp time.Time
+	var queries []string
+	sr := sqlparser.NewStatementReader(strings.NewReader(strings.Join(text, "\n")))
+	for {
+		piece, err := sr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// The statement was truncated: keep it as it is.
+			queries = []string{strings.TrimSpace(strings.Join(text, "\n"))}
+			break
+		}
+		lower := strings.ToLower(piece.Text)
+		if db, ok := strings.CutPrefix(lower, "use "); ok && len(queries) == 0 {
+			r.database = strings.Trim(strings.TrimSpace(piece.Text[len(piece.Text)-len(db):]), "`")
+			continue
+		}
+		if value, ok := strings.CutPrefix(lower, "set timestamp="); ok && len(queries) == 0 {
+			if seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+				timestamp = time.Unix(seconds, 0).UTC()
+			}
+			continue
+		}
+		queries = append(queries, piece.Text)
+	}
+	if entry.Command == "Query" {
+		entry.Query = strings.Join(queries, ";\n")
+	}
+
+	if entry.Database == "" {
+		entry.Database = r.database
+	}
+	switch {
+	case !entry.Time.IsZero():
+	case !timestamp.IsZero():
+		entry.Time = timestamp
+	default:
+		entry.Time = r.time
+	}
+	r.time = entry.Time
+	return entry, nil
+}
+
+// generalLine matches the line starting an entry of the general log: an
+// optional time, the thread id, the command and its argument.
+var generalLine = regexp.MustCompile(`^(?:(\d{4}-\d\d-\d\dT\S+|\d{6}\s+\d{1,2}:\d\d:\d\d)\s+|\s+)(\d+) ([A-Z][A-Za-z ]*?)(?:\t(.*))?$`)
+
+// nextGeneral reads a general log entry: a line per command, continued by
+// the following lines for statements that span several lines.
+func (r *Reader) nextGeneral() (*Entry, error) {
+	for {
+		line, ok, err := r.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, io.EOF
+		}
+		match := generalLine.FindStringSubmatch(line)
+		if match == nil {
+			// Headers and lines of statements of skipped commands.
+			continue
+		}
+		entry := &Entry{Line: r.line, Command: match[3]}
+		if match[1] != "" {
+			if entry.Time, err = parseTime(match[1]); err != nil {
+				return nil, r.errorf("invalid time %q", match[1])
+			}
+			r.time = entry.Time
+		} else {
+			entry.Time = r.time
+		}
+		if entry.ThreadID, err = strconv.ParseUint(match[2], 10, 64); err != nil {
+			return nil, r.errorf("invalid thread id %q", match[2])
+		}
+		argument := []string{match[4]}
+		for {
+			next, ok, err := r.readLine()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			if isHeader(next) || generalLine.MatchString(next) {
+				r.unread(next)
+				break
+			}
+			argument = append(argument, next)
+		}
+		r.command(entry, strings.TrimSpace(strings.Join(argument, "\n")))
+		return entry, nil
+	}
+}
+
+// command applies a general log command to the session of its connection.
+func (r *Reader) command(entry *Entry, argument string) {
+	s := r.sessions[entry.ThreadID]
+	if s == nil {
+		s = &session{}
+		r.sessions[entry.ThreadID] = s
+	}
+	switch entry.Command {
+	case "Connect":
+		// app@web1 on shop using TCP/IP
+		account, rest, _ := strings.Cut(argument, " on ")
+		s.user, s.host, _ = strings.Cut(account, "@")
+		s.database, _, _ = strings.Cut(strings.TrimSpace(rest), " ")
+	case "Init DB":
+		s.database = argument
+	case "Query", "Execute":
+		entry.Query = strings.TrimSuffix(argument, ";")
+		// A USE statement changes the database of the following queries.
+		if db, ok := strings.CutPrefix(strings.ToLower(entry.Query), "use "); ok {
+			s.database = strings.Trim(strings.TrimSpace(entry.Query[len(entry.Query)-len(db):]), "`")
+		}
+	}
+	entry.User, entry.Host, entry.Database = s.user, s.host, s.database
+	if entry.Command == "Quit" {
+		delete(r.sessions, entry.ThreadID)
+	}
+}
+
+// parseTime parses the times of MySQL 5.7 and later, such as
+// 2024-01-15T10:23:45.123456Z, and of earlier versions, such as
+// 240115 10:23:45.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05.999999999", value); err == nil {
+		return t, nil
+	}
+	return time.Parse("060102 15:04:05", strings.Join(strings.Fields(value), " "))
+}
+
+func parseSeconds(value string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querylog
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, r *Reader) []*Entry {
+	t.Helper()
+	var entries []*Entry
+	for {
+		entry, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+}
+
+func openLog(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestSlowLog(t *testing.T) {
+	entries := readAll(t, NewReader(openLog(t, "slow.log"), SlowLog))
+	require.Len(t, entries, 5)
+
+	assert.Equal(t, &Entry{
+		Line:         4,
+		Time:         time.Date(2024, 1, 15, 10, 23, 45, 123456000, time.UTC),
+		User:         "app",
+		Host:         "web1",
+		ThreadID:     42,
+		Database:     "shop",
+		Command:      "Query",
+		Query:        "SELECT * FROM orders WHERE customer_id = 7",
+		QueryTime:    1500 * time.Millisecond,
+		LockTime:     100 * time.Microsecond,
+		RowsSent:     1,
+		RowsExamined: 50000,
+	}, entries[0])
+
+	assert.Equal(t, "select *\n  from orders\n where customer_id = 12", entries[1].Query)
+	assert.Equal(t, "shop", entries[1].Database)
+
+	assert.Equal(t, "report", entries[2].User)
+	assert.Equal(t, "10.0.0.9", entries[2].Host)
+
+	// The time of the entry without a Time line comes from SET timestamp.
+	assert.Equal(t, "Quit", entries[3].Command)
+	assert.Empty(t, entries[3].Query)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 23, 47, 0, time.UTC), entries[3].Time)
+
+	assert.Equal(t, "UPDATE orders SET status = 'paid' WHERE id = 99", entries[4].Query)
+	assert.Equal(t, 26, entries[4].Line)
+}
+
+func TestSlowLogMySQL56(t *testing.T) {
+	log := `# Time: 240115  9:05:01
+# User@Host: root[root] @ localhost []
+# Thread_id: 7  Schema: shop  Last_errno: 0  Killed: 0
+# Query_time: 3.000000  Lock_time: 0.000000  Rows_sent: 0  Rows_examined: 12
+SET timestamp=1705309501;
+select sleep(3);
+# User@Host: root[root] @ localhost []
+# Query_time: 0.010000  Lock_time: 0.000000  Rows_sent: 1  Rows_examined: 1
+select 'unterminated
+`
+	entries := readAll(t, NewReader(strings.NewReader(log), SlowLog))
+	require.Len(t, entries, 2)
+	assert.Equal(t, time.Date(2024, 1, 15, 9, 5, 1, 0, time.UTC), entries[0].Time)
+	assert.Equal(t, uint64(7), entries[0].ThreadID)
+	assert.Equal(t, "shop", entries[0].Database)
+	assert.Equal(t, "localhost", entries[0].Host)
+	assert.Equal(t, 3*time.Second, entries[0].QueryTime)
+
+	// Without a time or a timestamp, the time of the previous entry is kept.
+	assert.Equal(t, entries[0].Time, entries[1].Time)
+	assert.Equal(t, "select 'unterminated", entries[1].Query)
+}
+
+func TestGeneralLog(t *testing.T) {
+	entries := readAll(t, NewReader(openLog(t, "general.log"), GeneralLog))
+	require.Len(t, entries, 6)
+
+	var commands []string
+	for _, entry := range entries {
+		commands = append(commands, entry.Command)
+	}
+	assert.Equal(t, []string{"Connect", "Query", "Query", "Init DB", "Query", "Quit"}, commands)
+
+	assert.Equal(t, &Entry{
+		Line:     5,
+		Time:     time.Date(2024, 1, 15, 10, 23, 45, 100000000, time.UTC),
+		User:     "app",
+		Host:     "web1",
+		ThreadID: 42,
+		Database: "shop",
+		Command:  "Query",
+		Query:    "SELECT * FROM orders WHERE customer_id = 7",
+	}, entries[1])
+	assert.Equal(t, "select *\n  from orders\n where customer_id = 12", entries[2].Query)
+	assert.Equal(t, "archive", entries[4].Database)
+	assert.Empty(t, entries[5].Query)
+}
+
+func TestReaderErrors(t *testing.T) {
+	testcases := []struct {
+		log    string
+		format Format
+		err    string
+	}{{
+		log:    "# Time: yesterday\n# Query_time: 1\nselect 1;\n",
+		format: SlowLog,
+		err:    `line 1: invalid time "yesterday"`,
+	}, {
+		log:    "# Time: 2024-01-15T10:23:45Z\n# Query_time: fast  Lock_time: 0\nselect 1;\n",
+		format: SlowLog,
+		err:    `line 2: invalid Query_time "fast"`,
+	}, {
+		log:    "2024-13-45T10:23:45Z\t 1 Query\tselect 1\n",
+		format: GeneralLog,
+		err:    `line 1: invalid time "2024-13-45T10:23:45Z"`,
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.log, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tcase.log), tcase.format)
+			_, err := r.Next()
+			require.EqualError(t, err, tcase.err)
+
+			// The error is sticky.
+			_, err = r.Next()
+			require.EqualError(t, err, tcase.err)
+		})
+	}
+}
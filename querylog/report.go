@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querylog
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redhajuanda/sqlparser"
+
+	querypb "github.com/redhajuanda/sqlparser/dependencies/vt/proto/query"
+)
+
+// Report aggregates the queries of a log by fingerprint.
+type Report struct {
+	// Queries is the number of queries aggregated.
+	Queries int `json:"queries"`
+	// Unparsed is the number of queries that did not parse, which are
+	// fingerprinted by their MySQL digest text instead.
+	Unparsed int `json:"unparsed"`
+	// Classes are the queries grouped by fingerprint, by decreasing total
+	// query time.
+	Classes []*Class `json:"classes"`
+}
+
+// Class is the set of queries of a log sharing a fingerprint.
+type Class struct {
+	// Fingerprint is the normalized query, with bind variables numbered
+	// by position in place of its values.
+	Fingerprint string `json:"fingerprint"`
+	// Example is the slowest query of the class.
+	Example string `json:"example"`
+	Count   int    `json:"count"`
+	// QueryTime and LockTime are in seconds.
+	QueryTime    Stats `json:"query_time"`
+	LockTime     Stats `json:"lock_time"`
+	RowsSent     Stats `json:"rows_sent"`
+	RowsExamined Stats `json:"rows_examined"`
+	// Tables are the tables the queries touch, qualified by the database
+	// of the connection when they are not.
+	Tables    []string  `json:"tables"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+
+	// values holds the values of the statistics of every query, from
+	// which Stats are computed.
+	values  [4][]float64
+	tables  map[string]bool
+	slowest time.Duration
+}
+
+// Stats are statistics of a value over the queries of a class.
+type Stats struct {
+	Total float64 `json:"total"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Avg   float64 `json:"avg"`
+	// P95 is the 95th percentile, by the nearest-rank method.
+	P95 float64 `json:"p95"`
+}
+
+// Aggregator aggregates the entries of query logs into a report.
+type Aggregator struct {
+	parser  *sqlparser.Parser
+	report  Report
+	classes map[string]*Class
+}
+
+// NewAggregator returns an Aggregator parsing queries with parser.
+func NewAggregator(parser *sqlparser.Parser) *Aggregator {
+	return &Aggregator{parser: parser, classes: map[string]*Class{}}
+}
+
+// Aggregate reads all the entries of a log and returns their report.
+func Aggregate(parser *sqlparser.Parser, r *Reader) (*Report, error) {
+	a := NewAggregator(parser)
+	for {
+		entry, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return a.Report(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.Add(entry)
+	}
+}
+
+// Add adds an entry to the report. Entries without a query, such as
+// connections, are skipped.
+func (a *Aggregator) Add(entry *Entry) {
+	if entry.Query == "" {
+		return
+	}
+	a.report.Queries++
+	fingerprint, tables, ok := a.fingerprint(entry)
+	if !ok {
+		a.report.Unparsed++
+	}
+
+	class := a.classes[fingerprint]
+	if class == nil {
+		class = &Class{Fingerprint: fingerprint, tables: map[string]bool{}, FirstSeen: entry.Time, LastSeen: entry.Time}
+		a.classes[fingerprint] = class
+	}
+	class.Count++
+	if class.Example == "" || entry.QueryTime > class.slowest {
+		class.Example, class.slowest = entry.Query, entry.QueryTime
+	}
+	for i, value := range []float64{entry.QueryTime.Seconds(), entry.LockTime.Seconds(), float64(entry.RowsSent), float64(entry.RowsExamined)} {
+		class.values[i] = append(class.values[i], value)
+	}
+	for _, table := range tables {
+		class.tables[table] = true
+	}
+	if entry.Time.Before(class.FirstSeen) {
+		class.FirstSeen = entry.Time
+	}
+	if entry.Time.After(class.LastSeen) {
+		class.LastSeen = entry.Time
+	}
+}
+
+// fingerprint returns the fingerprint of the query of an entry and the
+// tables it touches. Queries that do not parse are fingerprinted by their
+// digest text, and ok is false.
+func (a *Aggregator) fingerprint(entry *Entry) (fingerprint string, tables []string, ok bool) {
+	stmt, err := a.parser.Parse(entry.Query)
+	if err == nil {
+		for _, access := range sqlparser.ExtractTableAccess(stmt) {
+			name := access.Name
+			if name.Qualifier.IsEmpty() && entry.Database != "" {
+				name.Qualifier = sqlparser.NewIdentifierCS(entry.Database)
+			}
+			tables = append(tables, sqlparser.String(name))
+		}
+		err = sqlparser.Normalize(stmt, sqlparser.NewReservedVars("bv", sqlparser.BindVars{}), map[string]*querypb.BindVariable{})
+	}
+	if err == nil {
+		return sqlparser.String(canonicalize(stmt)), tables, true
+	}
+	if digest, err := a.parser.Digest(entry.Query); err == nil {
+		return digest.Text, nil, false
+	}
+	return strings.Join(strings.Fields(entry.Query), " "), nil, false
+}
+
+// canonicalize names the bind variables of a normalized statement after
+// their position and drops their types. Normalize gives equal values the
+// same bind variable and types them, which would otherwise split queries
+// differing only in their values into several classes.
+func canonicalize(stmt sqlparser.Statement) sqlparser.SQLNode {
+	n := 0
+	next := func() string {
+		n++
+		return "bv" + strconv.Itoa(n)
+	}
+	return sqlparser.Rewrite(stmt, nil, func(cursor *sqlparser.Cursor) bool {
+		switch cursor.Node().(type) {
+		case *sqlparser.Argument:
+			cursor.Replace(sqlparser.NewArgument(next()))
+		case sqlparser.ListArg:
+			cursor.Replace(sqlparser.NewListArg(next()))
+		}
+		return true
+	})
+}
+
+// Report returns the report of the entries added so far.
+func (a *Aggregator) Report() *Report {
+	report := a.report
+	report.Classes = make([]*Class, 0, len(a.classes))
+	for _, class := range a.classes {
+		// The classes keep aggregating entries: the report gets copies.
+		c := *class
+		stats := []*Stats{&c.QueryTime, &c.LockTime, &c.RowsSent, &c.RowsExamined}
+		for i, values := range class.values {
+			*stats[i] = newStats(values)
+		}
+		c.Tables = make([]string, 0, len(class.tables))
+		for table := range class.tables {
+			c.Tables = append(c.Tables, table)
+		}
+		sort.Strings(c.Tables)
+		c.values, c.tables = [4][]float64{}, nil
+		report.Classes = append(report.Classes, &c)
+	}
+	sort.Slice(report.Classes, func(i, j int) bool {
+		a, b := report.Classes[i], report.Classes[j]
+		if a.QueryTime.Total != b.QueryTime.Total {
+			return a.QueryTime.Total > b.QueryTime.Total
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return &report
+}
+
+func newStats(values []float64) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	stats := Stats{Min: sorted[0], Max: sorted[len(sorted)-1]}
+	for _, value := range sorted {
+		stats.Total += value
+	}
+	stats.Avg = stats.Total / float64(len(sorted))
+	stats.P95 = sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+	return stats
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
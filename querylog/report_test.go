@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querylog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestAggregate(t *testing.T) {
+	report, err := Aggregate(sqlparser.NewTestParser(), NewReader(openLog(t, "slow.log"), SlowLog))
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Queries)
+	assert.Equal(t, 0, report.Unparsed)
+
+	var fingerprints []string
+	for _, class := range report.Classes {
+		fingerprints = append(fingerprints, class.Fingerprint)
+	}
+	assert.Equal(t, []string{
+		"select * from orders where customer_id = :bv1",
+		"select c.`name`, sum(o.total) from customer as c join orders as o on o.customer_id = c.id where o.`status` in ::bv1 group by c.`name`",
+		"update orders set `status` = :bv1 where id = :bv2",
+	}, fingerprints)
+
+	class := report.Classes[0]
+	assert.Equal(t, 2, class.Count)
+	assert.Equal(t, "SELECT * FROM orders WHERE customer_id = 7", class.Example)
+	assert.Equal(t, Stats{Total: 2, Min: 0.5, Max: 1.5, Avg: 1, P95: 1.5}, class.QueryTime)
+	assert.Equal(t, Stats{Total: 60000, Min: 10000, Max: 50000, Avg: 30000, P95: 50000}, class.RowsExamined)
+	assert.Equal(t, []string{"shop.orders"}, class.Tables)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 23, 45, 123456000, time.UTC), class.FirstSeen)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 23, 46, 0, time.UTC), class.LastSeen)
+
+	assert.Equal(t, []string{"shop.customer", "shop.orders"}, report.Classes[1].Tables)
+}
+
+func TestAggregatorPercentile(t *testing.T) {
+	a := NewAggregator(sqlparser.NewTestParser())
+	for i := 1; i <= 40; i++ {
+		a.Add(&Entry{Query: "select 1 from dual", QueryTime: time.Duration(i) * time.Second})
+	}
+	// Connections and other commands are skipped.
+	a.Add(&Entry{Command: "Quit"})
+
+	report := a.Report()
+	require.Len(t, report.Classes, 1)
+	assert.Equal(t, 40, report.Queries)
+	assert.Equal(t, Stats{Total: 820, Min: 1, Max: 40, Avg: 20.5, P95: 38}, report.Classes[0].QueryTime)
+	assert.Equal(t, "select 1 from dual", report.Classes[0].Example)
+	assert.Empty(t, report.Classes[0].Tables)
+}
+
+func TestAggregatorValues(t *testing.T) {
+	a := NewAggregator(sqlparser.NewTestParser())
+	a.Add(&Entry{Query: "select * from t where a = 1 and b = 1"})
+	a.Add(&Entry{Query: "select * from t where a = 1 and b = 2"})
+	a.Add(&Entry{Query: "select * from t where a = 'x' and b = 2.5"})
+
+	report := a.Report()
+	require.Len(t, report.Classes, 1)
+	assert.Equal(t, "select * from t where a = :bv1 and b = :bv2", report.Classes[0].Fingerprint)
+	assert.Equal(t, 3, report.Classes[0].Count)
+}
+
+func TestAggregatorReportCopies(t *testing.T) {
+	a := NewAggregator(sqlparser.NewTestParser())
+	a.Add(&Entry{Query: "select * from t", Database: "db", QueryTime: time.Second})
+	first := a.Report()
+	first.Classes[0].Tables[0] = "changed"
+
+	a.Add(&Entry{Query: "select * from t", Database: "db", QueryTime: time.Second})
+	second := a.Report()
+	assert.Equal(t, 1, first.Classes[0].Count)
+	assert.Equal(t, float64(1), first.Classes[0].QueryTime.Total)
+	assert.Equal(t, 2, second.Classes[0].Count)
+	assert.Equal(t, []string{"db.t"}, second.Classes[0].Tables)
+}
+
+func TestAggregatorUnparsed(t *testing.T) {
+	a := NewAggregator(sqlparser.NewTestParser())
+	a.Add(&Entry{Query: "frobnicate t where x = 1"})
+	a.Add(&Entry{Query: "frobnicate t\n where x = 2"})
+
+	report := a.Report()
+	assert.Equal(t, 2, report.Unparsed)
+	require.Len(t, report.Classes, 1)
+	assert.Equal(t, "`frobnicate` `t` WHERE `x` = ?", report.Classes[0].Fingerprint)
+	assert.Equal(t, 2, report.Classes[0].Count)
+}
+
+func TestReportJSON(t *testing.T) {
+	report, err := Aggregate(sqlparser.NewTestParser(), NewReader(openLog(t, "general.log"), GeneralLog))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteJSON(&buf))
+
+	var decoded struct {
+		Queries int `json:"queries"`
+		Classes []struct {
+			Fingerprint string   `json:"fingerprint"`
+			Count       int      `json:"count"`
+			Tables      []string `json:"tables"`
+			QueryTime   struct {
+				P95 float64 `json:"p95"`
+			} `json:"query_time"`
+		} `json:"classes"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, 3, decoded.Queries)
+	require.Len(t, decoded.Classes, 2)
+	assert.Equal(t, "select * from orders where customer_id = :bv1", decoded.Classes[0].Fingerprint)
+	assert.Equal(t, 2, decoded.Classes[0].Count)
+	assert.Equal(t, []string{"shop.orders"}, decoded.Classes[0].Tables)
+	assert.Equal(t, []string{"archive.orders"}, decoded.Classes[1].Tables)
+}
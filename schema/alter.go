@@ -0,0 +1,617 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// alterTable applies an ALTER TABLE statement. The options are applied in
+// order to a copy of the table, which replaces the table only if all of them
+// succeed.
+func (s *Schema) alterTable(stmt *sqlparser.AlterTable) error {
+	if !stmt.FullyParsed {
+		return unsupportedStatement(stmt)
+	}
+	db, table, err := s.table(stmt.Table)
+	if err != nil {
+		return err
+	}
+
+	alter := &alteration{
+		table:   &Table{create: sqlparser.CloneRefOfCreateTable(table.create)},
+		renamed: map[string]string{},
+	}
+	var rename *sqlparser.TableName
+	for _, option := range stmt.AlterOptions {
+		if option, ok := option.(*sqlparser.RenameTableName); ok {
+			rename = &option.Table
+			continue
+		}
+		if err := alter.apply(option); err != nil {
+			return err
+		}
+	}
+	if stmt.PartitionSpec != nil {
+		if err := alter.table.alterPartitions(stmt.PartitionSpec); err != nil {
+			return err
+		}
+	}
+	if stmt.PartitionOption != nil {
+		alter.table.create.TableSpec.PartitionOption = sqlparser.CloneRefOfPartitionOption(stmt.PartitionOption)
+	}
+	if err := alter.table.validate(); err != nil {
+		return err
+	}
+
+	target, name := db, table.Name()
+	if rename != nil {
+		if target, err = s.database(*rename); err != nil {
+			return err
+		}
+		name = rename.Name.String()
+		if (target != db || name != table.Name()) && target.exists(name) {
+			return &TableExistsError{Database: target.name, Table: name}
+		}
+		alter.table.create.Table.Name = rename.Name
+	}
+
+	delete(db.tables, table.Name())
+	target.tables[name] = alter.table
+	s.updateReferences(db.name, table.Name(), target.name, name, alter.renamed)
+	return nil
+}
+
+// updateReferences updates the foreign keys referencing a table after the
+// table or some of its columns have been renamed.
+func (s *Schema) updateReferences(fromDB, fromTable, toDB, toTable string, columns map[string]string) {
+	for _, db := range s.databases {
+		for _, table := range db.tables {
+			for _, fk := range table.ForeignKeys() {
+				ref := fk.Details.(*sqlparser.ForeignKeyDefinition).ReferenceDefinition
+				refDB := db.name
+				if ref.ReferencedTable.Qualifier.NotEmpty() {
+					refDB = ref.ReferencedTable.Qualifier.String()
+				}
+				if refDB != fromDB || ref.ReferencedTable.Name.String() != fromTable {
+					continue
+				}
+				if toDB != db.name {
+					ref.ReferencedTable.Qualifier = sqlparser.NewIdentifierCS(toDB)
+				} else {
+					ref.ReferencedTable.Qualifier = sqlparser.IdentifierCS{}
+				}
+				ref.ReferencedTable.Name = sqlparser.NewIdentifierCS(toTable)
+				for i, col := range ref.ReferencedColumns {
+					if name, ok := columns[col.Lowered()]; ok {
+						ref.ReferencedColumns[i] = sqlparser.NewIdentifierCI(name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// alteration is an ALTER TABLE statement being applied to a copy of a table.
+type alteration struct {
+	table *Table
+	// renamed maps the lowercase original names of renamed columns to their
+	// new names.
+	renamed map[string]string
+}
+
+func (a *alteration) apply(option sqlparser.AlterOption) error {
+	t := a.table
+	spec := t.create.TableSpec
+	switch option := option.(type) {
+	case *sqlparser.AddColumns:
+		for i, col := range option.Columns {
+			pos := len(spec.Columns)
+			if i == 0 {
+				var err error
+				if pos, err = t.position(option.First, option.After); err != nil {
+					return err
+				}
+			}
+			if err := t.addColumn(sqlparser.CloneRefOfColumnDefinition(col), pos); err != nil {
+				return err
+			}
+		}
+	case *sqlparser.AddIndexDefinition:
+		return t.addIndex(sqlparser.CloneRefOfIndexDefinition(option.IndexDefinition))
+	case *sqlparser.AddConstraintDefinition:
+		return t.addConstraint(sqlparser.CloneRefOfConstraintDefinition(option.ConstraintDefinition))
+	case *sqlparser.AlterCharset:
+		t.convertCharset(option)
+	case *sqlparser.AlterCheck:
+		constraint := t.Constraint(option.Name.String())
+		if constraint == nil {
+			return &UnknownKeyError{Table: t.Name(), Key: option.Name.String()}
+		}
+		check, ok := constraint.Details.(*sqlparser.CheckConstraintDefinition)
+		if !ok {
+			return &UnknownKeyError{Table: t.Name(), Key: option.Name.String()}
+		}
+		check.Enforced = option.Enforced
+	case *sqlparser.AlterColumn:
+		col := t.Column(option.Column.Name.String())
+		if col == nil {
+			return &UnknownColumnError{Table: t.Name(), Column: option.Column.Name.String()}
+		}
+		if col.Type.Options == nil {
+			col.Type.Options = &sqlparser.ColumnTypeOptions{}
+		}
+		switch {
+		case option.DropDefault:
+			col.Type.Options.Default = nil
+			col.Type.Options.DefaultLiteral = false
+		case option.DefaultVal != nil:
+			col.Type.Options.Default = sqlparser.CloneExpr(option.DefaultVal)
+			col.Type.Options.DefaultLiteral = option.DefaultLiteral
+		}
+		if option.Invisible != nil {
+			invisible := *option.Invisible
+			col.Type.Options.Invisible = &invisible
+		}
+	case *sqlparser.AlterIndex:
+		index := t.Index(option.Name.String())
+		if index == nil {
+			return &UnknownKeyError{Table: t.Name(), Key: option.Name.String()}
+		}
+		setVisibility(index, option.Invisible)
+	case *sqlparser.ChangeColumn:
+		return a.changeColumn(option.OldColumn.Name.String(), option.NewColDefinition, option.First, option.After)
+	case *sqlparser.ModifyColumn:
+		return a.changeColumn(option.NewColDefinition.Name.String(), option.NewColDefinition, option.First, option.After)
+	case *sqlparser.RenameColumn:
+		i := t.columnIndex(option.OldName.Name.String())
+		if i < 0 {
+			return &UnknownColumnError{Table: t.Name(), Column: option.OldName.Name.String()}
+		}
+		col := spec.Columns[i]
+		if j := t.columnIndex(option.NewName.Name.String()); j >= 0 && j != i {
+			return &DuplicateColumnError{Table: t.Name(), Column: option.NewName.Name.String()}
+		}
+		a.renameColumn(col.Name, option.NewName.Name)
+		col.Name = option.NewName.Name
+	case *sqlparser.DropColumn:
+		return t.dropColumn(option.Name.Name.String())
+	case *sqlparser.DropKey:
+		return t.dropKey(option)
+	case *sqlparser.RenameIndex:
+		index := t.Index(option.OldName.String())
+		if index == nil || index.Info.Type == sqlparser.IndexTypePrimary {
+			return &UnknownKeyError{Table: t.Name(), Key: option.OldName.String()}
+		}
+		if other := t.Index(option.NewName.String()); (other != nil && other != index) || option.NewName.EqualString(PrimaryKeyName) {
+			return &DuplicateKeyError{Table: t.Name(), Key: option.NewName.String()}
+		}
+		index.Info.Name = option.NewName
+	case sqlparser.TableOptions:
+		t.setOptions(option)
+	case *sqlparser.Force, *sqlparser.KeyState, *sqlparser.LockOption, sqlparser.AlgorithmValue,
+		*sqlparser.OrderByOption, *sqlparser.Validation, *sqlparser.TablespaceOperation:
+		// These options do not change the definition of the table.
+	default:
+		return &UnsupportedAlterOptionError{Table: t.Name(), Option: sqlparser.String(option)}
+	}
+	return nil
+}
+
+// position returns the position of a column placed FIRST or AFTER another
+// column, or the position past the last column if neither is given.
+func (t *Table) position(first bool, after *sqlparser.ColName) (int, error) {
+	switch {
+	case first:
+		return 0, nil
+	case after != nil:
+		i := t.columnIndex(after.Name.String())
+		if i < 0 {
+			return 0, &UnknownColumnError{Table: t.Name(), Column: after.Name.String()}
+		}
+		return i + 1, nil
+	}
+	return len(t.create.TableSpec.Columns), nil
+}
+
+// addColumn inserts a column at the given position, moving its column-level
+// key to the index list.
+func (t *Table) addColumn(col *sqlparser.ColumnDefinition, pos int) error {
+	if t.Column(col.Name.String()) != nil {
+		return &DuplicateColumnError{Table: t.Name(), Column: col.Name.String()}
+	}
+	spec := t.create.TableSpec
+	spec.Columns = append(spec.Columns, nil)
+	copy(spec.Columns[pos+1:], spec.Columns[pos:])
+	spec.Columns[pos] = col
+	return t.addColumnKey(col)
+}
+
+// changeColumn replaces the definition of a column, possibly renaming and
+// moving it, as CHANGE COLUMN and MODIFY COLUMN do.
+func (a *alteration) changeColumn(name string, def *sqlparser.ColumnDefinition, first bool, after *sqlparser.ColName) error {
+	t := a.table
+	spec := t.create.TableSpec
+	i := t.columnIndex(name)
+	if i < 0 {
+		return &UnknownColumnError{Table: t.Name(), Column: name}
+	}
+	old := spec.Columns[i]
+	if j := t.columnIndex(def.Name.String()); j >= 0 && j != i {
+		return &DuplicateColumnError{Table: t.Name(), Column: def.Name.String()}
+	}
+	// A column that is part of the primary key stays NOT NULL.
+	col := sqlparser.CloneRefOfColumnDefinition(def)
+	if pk := t.PrimaryKey(); pk != nil && indexHasColumn(pk, old.Name.String()) {
+		setNotNull(col)
+	}
+	if !old.Name.Equal(col.Name) {
+		a.renameColumn(old.Name, col.Name)
+	}
+
+	spec.Columns = append(spec.Columns[:i], spec.Columns[i+1:]...)
+	pos := i
+	if first || after != nil {
+		var err error
+		if pos, err = t.position(first, after); err != nil {
+			return err
+		}
+	}
+	spec.Columns = append(spec.Columns, nil)
+	copy(spec.Columns[pos+1:], spec.Columns[pos:])
+	spec.Columns[pos] = col
+	return t.addColumnKey(col)
+}
+
+// renameColumn updates the indexes, constraints and generated columns of the
+// table that refer to a renamed column.
+func (a *alteration) renameColumn(from, to sqlparser.IdentifierCI) {
+	t := a.table
+	original := from.Lowered()
+	for orig, name := range a.renamed {
+		if strings.EqualFold(name, from.String()) {
+			original = orig
+		}
+	}
+	a.renamed[original] = to.String()
+
+	renameIn := func(expr sqlparser.Expr) {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			if col, ok := node.(*sqlparser.ColName); ok && col.Name.Equal(from) {
+				col.Name = to
+			}
+			return true, nil
+		}, expr)
+	}
+	spec := t.create.TableSpec
+	for _, col := range spec.Columns {
+		if col.Type.Options != nil && col.Type.Options.As != nil {
+			renameIn(col.Type.Options.As)
+		}
+	}
+	for _, index := range spec.Indexes {
+		for _, part := range index.Columns {
+			if part.Expression != nil {
+				renameIn(part.Expression)
+			} else if part.Column.Equal(from) {
+				part.Column = to
+			}
+		}
+	}
+	for _, constraint := range spec.Constraints {
+		switch details := constraint.Details.(type) {
+		case *sqlparser.ForeignKeyDefinition:
+			renameColumns(details.Source, from, to)
+			ref := details.ReferenceDefinition
+			if ref.ReferencedTable.Qualifier.IsEmpty() && ref.ReferencedTable.Name.String() == t.Name() {
+				renameColumns(ref.ReferencedColumns, from, to)
+			}
+		case *sqlparser.CheckConstraintDefinition:
+			renameIn(details.Expr)
+		}
+	}
+}
+
+func renameColumns(cols sqlparser.Columns, from, to sqlparser.IdentifierCI) {
+	for i, col := range cols {
+		if col.Equal(from) {
+			cols[i] = to
+		}
+	}
+}
+
+// dropColumn drops a column. The column is removed from the indexes that use
+// it, and indexes left without columns are dropped. Check constraints that
+// only use the column are dropped with it.
+func (t *Table) dropColumn(name string) error {
+	spec := t.create.TableSpec
+	i := t.columnIndex(name)
+	if i < 0 {
+		return &UnknownColumnError{Table: t.Name(), Column: name}
+	}
+	if len(spec.Columns) == 1 {
+		return &DropAllColumnsError{Table: t.Name()}
+	}
+	for _, col := range spec.Columns {
+		if col.Type.Options != nil && col.Type.Options.As != nil && references(col.Type.Options.As, name) {
+			return &DependentColumnError{Table: t.Name(), Column: name, Dependent: col.Name.String()}
+		}
+	}
+	var constraints []*sqlparser.ConstraintDefinition
+	for _, constraint := range spec.Constraints {
+		switch details := constraint.Details.(type) {
+		case *sqlparser.ForeignKeyDefinition:
+			if details.Source.FindColumn(sqlparser.NewIdentifierCI(name)) >= 0 {
+				return &DependentColumnError{Table: t.Name(), Column: name, Dependent: constraint.Name.String()}
+			}
+		case *sqlparser.CheckConstraintDefinition:
+			if references(details.Expr, name) {
+				for _, col := range columnsOf(details.Expr) {
+					if !col.EqualString(name) {
+						return &DependentColumnError{Table: t.Name(), Column: name, Dependent: constraint.Name.String()}
+					}
+				}
+				continue
+			}
+		}
+		constraints = append(constraints, constraint)
+	}
+	spec.Constraints = constraints
+
+	var indexes []*sqlparser.IndexDefinition
+	for _, index := range spec.Indexes {
+		var parts []*sqlparser.IndexColumn
+		for _, part := range index.Columns {
+			if part.Expression != nil && references(part.Expression, name) {
+				return &DependentColumnError{Table: t.Name(), Column: name, Dependent: index.Info.Name.String()}
+			}
+			if part.Expression == nil && part.Column.EqualString(name) {
+				continue
+			}
+			parts = append(parts, part)
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		index.Columns = parts
+		indexes = append(indexes, index)
+	}
+	spec.Indexes = indexes
+	spec.Columns = append(spec.Columns[:i], spec.Columns[i+1:]...)
+	return nil
+}
+
+// dropKey applies DROP PRIMARY KEY, DROP INDEX, DROP FOREIGN KEY, DROP CHECK
+// and DROP CONSTRAINT.
+func (t *Table) dropKey(option *sqlparser.DropKey) error {
+	name := option.Name.String()
+	switch option.Type {
+	case sqlparser.PrimaryKeyType:
+		if t.PrimaryKey() == nil {
+			return &UnknownKeyError{Table: t.Name(), Key: PrimaryKeyName}
+		}
+		return t.dropIndex(PrimaryKeyName)
+	case sqlparser.NormalKeyType:
+		return t.dropIndex(name)
+	case sqlparser.ForeignKeyType:
+		if constraint := t.Constraint(name); constraint != nil {
+			if _, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition); ok {
+				return t.dropConstraint(name)
+			}
+		}
+	case sqlparser.CheckKeyType:
+		// DROP CONSTRAINT drops a check, a foreign key or a unique key.
+		if t.Constraint(name) != nil {
+			return t.dropConstraint(name)
+		}
+		if index := t.Index(name); index != nil && index.Info.Type == sqlparser.IndexTypeUnique {
+			return t.dropIndex(name)
+		}
+	}
+	return &UnknownKeyError{Table: t.Name(), Key: name}
+}
+
+func (t *Table) dropIndex(name string) error {
+	spec := t.create.TableSpec
+	i := t.indexIndex(name)
+	if i < 0 {
+		return &UnknownKeyError{Table: t.Name(), Key: name}
+	}
+	for _, fk := range t.ForeignKeys() {
+		details := fk.Details.(*sqlparser.ForeignKeyDefinition)
+		if spec.Indexes[i] == t.foreignKeyIndex(details.Source, "") && t.foreignKeyIndex(details.Source, spec.Indexes[i].Info.Name.String()) == nil {
+			return &IndexNeededByForeignKeyError{Table: t.Name(), Index: name, ForeignKey: fk.Name.String()}
+		}
+	}
+	spec.Indexes = append(spec.Indexes[:i], spec.Indexes[i+1:]...)
+	return nil
+}
+
+func (t *Table) dropConstraint(name string) error {
+	spec := t.create.TableSpec
+	i := t.constraintIndex(name)
+	if i < 0 {
+		return &UnknownKeyError{Table: t.Name(), Key: name}
+	}
+	spec.Constraints = append(spec.Constraints[:i], spec.Constraints[i+1:]...)
+	return nil
+}
+
+// convertCharset applies CONVERT TO CHARACTER SET: the table gets the new
+// character set and collation, which all columns inherit.
+func (t *Table) convertCharset(option *sqlparser.AlterCharset) {
+	options := sqlparser.TableOptions{{Name: "charset", String: option.CharacterSet}}
+	if option.Collate != "" {
+		options = append(options, &sqlparser.TableOption{Name: "collate", String: option.Collate})
+	}
+	t.setOptions(options)
+	for _, col := range t.create.TableSpec.Columns {
+		col.Type.Charset = sqlparser.ColumnCharset{}
+		if col.Type.Options != nil {
+			col.Type.Options.Collate = ""
+		}
+	}
+}
+
+// setOptions sets table options, replacing existing options with the same
+// name. An empty comment removes the comment.
+func (t *Table) setOptions(options sqlparser.TableOptions) {
+	spec := t.create.TableSpec
+	for _, option := range options {
+		option = sqlparser.CloneRefOfTableOption(option)
+		remove := strings.EqualFold(option.Name, "comment") && option.Value != nil && option.Value.Val == ""
+		replaced := false
+		var kept sqlparser.TableOptions
+		for _, existing := range spec.Options {
+			if strings.EqualFold(existing.Name, option.Name) {
+				if !remove && !replaced {
+					kept = append(kept, option)
+				}
+				replaced = true
+				continue
+			}
+			kept = append(kept, existing)
+		}
+		if !replaced && !remove {
+			kept = append(kept, option)
+		}
+		spec.Options = kept
+	}
+}
+
+func setVisibility(index *sqlparser.IndexDefinition, invisible bool) {
+	var options []*sqlparser.IndexOption
+	for _, option := range index.Options {
+		if !strings.EqualFold(option.Name, "visible") && !strings.EqualFold(option.Name, "invisible") {
+			options = append(options, option)
+		}
+	}
+	if invisible {
+		options = append(options, &sqlparser.IndexOption{Name: "invisible"})
+	}
+	index.Options = options
+}
+
+func indexHasColumn(index *sqlparser.IndexDefinition, name string) bool {
+	for _, part := range index.Columns {
+		if part.Expression == nil && part.Column.EqualString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// alterPartitions applies the partition operations of an ALTER TABLE
+// statement.
+func (t *Table) alterPartitions(spec *sqlparser.PartitionSpec) error {
+	if spec.Action == sqlparser.RemoveAction {
+		if t.Partitioning() == nil {
+			return &NotPartitionedError{Table: t.Name()}
+		}
+		t.create.TableSpec.PartitionOption = nil
+		return nil
+	}
+	partitioning := t.Partitioning()
+	if partitioning == nil {
+		return &NotPartitionedError{Table: t.Name()}
+	}
+
+	switch spec.Action {
+	case sqlparser.AddAction:
+		if len(spec.Definitions) == 0 && spec.Number != nil {
+			n, err := strconv.Atoi(spec.Number.Val)
+			if err != nil {
+				return err
+			}
+			partitioning.Partitions += n
+			return nil
+		}
+		for _, def := range spec.Definitions {
+			if t.partitionIndex(def.Name.String()) >= 0 {
+				return &DuplicatePartitionError{Table: t.Name(), Partition: def.Name.String()}
+			}
+			partitioning.Definitions = append(partitioning.Definitions, sqlparser.CloneRefOfPartitionDefinition(def))
+		}
+	case sqlparser.DropAction:
+		for _, name := range spec.Names {
+			i := t.partitionIndex(name.String())
+			if i < 0 {
+				return &UnknownPartitionError{Table: t.Name(), Partition: name.String()}
+			}
+			partitioning.Definitions = append(partitioning.Definitions[:i], partitioning.Definitions[i+1:]...)
+		}
+	case sqlparser.ReorganizeAction:
+		if len(spec.Names) == 0 {
+			break
+		}
+		pos := -1
+		for _, name := range spec.Names {
+			i := t.partitionIndex(name.String())
+			if i < 0 {
+				return &UnknownPartitionError{Table: t.Name(), Partition: name.String()}
+			}
+			if pos < 0 || i < pos {
+				pos = i
+			}
+			partitioning.Definitions = append(partitioning.Definitions[:i], partitioning.Definitions[i+1:]...)
+		}
+		var defs []*sqlparser.PartitionDefinition
+		for _, def := range spec.Definitions {
+			if t.partitionIndex(def.Name.String()) >= 0 {
+				return &DuplicatePartitionError{Table: t.Name(), Partition: def.Name.String()}
+			}
+			defs = append(defs, sqlparser.CloneRefOfPartitionDefinition(def))
+		}
+		rest := append(defs, partitioning.Definitions[pos:]...)
+		partitioning.Definitions = append(partitioning.Definitions[:pos:pos], rest...)
+	case sqlparser.CoalesceAction:
+		if spec.Number == nil {
+			break
+		}
+		n, err := strconv.Atoi(spec.Number.Val)
+		if err != nil {
+			return err
+		}
+		partitioning.Partitions -= n
+	default:
+		if spec.IsAll {
+			break
+		}
+		for _, name := range spec.Names {
+			if t.partitionIndex(name.String()) < 0 {
+				return &UnknownPartitionError{Table: t.Name(), Partition: name.String()}
+			}
+		}
+	}
+	return nil
+}
+
+func (t *Table) partitionIndex(name string) int {
+	partitioning := t.Partitioning()
+	if partitioning == nil {
+		return -1
+	}
+	for i, def := range partitioning.Definitions {
+		if def.Name.EqualString(name) {
+			return i
+		}
+	}
+	return -1
+}
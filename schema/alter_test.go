@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestAlterTable(t *testing.T) {
+	testcases := []struct {
+		name   string
+		create string
+		alter  string
+		out    string
+	}{{
+		name:   "add columns first and after",
+		create: "create table t (a int, b int)",
+		alter:  "alter table t add column z int first, add column c int after a, add column d int",
+		out:    "create table t (\n\tz int,\n\ta int,\n\tc int,\n\tb int,\n\td int\n)",
+	}, {
+		name:   "add column with key",
+		create: "create table t (a int)",
+		alter:  "alter table t add column id int primary key first",
+		out:    "create table t (\n\tid int not null,\n\ta int,\n\tprimary key (id)\n)",
+	}, {
+		name:   "modify and change column",
+		create: "create table t (a int, b int, c int, key bc (b, c))",
+		alter:  "alter table t modify column c bigint first, change column b bb int not null after c",
+		out:    "create table t (\n\tc bigint,\n\tbb int not null,\n\ta int,\n\tkey bc (bb, c)\n)",
+	}, {
+		name:   "rename column",
+		create: "create table t (a int, b int as (a + 1), key ((a * 2)), check (a > 0))",
+		alter:  "alter table t rename column a to x",
+		out:    "create table t (\n\tx int,\n\tb int as (x + 1) virtual,\n\tkey functional_index ((x * 2)),\n\tconstraint t_chk_1 check (x > 0)\n)",
+	}, {
+		name:   "drop column",
+		create: "create table t (a int, b int, c int, key ab (a, b), key b (b), check (b > 0))",
+		alter:  "alter table t drop column b",
+		out:    "create table t (\n\ta int,\n\tc int,\n\tkey ab (a)\n)",
+	}, {
+		name:   "indexes",
+		create: "create table t (id int, a int, key a (a), key x (a, id))",
+		alter:  "alter table t add primary key (id), drop index a, rename index x to y, alter index y invisible, add unique (a)",
+		out:    "create table t (\n\tid int not null,\n\ta int,\n\tprimary key (id),\n\tkey y (a, id) invisible,\n\tunique key a (a)\n)",
+	}, {
+		name:   "drop primary key",
+		create: "create table t (id int primary key)",
+		alter:  "alter table t drop primary key",
+		out:    "create table t (\n\tid int not null\n)",
+	}, {
+		name:   "constraints",
+		create: "create table t (id int, p int, constraint c check (id > 0), foreign key (p) references p (id))",
+		alter:  "alter table t alter check c not enforced, drop foreign key t_ibfk_1, add foreign key (p) references q (id), drop constraint c",
+		out:    "create table t (\n\tid int,\n\tp int,\n\tkey p (p),\n\tconstraint t_ibfk_1 foreign key (p) references q (id)\n)",
+	}, {
+		name:   "defaults and options",
+		create: "create table t (a int, b varchar(10) character set latin1) engine InnoDB",
+		alter:  "alter table t alter column a set default 1, convert to character set utf8mb4, engine = MyISAM, comment 'x'",
+		out:    "create table t (\n\ta int default 1,\n\tb varchar(10)\n) engine MyISAM,\n  charset utf8mb4,\n  comment 'x'",
+	}, {
+		name:   "partitions",
+		create: "create table t (id int) partition by range (id) (partition p0 values less than (10), partition p1 values less than (20), partition p2 values less than maxvalue)",
+		alter:  "alter table t reorganize partition p1, p2 into (partition p1 values less than (30), partition p3 values less than maxvalue)",
+		out:    "create table t (\n\tid int\n)\npartition by range (id)\n(partition p0 values less than (10),\n partition p1 values less than (30),\n partition p3 values less than maxvalue)",
+	}, {
+		name:   "remove partitioning",
+		create: "create table t (id int) partition by hash (id) partitions 4",
+		alter:  "alter table t remove partitioning",
+		out:    "create table t (\n\tid int\n)",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			s, err := NewFromSQL(parser, tcase.create)
+			require.NoError(t, err)
+			require.NoError(t, s.ApplySQL(parser, tcase.alter))
+			assert.Equal(t, tcase.out, sqlparser.String(s.Database("").Table("t").CreateTable()))
+		})
+	}
+}
+
+func TestAlterTableErrors(t *testing.T) {
+	testcases := []struct {
+		create string
+		alter  string
+		err    string
+	}{{
+		create: "create table t (a int)",
+		alter:  "alter table t add column a int",
+		err:    "Duplicate column name 'a' in table 't'",
+	}, {
+		create: "create table t (a int)",
+		alter:  "alter table t add column b int after c",
+		err:    "Unknown column 'c' in table 't'",
+	}, {
+		create: "create table t (a int, b int)",
+		alter:  "alter table t rename column a to b",
+		err:    "Duplicate column name 'b' in table 't'",
+	}, {
+		create: "create table t (a int, b int)",
+		alter:  "alter table t change column c d int",
+		err:    "Unknown column 'c' in table 't'",
+	}, {
+		create: "create table t (a int)",
+		alter:  "alter table t drop column a",
+		err:    "You can't delete all columns of table 't' with ALTER TABLE; use DROP TABLE instead",
+	}, {
+		create: "create table t (a int, b int as (a + 1))",
+		alter:  "alter table t drop column a",
+		err:    "Column 'a' in table 't' is used by 'b'",
+	}, {
+		create: "create table t (a int, b int, check (a > b))",
+		alter:  "alter table t drop column a",
+		err:    "Column 'a' in table 't' is used by 't_chk_1'",
+	}, {
+		create: "create table t (a int, b int, foreign key (a) references p (id))",
+		alter:  "alter table t drop column a",
+		err:    "Column 'a' in table 't' is used by 't_ibfk_1'",
+	}, {
+		create: "create table t (a int, foreign key (a) references p (id))",
+		alter:  "alter table t drop index a",
+		err:    "Cannot drop index 'a' in table 't': needed in foreign key constraint 't_ibfk_1'",
+	}, {
+		create: "create table t (a int)",
+		alter:  "alter table t drop index a",
+		err:    "Can't DROP 'a'; check that column/key exists in table 't'",
+	}, {
+		create: "create table t (a int)",
+		alter:  "alter table t drop primary key",
+		err:    "Can't DROP 'PRIMARY'; check that column/key exists in table 't'",
+	}, {
+		create: "create table t (a int primary key)",
+		alter:  "alter table t add primary key (a)",
+		err:    "Multiple primary key defined in table 't'",
+	}, {
+		create: "create table t (a int, key k (a))",
+		alter:  "alter table t add key K (a)",
+		err:    "Duplicate key name 'K' in table 't'",
+	}, {
+		create: "create table t (a int); create table u (a int)",
+		alter:  "alter table t rename to u",
+		err:    "Table '.u' already exists",
+	}, {
+		create: "create table t (a int)",
+		alter:  "alter table t drop partition p0",
+		err:    "Partition management on a not partitioned table 't' is not possible",
+	}, {
+		create: "create table t (id int) partition by range (id) (partition p0 values less than (10))",
+		alter:  "alter table t drop partition p1",
+		err:    "Unknown partition 'p1' in table 't'",
+	}, {
+		create: "create table t (id int) partition by range (id) (partition p0 values less than (10))",
+		alter:  "alter table t add partition (partition p0 values less than (20))",
+		err:    "Duplicate partition name 'p0' in table 't'",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.alter, func(t *testing.T) {
+			s, err := NewFromSQL(parser, tcase.create)
+			require.NoError(t, err)
+			before := sqlparser.String(s.Database("").Table("t").CreateTable())
+
+			err = s.ApplySQL(parser, tcase.alter)
+			assert.EqualError(t, err, tcase.err)
+			assert.Equal(t, before, sqlparser.String(s.Database("").Table("t").CreateTable()), "failed ALTER must not change the table")
+		})
+	}
+}
+
+func TestAlterTableRenames(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, `
+create database other;
+create table p (id int primary key);
+create table c (id int, pid int, foreign key (pid) references p (id));
+alter table p rename column id to pk, rename to other.parent;
+`)
+	require.NoError(t, err)
+	assert.Nil(t, s.Database("").Table("p"))
+	require.NotNil(t, s.Database("other").Table("parent"))
+
+	fk := s.Database("").Table("c").ForeignKeys()[0]
+	assert.Equal(t, "constraint c_ibfk_1 foreign key (pid) references other.parent (pk)", sqlparser.String(fk))
+}
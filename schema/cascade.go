@@ -0,0 +1,371 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// CascadeAction is what a statement does to the rows of a table, either
+// directly or through a foreign key.
+type CascadeAction int8
+
+const (
+	// CascadeDelete deletes the rows.
+	CascadeDelete CascadeAction = iota
+	// CascadeUpdate updates columns of the rows.
+	CascadeUpdate
+	// CascadeSetNull sets the foreign key columns of the rows to NULL.
+	CascadeSetNull
+	// CascadeSetDefault sets the foreign key columns of the rows to their
+	// default. InnoDB rejects tables with this action.
+	CascadeSetDefault
+	// CascadeRestrict makes the statement fail if there are matching rows.
+	CascadeRestrict
+)
+
+// String returns the action as written in the referential actions of
+// foreign keys and in statements.
+func (a CascadeAction) String() string {
+	switch a {
+	case CascadeDelete:
+		return "DELETE"
+	case CascadeUpdate:
+		return "UPDATE"
+	case CascadeSetNull:
+		return "SET NULL"
+	case CascadeSetDefault:
+		return "SET DEFAULT"
+	case CascadeRestrict:
+		return "RESTRICT"
+	}
+	return fmt.Sprintf("CascadeAction(%d)", int8(a))
+}
+
+// CascadeNode is a table reached by a DELETE or UPDATE statement, either as
+// a target of the statement or through the foreign keys of the tables it
+// changes.
+type CascadeNode struct {
+	Table  sqlparser.TableName
+	Action CascadeAction
+	// ForeignKey is the foreign key of Table the change reaches it through.
+	// It is empty for the targets of the statement.
+	ForeignKey string
+	// Columns are the columns of Table the action changes or, for RESTRICT,
+	// the foreign key columns that must not match.
+	Columns []string
+	// Depth is the number of foreign keys between the target of the
+	// statement and Table.
+	Depth int
+	// Cycle is set when the foreign key was already followed on the way from
+	// the target. The chain is not followed further.
+	Cycle    bool
+	Children []*CascadeNode
+}
+
+// CascadeTree holds the tables a DELETE or UPDATE statement changes or is
+// blocked by.
+type CascadeTree struct {
+	Roots []*CascadeNode
+}
+
+// Nodes returns the nodes of the tree in depth-first order.
+func (t *CascadeTree) Nodes() []*CascadeNode {
+	var nodes []*CascadeNode
+	var visit func(node *CascadeNode)
+	visit = func(node *CascadeNode) {
+		nodes = append(nodes, node)
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+	for _, root := range t.Roots {
+		visit(root)
+	}
+	return nodes
+}
+
+// Restricted returns the nodes whose foreign keys make the statement fail
+// if they have rows matching the changed ones.
+func (t *CascadeTree) Restricted() []*CascadeNode {
+	var nodes []*CascadeNode
+	for _, node := range t.Nodes() {
+		if node.Action == CascadeRestrict {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Depth returns the length of the longest chain of foreign keys in the
+// tree.
+func (t *CascadeTree) Depth() int {
+	depth := 0
+	for _, node := range t.Nodes() {
+		depth = max(depth, node.Depth)
+	}
+	return depth
+}
+
+// String returns the tree with one line per node, indented by depth.
+func (t *CascadeTree) String() string {
+	var b strings.Builder
+	for _, node := range t.Nodes() {
+		b.WriteString(strings.Repeat("  ", node.Depth))
+		fmt.Fprintf(&b, "%s %s", node.Action, sqlparser.String(node.Table))
+		if node.ForeignKey != "" {
+			fmt.Fprintf(&b, " via foreign key %s", node.ForeignKey)
+		}
+		if len(node.Columns) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(node.Columns, ", "))
+		}
+		switch {
+		case node.Action == CascadeRestrict:
+			b.WriteString(": fails if rows match")
+		case node.Cycle:
+			b.WriteString(": cycle, repeats recursively")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Cascade returns the tables a DELETE or UPDATE statement changes through
+// the ON DELETE and ON UPDATE actions of foreign keys, and the ones whose
+// RESTRICT or NO ACTION foreign keys make it fail when they have matching
+// rows.
+//
+// Deleting a row affects every foreign key referencing its table, while
+// updating one only affects the foreign keys referencing the updated
+// columns. SET NULL and ON UPDATE CASCADE update the foreign key columns of
+// the child table, which in turn affects the foreign keys referencing those.
+func (s *Schema) Cascade(stmt sqlparser.Statement) (*CascadeTree, error) {
+	tables, err := s.dmlTables(stmt)
+	if err != nil {
+		return nil, err
+	}
+	tree := &CascadeTree{}
+	switch stmt := stmt.(type) {
+	case *sqlparser.Delete:
+		// A single-table DELETE has no targets: it deletes from its only
+		// table.
+		targets := stmt.Targets
+		if len(targets) == 0 {
+			for alias := range tables {
+				targets = append(targets, sqlparser.NewTableName(alias))
+			}
+		}
+		for _, target := range targets {
+			key, ok := tables.lookup(target)
+			if !ok {
+				return nil, &UnknownTableError{Database: target.Qualifier.String(), Table: target.Name.String()}
+			}
+			root := &CascadeNode{Table: key.tableName(), Action: CascadeDelete}
+			s.cascade(root, key, nil, nil)
+			tree.Roots = append(tree.Roots, root)
+		}
+	case *sqlparser.Update:
+		columns := map[tableKey][]string{}
+		var keys []tableKey
+		for _, expr := range stmt.Exprs {
+			key, err := s.updatedTable(tables, expr.Name)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := columns[key]; !ok {
+				keys = append(keys, key)
+			}
+			if !slices.Contains(columns[key], expr.Name.Name.String()) {
+				columns[key] = append(columns[key], expr.Name.Name.String())
+			}
+		}
+		for _, key := range keys {
+			root := &CascadeNode{Table: key.tableName(), Action: CascadeUpdate, Columns: columns[key]}
+			s.cascade(root, key, columns[key], nil)
+			tree.Roots = append(tree.Roots, root)
+		}
+	default:
+		return nil, unsupportedStatement(stmt)
+	}
+	return tree, nil
+}
+
+// fkPath identifies a foreign key on the path from the target of a
+// statement.
+type fkPath struct {
+	table tableKey
+	name  string
+}
+
+// cascade adds the children of a node for the tables whose foreign keys
+// reference the given table. Nil columns mean the rows are deleted;
+// otherwise only the foreign keys referencing one of the columns are
+// affected.
+func (s *Schema) cascade(node *CascadeNode, parent tableKey, columns []string, path []fkPath) {
+	for _, db := range s.Databases() {
+		for _, table := range db.Tables() {
+			key := tableKey{db.name, table.Name()}
+			for _, constraint := range table.ForeignKeys() {
+				fk := constraint.Details.(*sqlparser.ForeignKeyDefinition)
+				ref := fk.ReferenceDefinition
+				if key.resolve(ref.ReferencedTable) != parent {
+					continue
+				}
+				if columns != nil && !slices.ContainsFunc(ref.ReferencedColumns, func(col sqlparser.IdentifierCI) bool {
+					return slices.ContainsFunc(columns, col.EqualString)
+				}) {
+					continue
+				}
+				action := ref.OnDelete
+				if columns != nil {
+					action = ref.OnUpdate
+				}
+				child := &CascadeNode{
+					Table:      key.tableName(),
+					Action:     cascadeAction(action, columns == nil),
+					ForeignKey: constraint.Name.String(),
+					Depth:      node.Depth + 1,
+				}
+				for _, col := range fk.Source {
+					child.Columns = append(child.Columns, col.String())
+				}
+				node.Children = append(node.Children, child)
+
+				step := fkPath{key, constraint.Name.String()}
+				if slices.Contains(path, step) {
+					child.Cycle = true
+					continue
+				}
+				switch child.Action {
+				case CascadeDelete:
+					s.cascade(child, key, nil, append(path, step))
+				case CascadeUpdate, CascadeSetNull, CascadeSetDefault:
+					s.cascade(child, key, child.Columns, append(path, step))
+				}
+			}
+		}
+	}
+}
+
+// cascadeAction returns what a referential action does to the rows of the
+// child table when the parent rows are deleted or updated.
+func cascadeAction(action sqlparser.ReferenceAction, delete bool) CascadeAction {
+	switch {
+	case action.IsCascade() && delete:
+		return CascadeDelete
+	case action.IsCascade():
+		return CascadeUpdate
+	case action == sqlparser.SetNull:
+		return CascadeSetNull
+	case action == sqlparser.SetDefault:
+		return CascadeSetDefault
+	default:
+		return CascadeRestrict
+	}
+}
+
+// dmlTableMap maps the names and aliases tables are referred to by in a DML
+// statement to their tables.
+type dmlTableMap map[string]tableKey
+
+func (m dmlTableMap) lookup(name sqlparser.TableName) (tableKey, bool) {
+	if name.Qualifier.NotEmpty() {
+		for _, key := range m {
+			if key == (tableKey{name.Qualifier.String(), name.Name.String()}) {
+				return key, true
+			}
+		}
+		return tableKey{}, false
+	}
+	key, ok := m[name.Name.String()]
+	return key, ok
+}
+
+// dmlTables returns the tables of a DELETE or UPDATE statement by the name
+// or alias they are referred to by. Derived tables are skipped.
+func (s *Schema) dmlTables(stmt sqlparser.Statement) (dmlTableMap, error) {
+	var exprs []sqlparser.TableExpr
+	switch stmt := stmt.(type) {
+	case *sqlparser.Delete:
+		exprs = stmt.TableExprs
+	case *sqlparser.Update:
+		exprs = stmt.TableExprs
+	}
+	tables := dmlTableMap{}
+	for _, expr := range exprs {
+		err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.DerivedTable:
+				return false, nil
+			case *sqlparser.AliasedTableExpr:
+				name, ok := node.Expr.(sqlparser.TableName)
+				if !ok {
+					return false, nil
+				}
+				db, table, err := s.table(name)
+				if err != nil {
+					return false, err
+				}
+				alias := name.Name.String()
+				if node.As.NotEmpty() {
+					alias = node.As.String()
+				}
+				tables[alias] = tableKey{db.name, table.Name()}
+				return false, nil
+			}
+			return true, nil
+		}, expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tables, nil
+}
+
+// updatedTable returns the table of a column set by an UPDATE statement.
+// Unqualified columns belong to the only table of the statement that has
+// them.
+func (s *Schema) updatedTable(tables dmlTableMap, col *sqlparser.ColName) (tableKey, error) {
+	if col.Qualifier.NonEmpty() {
+		key, ok := tables.lookup(col.Qualifier)
+		if !ok {
+			return tableKey{}, &UnknownTableError{Database: col.Qualifier.Qualifier.String(), Table: col.Qualifier.Name.String()}
+		}
+		return key, nil
+	}
+	var found []tableKey
+	var names []string
+	for alias, key := range tables {
+		names = append(names, alias)
+		if s.databases[key.db].tables[key.name].Column(col.Name.String()) != nil && !slices.Contains(found, key) {
+			found = append(found, key)
+		}
+	}
+	switch len(found) {
+	case 0:
+		slices.Sort(names)
+		return tableKey{}, &UnknownColumnError{Table: strings.Join(names, ", "), Column: col.Name.String()}
+	case 1:
+		return found[0], nil
+	default:
+		return tableKey{}, &AmbiguousColumnError{Column: col.Name.String()}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestCascade(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		tree     string
+		depth    int
+		restrict []string
+	}{{
+		sql: "delete from customer where id = 1",
+		tree: "DELETE customer\n" +
+			"  DELETE orders via foreign key orders_ibfk_1 (customer_id)\n" +
+			"    DELETE item via foreign key item_ibfk_1 (order_id)\n" +
+			"      DELETE item via foreign key item_ibfk_2 (parent_id)\n" +
+			"        DELETE item via foreign key item_ibfk_2 (parent_id): cycle, repeats recursively\n" +
+			"    RESTRICT payment via foreign key payment_ibfk_1 (order_id): fails if rows match\n" +
+			"  SET NULL review via foreign key review_ibfk_1 (customer_id)\n",
+		depth:    4,
+		restrict: []string{"payment"},
+	}, {
+		sql:  "update customer set email = 'x' where id = 1",
+		tree: "UPDATE customer (email)\n",
+	}, {
+		sql: "update orders o set o.id = o.id + 100",
+		tree: "UPDATE orders (id)\n" +
+			"  UPDATE item via foreign key item_ibfk_1 (order_id)\n" +
+			"  RESTRICT payment via foreign key payment_ibfk_1 (order_id): fails if rows match\n",
+		depth:    1,
+		restrict: []string{"payment"},
+	}, {
+		sql: "delete o, r from orders o join review r on r.customer_id = o.customer_id",
+		tree: "DELETE orders\n" +
+			"  DELETE item via foreign key item_ibfk_1 (order_id)\n" +
+			"    DELETE item via foreign key item_ibfk_2 (parent_id)\n" +
+			"      DELETE item via foreign key item_ibfk_2 (parent_id): cycle, repeats recursively\n" +
+			"  RESTRICT payment via foreign key payment_ibfk_1 (order_id): fails if rows match\n" +
+			"DELETE review\n",
+		depth:    3,
+		restrict: []string{"payment"},
+	}, {
+		sql: "delete from shop.archive",
+		tree: "DELETE shop.archive\n" +
+			"  DELETE orders via foreign key orders_ibfk_2 (archive_id)\n" +
+			"    DELETE item via foreign key item_ibfk_1 (order_id)\n" +
+			"      DELETE item via foreign key item_ibfk_2 (parent_id)\n" +
+			"        DELETE item via foreign key item_ibfk_2 (parent_id): cycle, repeats recursively\n" +
+			"    RESTRICT payment via foreign key payment_ibfk_1 (order_id): fails if rows match\n",
+		depth:    4,
+		restrict: []string{"payment"},
+	}}
+
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, `
+		create database shop;
+		create table shop.archive (id int primary key);
+		create table customer (id int primary key, email varchar(100));
+		create table orders (id int primary key, customer_id int, archive_id int,
+			foreign key (customer_id) references customer (id) on delete cascade,
+			foreign key (archive_id) references shop.archive (id) on delete cascade);
+		create table item (id int primary key, order_id int, parent_id int,
+			foreign key (order_id) references orders (id) on delete cascade on update cascade,
+			foreign key (parent_id) references item (id) on delete cascade);
+		create table payment (id int primary key, order_id int, foreign key (order_id) references orders (id));
+		create table review (id int primary key, customer_id int, foreign key (customer_id) references customer (id) on delete set null);
+	`)
+	require.NoError(t, err)
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := parser.Parse(tcase.sql)
+			require.NoError(t, err)
+			tree, err := s.Cascade(stmt)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.tree, tree.String())
+			assert.Equal(t, tcase.depth, tree.Depth())
+			var restrict []string
+			for _, node := range tree.Restricted() {
+				restrict = append(restrict, sqlparser.String(node.Table))
+			}
+			assert.Equal(t, tcase.restrict, restrict)
+		})
+	}
+}
+
+func TestCascadeErrors(t *testing.T) {
+	testcases := []struct {
+		sql string
+		err string
+	}{{
+		sql: "delete from missing",
+		err: "Table '.missing' doesn't exist",
+	}, {
+		sql: "update a join b on a.id = b.id set name = 'x'",
+		err: "Column 'name' in field list is ambiguous",
+	}, {
+		sql: "update a set nope = 1",
+		err: "Unknown column 'nope' in table 'a'",
+	}, {
+		sql: "select * from a",
+		err: "unsupported statement: SELECT * FROM `a`",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, "create table a (id int, name int); create table b (id int, name int)")
+	require.NoError(t, err)
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := parser.Parse(tcase.sql)
+			require.NoError(t, err)
+			_, err = s.Cascade(stmt)
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
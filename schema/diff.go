@@ -0,0 +1,596 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// Diff returns the statements that turn the schema from into the schema to.
+// Applying them to from in order yields a schema equal to to.
+//
+// Objects are matched by name, so a renamed table, column or index shows up
+// as a drop and a create. The statements are ordered so that each one is
+// valid when it runs:
+//
+//  1. CREATE DATABASE for new databases;
+//  2. ALTER TABLE ... DROP FOREIGN KEY for removed and changed foreign keys;
+//  3. DROP VIEW for removed views;
+//  4. DROP TABLE for removed tables, referencing tables first;
+//  5. ALTER TABLE for changed columns, indexes, checks, options and
+//     partitioning;
+//  6. CREATE TABLE for new tables, referenced tables first;
+//  7. ALTER TABLE ... ADD FOREIGN KEY for new and changed foreign keys;
+//  8. ALTER TABLE for partition management;
+//  9. CREATE VIEW and CREATE OR REPLACE VIEW for new and changed views,
+//     referenced views first;
+//  10. DROP DATABASE for removed databases.
+//
+// Within each step, objects are ordered by database and name, so the output
+// is deterministic.
+func Diff(from, to *Schema) []sqlparser.Statement {
+	var createDBs, dropDBs []sqlparser.Statement
+	var dropFKs, alters, addFKs, partitions []sqlparser.Statement
+	var dropTables, createTables, dropViews, createViews []tableKey
+	fromTables := map[tableKey]*Table{}
+	toTables := map[tableKey]*Table{}
+	fromViews := map[tableKey]*View{}
+	toViews := map[tableKey]*View{}
+
+	for _, db := range from.Databases() {
+		for _, table := range db.Tables() {
+			fromTables[tableKey{db.name, table.Name()}] = table
+		}
+		for _, view := range db.Views() {
+			fromViews[tableKey{db.name, view.Name()}] = view
+		}
+		if to.Database(db.name) == nil {
+			dropDBs = append(dropDBs, &sqlparser.DropDatabase{DBName: sqlparser.NewIdentifierCS(db.name)})
+		}
+	}
+	for _, db := range to.Databases() {
+		if from.Database(db.name) == nil {
+			createDBs = append(createDBs, &sqlparser.CreateDatabase{
+				DBName:        sqlparser.NewIdentifierCS(db.name),
+				CreateOptions: append([]sqlparser.DatabaseOption(nil), db.options...),
+				FullyParsed:   true,
+			})
+		}
+		for _, table := range db.Tables() {
+			key := tableKey{db.name, table.Name()}
+			toTables[key] = table
+			source := fromTables[key]
+			if source == nil {
+				createTables = append(createTables, key)
+				continue
+			}
+			diff := diffTable(key.tableName(), source, table)
+			dropFKs = appendAlter(dropFKs, diff.dropForeignKeys)
+			alters = appendAlter(alters, diff.alter)
+			addFKs = appendAlter(addFKs, diff.addForeignKeys)
+			for _, alter := range diff.partitions {
+				partitions = append(partitions, alter)
+			}
+		}
+		for _, view := range db.Views() {
+			key := tableKey{db.name, view.Name()}
+			toViews[key] = view
+			if source := fromViews[key]; source == nil || !sqlparser.Equals.RefOfCreateView(source.create, view.create) {
+				createViews = append(createViews, key)
+			}
+		}
+	}
+	for _, db := range from.Databases() {
+		if to.Database(db.name) == nil {
+			// Dropping the database drops its tables and views. Foreign keys
+			// referencing them from other databases have been dropped in
+			// step 2.
+			continue
+		}
+		for _, table := range db.Tables() {
+			if key := (tableKey{db.name, table.Name()}); toTables[key] == nil {
+				dropTables = append(dropTables, key)
+			}
+		}
+		for _, view := range db.Views() {
+			if key := (tableKey{db.name, view.Name()}); toViews[key] == nil {
+				dropViews = append(dropViews, key)
+			}
+		}
+	}
+
+	var stmts []sqlparser.Statement
+	stmts = append(stmts, createDBs...)
+	stmts = append(stmts, dropFKs...)
+	for _, key := range reverse(sortViews(dropViews, fromViews)) {
+		stmts = append(stmts, &sqlparser.DropView{FromTables: sqlparser.TableNames{key.tableName()}})
+	}
+	for _, key := range reverse(sortTables(dropTables, fromTables)) {
+		stmts = append(stmts, &sqlparser.DropTable{FromTables: sqlparser.TableNames{key.tableName()}})
+	}
+	stmts = append(stmts, alters...)
+	for _, key := range sortTables(createTables, toTables) {
+		create := sqlparser.CloneRefOfCreateTable(toTables[key].create)
+		create.Table = key.tableName()
+		stmts = append(stmts, create)
+	}
+	stmts = append(stmts, addFKs...)
+	stmts = append(stmts, partitions...)
+	for _, key := range sortViews(createViews, toViews) {
+		create := sqlparser.CloneRefOfCreateView(toViews[key].create)
+		create.ViewName = key.tableName()
+		create.IsReplace = fromViews[key] != nil
+		stmts = append(stmts, create)
+	}
+	stmts = append(stmts, dropDBs...)
+	return stmts
+}
+
+// DiffTables returns the ALTER TABLE statements that turn the table from into
+// the table to, in the order they must be applied. The statements refer to
+// the table by its unqualified name. It returns nil if the tables are equal.
+func DiffTables(from, to *Table) []*sqlparser.AlterTable {
+	diff := diffTable(sqlparser.TableName{Name: from.create.Table.Name}, from, to)
+	var alters []*sqlparser.AlterTable
+	for _, alter := range []*sqlparser.AlterTable{diff.dropForeignKeys, diff.alter, diff.addForeignKeys} {
+		if alter != nil {
+			alters = append(alters, alter)
+		}
+	}
+	return append(alters, diff.partitions...)
+}
+
+// tableKey identifies a table or view across databases.
+type tableKey struct {
+	db, name string
+}
+
+func (k tableKey) tableName() sqlparser.TableName {
+	if k.db == "" {
+		return sqlparser.NewTableName(k.name)
+	}
+	return sqlparser.NewTableNameWithQualifier(k.name, k.db)
+}
+
+func (k tableKey) less(other tableKey) bool {
+	if k.db != other.db {
+		return k.db < other.db
+	}
+	return k.name < other.name
+}
+
+// resolve returns the key of a table referenced from the given database.
+func (k tableKey) resolve(name sqlparser.TableName) tableKey {
+	if name.Qualifier.NotEmpty() {
+		return tableKey{name.Qualifier.String(), name.Name.String()}
+	}
+	return tableKey{k.db, name.Name.String()}
+}
+
+// sortTables orders tables so that tables referenced by foreign keys come
+// before the tables referencing them.
+func sortTables(keys []tableKey, tables map[tableKey]*Table) []tableKey {
+	return topoSort(keys, func(key tableKey) []tableKey {
+		var deps []tableKey
+		for _, fk := range tables[key].ForeignKeys() {
+			ref := fk.Details.(*sqlparser.ForeignKeyDefinition).ReferenceDefinition
+			deps = append(deps, key.resolve(ref.ReferencedTable))
+		}
+		return deps
+	})
+}
+
+// sortViews orders views so that views used by other views come first.
+func sortViews(keys []tableKey, views map[tableKey]*View) []tableKey {
+	return topoSort(keys, func(key tableKey) []tableKey {
+		var deps []tableKey
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			if table, ok := node.(*sqlparser.AliasedTableExpr); ok {
+				if name, ok := table.Expr.(sqlparser.TableName); ok {
+					deps = append(deps, key.resolve(name))
+				}
+			}
+			return true, nil
+		}, views[key].create.Select)
+		return deps
+	})
+}
+
+// topoSort orders keys so that each key comes after those of its
+// dependencies that are part of keys. Cycles are broken arbitrarily but
+// deterministically.
+func topoSort(keys []tableKey, deps func(tableKey) []tableKey) []tableKey {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+	wanted := map[tableKey]bool{}
+	for _, key := range keys {
+		wanted[key] = true
+	}
+	visited := map[tableKey]bool{}
+	var sorted []tableKey
+	var visit func(key tableKey)
+	visit = func(key tableKey) {
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+		dependencies := deps(key)
+		sort.Slice(dependencies, func(i, j int) bool {
+			return dependencies[i].less(dependencies[j])
+		})
+		for _, dep := range dependencies {
+			if wanted[dep] {
+				visit(dep)
+			}
+		}
+		sorted = append(sorted, key)
+	}
+	for _, key := range keys {
+		visit(key)
+	}
+	return sorted
+}
+
+func reverse(keys []tableKey) []tableKey {
+	for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+	return keys
+}
+
+func appendAlter(stmts []sqlparser.Statement, alter *sqlparser.AlterTable) []sqlparser.Statement {
+	if alter == nil {
+		return stmts
+	}
+	return append(stmts, alter)
+}
+
+// tableDiff holds the statements that turn one table into another. Foreign
+// key changes and partition management are kept apart from the other
+// changes, since they must run at a different time or on their own.
+type tableDiff struct {
+	dropForeignKeys *sqlparser.AlterTable
+	alter           *sqlparser.AlterTable
+	addForeignKeys  *sqlparser.AlterTable
+	partitions      []*sqlparser.AlterTable
+}
+
+func diffTable(name sqlparser.TableName, from, to *Table) *tableDiff {
+	newAlter := func(options []sqlparser.AlterOption) *sqlparser.AlterTable {
+		if len(options) == 0 {
+			return nil
+		}
+		return &sqlparser.AlterTable{Table: name, AlterOptions: options, FullyParsed: true}
+	}
+
+	diff := &tableDiff{}
+	var options []sqlparser.AlterOption
+	dropFKs, addFKs := diffForeignKeys(from, to)
+	dropChecks, addChecks := diffChecks(from, to)
+	dropIndexes, addIndexes := diffIndexes(from, to)
+	options = append(options, dropChecks...)
+	options = append(options, dropIndexes...)
+	options = append(options, diffColumns(from, to)...)
+	options = append(options, addIndexes...)
+	options = append(options, addChecks...)
+	if tableOptions := diffTableOptions(from, to); len(tableOptions) > 0 {
+		options = append(options, tableOptions)
+	}
+
+	partitionOption, partitionSpecs := diffPartitioning(from.Partitioning(), to.Partitioning())
+	diff.alter = newAlter(options)
+	if partitionOption != nil {
+		if diff.alter == nil {
+			diff.alter = &sqlparser.AlterTable{Table: name, FullyParsed: true}
+		}
+		diff.alter.PartitionOption = partitionOption
+	}
+	diff.dropForeignKeys = newAlter(dropFKs)
+	diff.addForeignKeys = newAlter(addFKs)
+	for _, spec := range partitionSpecs {
+		diff.partitions = append(diff.partitions, &sqlparser.AlterTable{Table: name, PartitionSpec: spec, FullyParsed: true})
+	}
+	return diff
+}
+
+// diffColumns returns the options that drop, add, modify and reorder columns.
+// Columns that keep their relative order are not moved: only the columns
+// that are not part of the longest common subsequence of both column orders
+// get a FIRST or AFTER clause.
+func diffColumns(from, to *Table) []sqlparser.AlterOption {
+	var options []sqlparser.AlterOption
+	var kept []string
+	for _, col := range from.Columns() {
+		if to.Column(col.Name.String()) == nil {
+			options = append(options, &sqlparser.DropColumn{Name: &sqlparser.ColName{Name: col.Name}})
+			continue
+		}
+		kept = append(kept, col.Name.Lowered())
+	}
+	var target []string
+	for _, col := range to.Columns() {
+		if from.Column(col.Name.String()) != nil {
+			target = append(target, col.Name.Lowered())
+		}
+	}
+	stay := map[string]bool{}
+	for _, name := range longestCommonSubsequence(kept, target) {
+		stay[name] = true
+	}
+
+	for i, col := range to.Columns() {
+		first := i == 0
+		var after *sqlparser.ColName
+		if i > 0 {
+			after = &sqlparser.ColName{Name: to.Columns()[i-1].Name}
+		}
+		source := from.Column(col.Name.String())
+		switch {
+		case source == nil:
+			if i == len(to.Columns())-1 {
+				// A column added at the end needs no position.
+				first, after = false, nil
+			}
+			options = append(options, &sqlparser.AddColumns{
+				Columns: []*sqlparser.ColumnDefinition{sqlparser.CloneRefOfColumnDefinition(col)},
+				First:   first,
+				After:   after,
+			})
+		case !stay[col.Name.Lowered()]:
+			options = append(options, &sqlparser.ModifyColumn{
+				NewColDefinition: sqlparser.CloneRefOfColumnDefinition(col),
+				First:            first,
+				After:            after,
+			})
+		case !sqlparser.Equals.RefOfColumnDefinition(source, col):
+			options = append(options, &sqlparser.ModifyColumn{NewColDefinition: sqlparser.CloneRefOfColumnDefinition(col)})
+		}
+	}
+	return options
+}
+
+func longestCommonSubsequence(a, b []string) []string {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	var lcs []string
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			lcs = append(lcs, a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return lcs
+}
+
+// diffIndexes returns the options that drop and add indexes. An index whose
+// definition changed is dropped and added again, unless only its visibility
+// changed.
+func diffIndexes(from, to *Table) (drops, adds []sqlparser.AlterOption) {
+	for _, index := range from.Indexes() {
+		target := to.Index(index.Info.Name.String())
+		switch {
+		case target == nil:
+		case sqlparser.Equals.RefOfIndexDefinition(index, target):
+			continue
+		case sameIndexIgnoringVisibility(index, target):
+			adds = append(adds, &sqlparser.AlterIndex{Name: target.Info.Name, Invisible: isInvisible(target)})
+			continue
+		}
+		drops = append(drops, dropIndex(index))
+	}
+	for _, index := range to.Indexes() {
+		source := from.Index(index.Info.Name.String())
+		if source != nil && (sqlparser.Equals.RefOfIndexDefinition(source, index) || sameIndexIgnoringVisibility(source, index)) {
+			continue
+		}
+		adds = append(adds, &sqlparser.AddIndexDefinition{IndexDefinition: sqlparser.CloneRefOfIndexDefinition(index)})
+	}
+	return drops, adds
+}
+
+func dropIndex(index *sqlparser.IndexDefinition) *sqlparser.DropKey {
+	if index.Info.Type == sqlparser.IndexTypePrimary {
+		return &sqlparser.DropKey{Type: sqlparser.PrimaryKeyType}
+	}
+	return &sqlparser.DropKey{Type: sqlparser.NormalKeyType, Name: index.Info.Name}
+}
+
+func sameIndexIgnoringVisibility(a, b *sqlparser.IndexDefinition) bool {
+	a = sqlparser.CloneRefOfIndexDefinition(a)
+	b = sqlparser.CloneRefOfIndexDefinition(b)
+	setVisibility(a, false)
+	setVisibility(b, false)
+	return sqlparser.Equals.RefOfIndexDefinition(a, b)
+}
+
+func isInvisible(index *sqlparser.IndexDefinition) bool {
+	for _, option := range index.Options {
+		if strings.EqualFold(option.Name, "invisible") {
+			return true
+		}
+	}
+	return false
+}
+
+// diffForeignKeys returns the options that drop removed and changed foreign
+// keys, and those that add new and changed ones.
+func diffForeignKeys(from, to *Table) (drops, adds []sqlparser.AlterOption) {
+	for _, fk := range from.ForeignKeys() {
+		target := to.Constraint(fk.Name.String())
+		if target != nil && sqlparser.Equals.RefOfConstraintDefinition(fk, target) {
+			continue
+		}
+		drops = append(drops, &sqlparser.DropKey{Type: sqlparser.ForeignKeyType, Name: fk.Name})
+	}
+	for _, fk := range to.ForeignKeys() {
+		source := from.Constraint(fk.Name.String())
+		if source != nil && sqlparser.Equals.RefOfConstraintDefinition(source, fk) {
+			continue
+		}
+		adds = append(adds, &sqlparser.AddConstraintDefinition{ConstraintDefinition: sqlparser.CloneRefOfConstraintDefinition(fk)})
+	}
+	return drops, adds
+}
+
+// diffChecks returns the options that drop and add check constraints. A
+// check whose expression is unchanged but whose enforcement changed is
+// altered in place.
+func diffChecks(from, to *Table) (drops, adds []sqlparser.AlterOption) {
+	for _, check := range from.Checks() {
+		target := to.Constraint(check.Name.String())
+		if target != nil {
+			if targetCheck, ok := target.Details.(*sqlparser.CheckConstraintDefinition); ok {
+				sourceCheck := check.Details.(*sqlparser.CheckConstraintDefinition)
+				if sqlparser.Equals.Expr(sourceCheck.Expr, targetCheck.Expr) {
+					if sourceCheck.Enforced != targetCheck.Enforced {
+						adds = append(adds, &sqlparser.AlterCheck{Name: target.Name, Enforced: targetCheck.Enforced})
+					}
+					continue
+				}
+			}
+		}
+		drops = append(drops, &sqlparser.DropKey{Type: sqlparser.CheckKeyType, Name: check.Name})
+	}
+	for _, check := range to.Checks() {
+		if source := from.Constraint(check.Name.String()); source != nil {
+			if sourceCheck, ok := source.Details.(*sqlparser.CheckConstraintDefinition); ok &&
+				sqlparser.Equals.Expr(sourceCheck.Expr, check.Details.(*sqlparser.CheckConstraintDefinition).Expr) {
+				continue
+			}
+		}
+		adds = append(adds, &sqlparser.AddConstraintDefinition{ConstraintDefinition: sqlparser.CloneRefOfConstraintDefinition(check)})
+	}
+	return drops, adds
+}
+
+// diffTableOptions returns the table options that are new or changed. The
+// AUTO_INCREMENT counter is not part of the definition and is ignored. A
+// removed comment is cleared; other removed options cannot be reset by name
+// and are left alone.
+func diffTableOptions(from, to *Table) sqlparser.TableOptions {
+	var options sqlparser.TableOptions
+	for _, option := range to.Options() {
+		if strings.EqualFold(option.Name, "auto_increment") {
+			continue
+		}
+		source := tableOption(from.Options(), option.Name)
+		if source == nil || !sqlparser.Equals.RefOfTableOption(source, option) {
+			options = append(options, sqlparser.CloneRefOfTableOption(option))
+		}
+	}
+	if tableOption(from.Options(), "comment") != nil && tableOption(to.Options(), "comment") == nil {
+		options = append(options, &sqlparser.TableOption{Name: "comment", Value: sqlparser.NewStrLiteral("")})
+	}
+	return options
+}
+
+func tableOption(options sqlparser.TableOptions, name string) *sqlparser.TableOption {
+	for _, option := range options {
+		if strings.EqualFold(option.Name, name) {
+			return option
+		}
+	}
+	return nil
+}
+
+// diffPartitioning compares the partitioning of two tables. Partitions of a
+// RANGE or LIST partitioned table that are only removed or added at the end
+// are handled with DROP PARTITION and ADD PARTITION; any other change
+// repartitions the table.
+func diffPartitioning(from, to *sqlparser.PartitionOption) (*sqlparser.PartitionOption, []*sqlparser.PartitionSpec) {
+	switch {
+	case from == nil && to == nil:
+		return nil, nil
+	case to == nil:
+		return nil, []*sqlparser.PartitionSpec{{Action: sqlparser.RemoveAction}}
+	case from == nil:
+		return sqlparser.CloneRefOfPartitionOption(to), nil
+	case sqlparser.Equals.RefOfPartitionOption(from, to):
+		return nil, nil
+	}
+
+	if from.Type != sqlparser.RangeType && from.Type != sqlparser.ListType {
+		return sqlparser.CloneRefOfPartitionOption(to), nil
+	}
+	fromScheme := sqlparser.CloneRefOfPartitionOption(from)
+	toScheme := sqlparser.CloneRefOfPartitionOption(to)
+	fromScheme.Definitions, toScheme.Definitions = nil, nil
+	if !sqlparser.Equals.RefOfPartitionOption(fromScheme, toScheme) {
+		return sqlparser.CloneRefOfPartitionOption(to), nil
+	}
+
+	var dropped sqlparser.Partitions
+	var kept []*sqlparser.PartitionDefinition
+	for _, def := range from.Definitions {
+		if target := partitionDefinition(to, def.Name.String()); target != nil {
+			if !sqlparser.Equals.RefOfPartitionDefinition(def, target) {
+				return sqlparser.CloneRefOfPartitionOption(to), nil
+			}
+			kept = append(kept, def)
+			continue
+		}
+		dropped = append(dropped, def.Name)
+	}
+	for i, def := range kept {
+		if !to.Definitions[i].Name.Equal(def.Name) {
+			return sqlparser.CloneRefOfPartitionOption(to), nil
+		}
+	}
+
+	var specs []*sqlparser.PartitionSpec
+	if len(dropped) > 0 {
+		specs = append(specs, &sqlparser.PartitionSpec{Action: sqlparser.DropAction, Names: dropped})
+	}
+	if added := to.Definitions[len(kept):]; len(added) > 0 {
+		spec := &sqlparser.PartitionSpec{Action: sqlparser.AddAction}
+		for _, def := range added {
+			spec.Definitions = append(spec.Definitions, sqlparser.CloneRefOfPartitionDefinition(def))
+		}
+		specs = append(specs, spec)
+	}
+	return nil, specs
+}
+
+func partitionDefinition(option *sqlparser.PartitionOption, name string) *sqlparser.PartitionDefinition {
+	for _, def := range option.Definitions {
+		if def.Name.EqualString(name) {
+			return def
+		}
+	}
+	return nil
+}
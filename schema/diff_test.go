@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestDiff(t *testing.T) {
+	testcases := []struct {
+		name string
+		from string
+		to   string
+		diff []string
+	}{{
+		name: "equal",
+		from: "create table t (id int primary key, a int)",
+		to:   "create table t (id int, a int, primary key (id))",
+	}, {
+		name: "columns",
+		from: "create table t (id int, a int, b int, c int)",
+		to:   "create table t (id int, c int, b bigint, d int, e int)",
+		diff: []string{
+			"alter table t drop column a, modify column b bigint after c, add column d int after b, add column e int",
+		},
+	}, {
+		name: "move first",
+		from: "create table t (a int, b int, c int)",
+		to:   "create table t (c int, a int, b int)",
+		diff: []string{
+			"alter table t modify column c int first",
+		},
+	}, {
+		name: "indexes",
+		from: "create table t (id int primary key, a int, b int, key a (a), key b (b), key ab (a, b))",
+		to:   "create table t (id int, a int, b int, primary key (id, a), key a (a) invisible, key b (b, a), unique key c (b))",
+		diff: []string{
+			"alter table t drop primary key, drop key b, drop key ab, modify column a int not null, alter index a invisible, add primary key (id, a), add key b (b, a), add unique key c (b)",
+		},
+	}, {
+		name: "checks",
+		from: "create table t (a int, constraint c1 check (a > 0), constraint c2 check (a < 10), constraint c3 check (a != 5))",
+		to:   "create table t (a int, constraint c1 check (a > 0) not enforced, constraint c2 check (a < 20), constraint c4 check (a != 6))",
+		diff: []string{
+			"alter table t drop check c2, drop check c3, alter check c1 not enforced, add constraint c2 check (a < 20), add constraint c4 check (a != 6)",
+		},
+	}, {
+		name: "foreign keys before and after their indexes",
+		from: "create table p (id int primary key); create table t (id int, pid int, key pid (pid), constraint fk foreign key (pid) references p (id))",
+		to:   "create table p (id int primary key); create table t (id int, pid int, key pid2 (pid), constraint fk foreign key (pid) references p (id) on delete cascade)",
+		diff: []string{
+			"alter table t drop foreign key fk",
+			"alter table t drop key pid, add key pid2 (pid)",
+			"alter table t add constraint fk foreign key (pid) references p (id) on delete cascade",
+		},
+	}, {
+		name: "table options",
+		from: "create table t (a int) engine InnoDB comment 'x' auto_increment 10",
+		to:   "create table t (a int) engine MyISAM auto_increment 20 charset utf8mb4",
+		diff: []string{
+			"alter table t engine MyISAM charset utf8mb4 comment ''",
+		},
+	}, {
+		name: "range partitions",
+		from: "create table t (id int) partition by range (id) (partition p0 values less than (10), partition p1 values less than (20))",
+		to:   "create table t (id int) partition by range (id) (partition p1 values less than (20), partition p2 values less than (30))",
+		diff: []string{
+			"alter table t drop partition p0",
+			"alter table t add partition (partition p2 values less than (30))",
+		},
+	}, {
+		name: "repartition",
+		from: "create table t (id int) partition by hash (id) partitions 2",
+		to:   "create table t (id int, a int) partition by hash (id) partitions 4",
+		diff: []string{
+			"alter table t add column a int \npartition by hash (id) partitions 4",
+		},
+	}, {
+		name: "remove partitioning",
+		from: "create table t (id int) partition by hash (id) partitions 2",
+		to:   "create table t (id int)",
+		diff: []string{
+			"alter table t remove partitioning",
+		},
+	}, {
+		name: "tables in dependency order",
+		from: "create table a (id int primary key); create table b (id int, aid int, foreign key (aid) references a (id))",
+		to:   "create table z (id int primary key); create table y (id int, zid int, foreign key (zid) references z (id))",
+		diff: []string{
+			"drop table b",
+			"drop table a",
+			"create table z (\n\tid int not null,\n\tprimary key (id)\n)",
+			"create table y (\n\tid int,\n\tzid int,\n\tkey zid (zid),\n\tconstraint y_ibfk_1 foreign key (zid) references z (id)\n)",
+		},
+	}, {
+		name: "views",
+		from: "create table t (id int); create view v1 as select id from t; create view v2 as select id from t",
+		to:   "create table t (id int); create view v2 as select x.id from v3 as x; create view v3 as select id from t where id > 0",
+		diff: []string{
+			"drop view v1",
+			"create view v3 as select id from t where id > 0",
+			"create or replace view v2 as select x.id from v3 as x",
+		},
+	}, {
+		name: "databases",
+		from: "create database a; create table a.t (id int primary key); create database b; create table b.t (aid int, foreign key (aid) references a.t (id))",
+		to:   "create database b; create table b.t (aid int, key aid (aid)); create database c",
+		diff: []string{
+			"create database c",
+			"alter table b.t drop foreign key t_ibfk_1",
+			"drop database a",
+		},
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			from, err := NewFromSQL(parser, tcase.from)
+			require.NoError(t, err)
+			to, err := NewFromSQL(parser, tcase.to)
+			require.NoError(t, err)
+
+			stmts := Diff(from, to)
+			var diff []string
+			for _, stmt := range stmts {
+				diff = append(diff, sqlparser.String(stmt))
+			}
+			assert.Equal(t, tcase.diff, diff)
+
+			// Applying the diff must turn from into to.
+			for _, stmt := range stmts {
+				require.NoError(t, from.Apply(stmt), sqlparser.String(stmt))
+			}
+			assert.Empty(t, Diff(from, to))
+		})
+	}
+}
+
+func TestDiffIsDeterministic(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	from, err := NewFromSQL(parser, "create table a (x int); create table b (x int); create table c (x int)")
+	require.NoError(t, err)
+	to, err := NewFromSQL(parser, "create table a (y int); create table b (y int); create table d (y int); create table e (y int)")
+	require.NoError(t, err)
+
+	first := Diff(from, to)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, Diff(from, to))
+	}
+}
+
+func TestDiffTables(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, `
+create table t1 (id int, pid int, foreign key (pid) references p (id));
+create table t2 (id int primary key, pid int, foreign key (pid) references q (id));
+`)
+	require.NoError(t, err)
+
+	var diff []string
+	for _, alter := range DiffTables(s.Database("").Table("t1"), s.Database("").Table("t2")) {
+		diff = append(diff, sqlparser.String(alter))
+	}
+	assert.Equal(t, []string{
+		"alter table t1 drop foreign key t1_ibfk_1",
+		"alter table t1 modify column id int not null, add primary key (id)",
+		"alter table t1 add constraint t2_ibfk_1 foreign key (pid) references q (id)",
+	}, diff)
+	assert.Empty(t, DiffTables(s.Database("").Table("t1"), s.Database("").Table("t1")))
+}
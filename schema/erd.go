@@ -0,0 +1,466 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// Cardinality is the number of rows of a table a row of the other table of
+// a relationship is related to.
+type Cardinality int8
+
+const (
+	// ExactlyOne relates a row to one row of the other table.
+	ExactlyOne Cardinality = iota
+	// ZeroOrOne relates a row to at most one row of the other table.
+	ZeroOrOne
+	// ZeroOrMany relates a row to any number of rows of the other table.
+	ZeroOrMany
+)
+
+// String returns the cardinality in words, as in "zero or one".
+func (c Cardinality) String() string {
+	switch c {
+	case ExactlyOne:
+		return "exactly one"
+	case ZeroOrOne:
+		return "zero or one"
+	case ZeroOrMany:
+		return "zero or many"
+	}
+	return fmt.Sprintf("Cardinality(%d)", int8(c))
+}
+
+// Entity is a table of an entity-relationship diagram.
+type Entity struct {
+	Database string
+	Name     string
+	Columns  []*Attribute
+}
+
+// Attribute is a column of an entity.
+type Attribute struct {
+	Name string
+	// Type is the data type of the column, with its length and signedness.
+	Type       string
+	Nullable   bool
+	PrimaryKey bool
+	ForeignKey bool
+	// Unique is set for the columns of unique keys other than the primary
+	// key.
+	Unique  bool
+	Comment string
+}
+
+// Relationship is a foreign key between two entities.
+type Relationship struct {
+	// Name is the name of the foreign key.
+	Name   string
+	Child  *Entity
+	Parent *Entity
+	// Columns are the foreign key columns of Child, and ReferencedColumns
+	// the columns of Parent they reference.
+	Columns           []string
+	ReferencedColumns []string
+	// ChildCardinality is the number of child rows a parent row has: zero or
+	// one if the foreign key columns are unique, zero or many otherwise.
+	ChildCardinality Cardinality
+	// ParentCardinality is the number of parent rows a child row has: zero
+	// or one if a foreign key column is nullable, exactly one otherwise.
+	ParentCardinality Cardinality
+	// Identifying is set when the foreign key columns are part of the
+	// primary key of Child, which cannot exist without its parent.
+	Identifying bool
+}
+
+// DiagramOptions selects the tables of a diagram.
+type DiagramOptions struct {
+	// Databases are the databases whose tables are included. All databases
+	// are included if it is empty.
+	Databases []string
+	// Tables are path.Match patterns of the names of the included tables,
+	// such as "order_*". All tables are included if it is empty.
+	Tables []string
+}
+
+// Diagram is an entity-relationship diagram of the tables of a schema.
+type Diagram struct {
+	Entities      []*Entity
+	Relationships []*Relationship
+}
+
+// Diagram returns the entity-relationship diagram of the tables of the
+// schema selected by the options. Relationships are included when both of
+// their tables are.
+func (s *Schema) Diagram(opts DiagramOptions) (*Diagram, error) {
+	for _, pattern := range opts.Tables {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid table pattern %q: %w", pattern, err)
+		}
+	}
+	d := &Diagram{}
+	entities := map[tableKey]*Entity{}
+	var keys []tableKey
+	for _, db := range s.Databases() {
+		if len(opts.Databases) > 0 && !slices.Contains(opts.Databases, db.name) {
+			continue
+		}
+		for _, table := range db.Tables() {
+			if len(opts.Tables) > 0 && !slices.ContainsFunc(opts.Tables, func(pattern string) bool {
+				ok, _ := path.Match(pattern, table.Name())
+				return ok
+			}) {
+				continue
+			}
+			key := tableKey{db.name, table.Name()}
+			entities[key] = newEntity(key, table)
+			keys = append(keys, key)
+			d.Entities = append(d.Entities, entities[key])
+		}
+	}
+
+	for _, key := range keys {
+		table := s.databases[key.db].tables[key.name]
+		for _, constraint := range table.ForeignKeys() {
+			fk := constraint.Details.(*sqlparser.ForeignKeyDefinition)
+			parent, ok := entities[key.resolve(fk.ReferenceDefinition.ReferencedTable)]
+			if !ok {
+				continue
+			}
+			rel := &Relationship{
+				Name:              constraint.Name.String(),
+				Child:             entities[key],
+				Parent:            parent,
+				Columns:           identifiers(fk.Source),
+				ReferencedColumns: identifiers(fk.ReferenceDefinition.ReferencedColumns),
+				ChildCardinality:  ZeroOrMany,
+				ParentCardinality: ExactlyOne,
+			}
+			for _, index := range table.Indexes() {
+				cols := indexColumns(index)
+				if index.Info.IsUnique() && len(cols) > 0 && isSubset(cols, rel.Columns) {
+					rel.ChildCardinality = ZeroOrOne
+				}
+				if index.Info.Type == sqlparser.IndexTypePrimary && isSubset(rel.Columns, cols) {
+					rel.Identifying = true
+				}
+			}
+			for _, col := range rel.Columns {
+				if def := table.Column(col); def != nil && isNullableColumn(def) {
+					rel.ParentCardinality = ZeroOrOne
+				}
+			}
+			d.Relationships = append(d.Relationships, rel)
+		}
+	}
+	return d, nil
+}
+
+func newEntity(key tableKey, table *Table) *Entity {
+	entity := &Entity{Database: key.db, Name: key.name}
+	for _, col := range table.Columns() {
+		attr := &Attribute{
+			Name:     col.Name.String(),
+			Type:     attributeType(col.Type),
+			Nullable: isNullableColumn(col),
+		}
+		if opts := col.Type.Options; opts != nil && opts.Comment != nil {
+			attr.Comment = opts.Comment.Val
+		}
+		for _, index := range table.Indexes() {
+			if !containsName(indexColumns(index), attr.Name) {
+				continue
+			}
+			switch index.Info.Type {
+			case sqlparser.IndexTypePrimary:
+				attr.PrimaryKey = true
+			case sqlparser.IndexTypeUnique:
+				attr.Unique = true
+			}
+		}
+		for _, constraint := range table.ForeignKeys() {
+			fk := constraint.Details.(*sqlparser.ForeignKeyDefinition)
+			if containsName(identifiers(fk.Source), attr.Name) {
+				attr.ForeignKey = true
+			}
+		}
+		entity.Columns = append(entity.Columns, attr)
+	}
+	return entity
+}
+
+// String returns the qualified name of the table.
+func (e *Entity) String() string {
+	return sqlparser.String(tableKey{e.Database, e.Name}.tableName())
+}
+
+// id returns an identifier for the entity, made of letters, digits and
+// underscores.
+func (e *Entity) id() string {
+	name := e.Name
+	if e.Database != "" {
+		name = e.Database + "_" + e.Name
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// keys returns the PK, FK and UK markers of an attribute.
+func (a *Attribute) keys() []string {
+	var keys []string
+	if a.PrimaryKey {
+		keys = append(keys, "PK")
+	}
+	if a.ForeignKey {
+		keys = append(keys, "FK")
+	}
+	if a.Unique {
+		keys = append(keys, "UK")
+	}
+	return keys
+}
+
+// Mermaid returns the diagram as a Mermaid erDiagram.
+func (d *Diagram) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, entity := range d.Entities {
+		fmt.Fprintf(&b, "    %s", entity.id())
+		if entity.id() != entity.String() {
+			fmt.Fprintf(&b, "[%q]", entity.String())
+		}
+		b.WriteString(" {\n")
+		for _, attr := range entity.Columns {
+			// Mermaid types cannot contain commas or spaces.
+			typ := strings.NewReplacer(",", "-", " ", "_").Replace(attr.Type)
+			fmt.Fprintf(&b, "        %s %s", typ, attr.Name)
+			if keys := attr.keys(); len(keys) > 0 {
+				fmt.Fprintf(&b, " %s", strings.Join(keys, ", "))
+			}
+			if attr.Comment != "" {
+				fmt.Fprintf(&b, " %q", attr.Comment)
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString("    }\n")
+	}
+	for _, rel := range d.Relationships {
+		line := ".."
+		if rel.Identifying {
+			line = "--"
+		}
+		fmt.Fprintf(&b, "    %s %s%s%s %s : %q\n", rel.Parent.id(), crowsFoot(rel.ParentCardinality, true), line,
+			crowsFoot(rel.ChildCardinality, false), rel.Child.id(), rel.Name)
+	}
+	return b.String()
+}
+
+// PlantUML returns the diagram as a PlantUML entity diagram in
+// Information Engineering notation.
+func (d *Diagram) PlantUML() string {
+	var b strings.Builder
+	b.WriteString("@startuml\nhide circle\nskinparam linetype ortho\n\n")
+	for _, entity := range d.Entities {
+		fmt.Fprintf(&b, "entity %q as %s {\n", entity.String(), entity.id())
+		// The primary key columns come first, above a separator.
+		var pk, others []*Attribute
+		for _, attr := range entity.Columns {
+			if attr.PrimaryKey {
+				pk = append(pk, attr)
+			} else {
+				others = append(others, attr)
+			}
+		}
+		for _, attr := range pk {
+			plantUMLAttribute(&b, attr)
+		}
+		if len(pk) > 0 && len(others) > 0 {
+			b.WriteString("  --\n")
+		}
+		for _, attr := range others {
+			plantUMLAttribute(&b, attr)
+		}
+		b.WriteString("}\n\n")
+	}
+	for _, rel := range d.Relationships {
+		line := ".."
+		if rel.Identifying {
+			line = "--"
+		}
+		fmt.Fprintf(&b, "%s %s%s%s %s : %s\n", rel.Parent.id(), crowsFoot(rel.ParentCardinality, true), line,
+			crowsFoot(rel.ChildCardinality, false), rel.Child.id(), rel.Name)
+	}
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+func plantUMLAttribute(b *strings.Builder, attr *Attribute) {
+	// Mandatory attributes are marked with a star.
+	b.WriteString("  ")
+	if !attr.Nullable {
+		b.WriteString("* ")
+	}
+	fmt.Fprintf(b, "%s : %s", attr.Name, attr.Type)
+	for _, key := range attr.keys() {
+		fmt.Fprintf(b, " <<%s>>", key)
+	}
+	if attr.Comment != "" {
+		fmt.Fprintf(b, " -- %s", attr.Comment)
+	}
+	b.WriteByte('\n')
+}
+
+// crowsFoot returns the crow's foot notation of a cardinality, as Mermaid
+// and PlantUML write it on the left or right of a relationship line.
+func crowsFoot(c Cardinality, left bool) string {
+	switch {
+	case c == ZeroOrOne && left:
+		return "|o"
+	case c == ZeroOrOne:
+		return "o|"
+	case c == ZeroOrMany && left:
+		return "}o"
+	case c == ZeroOrMany:
+		return "o{"
+	default:
+		return "||"
+	}
+}
+
+// DOT returns the diagram as a Graphviz digraph, with a record-like HTML
+// table per entity and an edge from each foreign key column to the column
+// it references.
+func (d *Diagram) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph schema {\n  rankdir=LR;\n  node [shape=plaintext];\n\n")
+	for _, entity := range d.Entities {
+		fmt.Fprintf(&b, "  %q [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">\n", entity.String())
+		fmt.Fprintf(&b, "    <tr><td bgcolor=\"lightgrey\"><b>%s</b></td></tr>\n", html.EscapeString(entity.String()))
+		for _, attr := range entity.Columns {
+			text := html.EscapeString(attr.Name + " " + attr.Type)
+			if attr.PrimaryKey {
+				text = "<u>" + text + "</u>"
+			}
+			if keys := attr.keys(); len(keys) > 0 {
+				text += " " + strings.Join(keys, ", ")
+			}
+			if !attr.Nullable {
+				text += " NOT NULL"
+			}
+			fmt.Fprintf(&b, "    <tr><td port=%q align=\"left\">%s</td></tr>\n", attr.Name, text)
+		}
+		b.WriteString("  </table>>];\n")
+	}
+	if len(d.Relationships) > 0 {
+		b.WriteByte('\n')
+	}
+	for _, rel := range d.Relationships {
+		style := "dashed"
+		if rel.Identifying {
+			style = "solid"
+		}
+		fmt.Fprintf(&b, "  %q:%q -> %q:%q [label=%q, dir=both, arrowtail=%s, arrowhead=%s, style=%s];\n",
+			rel.Child.String(), rel.Columns[0], rel.Parent.String(), rel.ReferencedColumns[0], rel.Name,
+			dotArrow(rel.ChildCardinality), dotArrow(rel.ParentCardinality), style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotArrow returns the Graphviz arrow shape of a cardinality.
+func dotArrow(c Cardinality) string {
+	switch c {
+	case ZeroOrOne:
+		return "teeodot"
+	case ZeroOrMany:
+		return "crowodot"
+	default:
+		return "teetee"
+	}
+}
+
+// attributeType returns a column type with its length and signedness,
+// without its options.
+func attributeType(ct *sqlparser.ColumnType) string {
+	name := strings.ToLower(ct.Type)
+	switch {
+	case ct.Length != nil && ct.Scale != nil:
+		name += fmt.Sprintf("(%d,%d)", *ct.Length, *ct.Scale)
+	case ct.Length != nil:
+		name += fmt.Sprintf("(%d)", *ct.Length)
+	}
+	if ct.Unsigned {
+		name += " unsigned"
+	}
+	return name
+}
+
+// isNullableColumn returns whether a column of a normalized table can hold
+// NULL.
+func isNullableColumn(col *sqlparser.ColumnDefinition) bool {
+	null := columnOptions(col.Type).Null
+	return null == nil || *null
+}
+
+// indexColumns returns the names of the columns of an index, skipping
+// expressions.
+func indexColumns(index *sqlparser.IndexDefinition) []string {
+	var cols []string
+	for _, col := range index.Columns {
+		if col.Column.NotEmpty() {
+			cols = append(cols, col.Column.String())
+		}
+	}
+	return cols
+}
+
+func identifiers(cols sqlparser.Columns) []string {
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.String()
+	}
+	return names
+}
+
+// isSubset returns whether all the column names of a are in b.
+func isSubset(a, b []string) bool {
+	for _, name := range a {
+		if !containsName(b, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsName returns whether a column name is in names. Column names are
+// case-insensitive.
+func containsName(names []string, name string) bool {
+	return slices.ContainsFunc(names, func(n string) bool {
+		return strings.EqualFold(n, name)
+	})
+}
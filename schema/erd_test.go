@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func diagramSchema(t *testing.T) *Schema {
+	t.Helper()
+	s, err := NewFromSQL(sqlparser.NewTestParser(), `
+		create database shop;
+		use shop;
+		create table customer (id int primary key, email varchar(100) not null unique comment 'login', name varchar(50));
+		create table orders (id bigint primary key, customer_id int not null, total decimal(10,2) unsigned,
+			foreign key (customer_id) references customer (id));
+		create table invoice (order_id bigint primary key, foreign key (order_id) references orders (id));
+		create table order_item (order_id bigint, line int, gift_for int, primary key (order_id, line),
+			foreign key (order_id) references orders (id), foreign key (gift_for) references customer (id));
+		create database crm;
+		create table crm.note (id int primary key, customer_id int, foreign key (customer_id) references shop.customer (id));
+	`)
+	require.NoError(t, err)
+	return s
+}
+
+func TestDiagram(t *testing.T) {
+	testcases := []struct {
+		opts          DiagramOptions
+		entities      []string
+		relationships []string
+	}{{
+		entities: []string{"crm.note", "shop.customer", "shop.invoice", "shop.order_item", "shop.orders"},
+		relationships: []string{
+			"crm.note note_ibfk_1 shop.customer: zero or one to zero or many",
+			"shop.invoice invoice_ibfk_1 shop.orders: exactly one to zero or one, identifying",
+			"shop.order_item order_item_ibfk_1 shop.orders: exactly one to zero or many, identifying",
+			"shop.order_item order_item_ibfk_2 shop.customer: zero or one to zero or many",
+			"shop.orders orders_ibfk_1 shop.customer: exactly one to zero or many",
+		},
+	}, {
+		opts:     DiagramOptions{Databases: []string{"shop"}, Tables: []string{"order*", "customer"}},
+		entities: []string{"shop.customer", "shop.order_item", "shop.orders"},
+		relationships: []string{
+			"shop.order_item order_item_ibfk_1 shop.orders: exactly one to zero or many, identifying",
+			"shop.order_item order_item_ibfk_2 shop.customer: zero or one to zero or many",
+			"shop.orders orders_ibfk_1 shop.customer: exactly one to zero or many",
+		},
+	}, {
+		opts:     DiagramOptions{Databases: []string{"crm"}},
+		entities: []string{"crm.note"},
+	}}
+
+	s := diagramSchema(t)
+	for _, tcase := range testcases {
+		t.Run("", func(t *testing.T) {
+			d, err := s.Diagram(tcase.opts)
+			require.NoError(t, err)
+			var entities []string
+			for _, entity := range d.Entities {
+				entities = append(entities, entity.String())
+			}
+			assert.Equal(t, tcase.entities, entities)
+			var relationships []string
+			for _, rel := range d.Relationships {
+				desc := rel.Child.String() + " " + rel.Name + " " + rel.Parent.String() + ": " +
+					rel.ParentCardinality.String() + " to " + rel.ChildCardinality.String()
+				if rel.Identifying {
+					desc += ", identifying"
+				}
+				relationships = append(relationships, desc)
+			}
+			assert.Equal(t, tcase.relationships, relationships)
+		})
+	}
+
+	_, err := s.Diagram(DiagramOptions{Tables: []string{"["}})
+	assert.EqualError(t, err, `invalid table pattern "[": syntax error in pattern`)
+}
+
+func TestDiagramColumnCase(t *testing.T) {
+	s, err := NewFromSQL(sqlparser.NewTestParser(), `
+		create table parent (PId int, primary key (pid));
+		create table child (PId int not null, primary key (pid), foreign key (PID) references parent (pid))`)
+	require.NoError(t, err)
+	d, err := s.Diagram(DiagramOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, d.Entities, 2)
+	col := d.Entities[0].Columns[0]
+	assert.Equal(t, "PId", col.Name)
+	assert.True(t, col.PrimaryKey)
+	assert.True(t, col.ForeignKey)
+
+	require.Len(t, d.Relationships, 1)
+	rel := d.Relationships[0]
+	assert.Equal(t, ExactlyOne, rel.ParentCardinality)
+	assert.Equal(t, ZeroOrOne, rel.ChildCardinality)
+	assert.True(t, rel.Identifying)
+}
+
+func TestDiagramFormats(t *testing.T) {
+	d, err := diagramSchema(t).Diagram(DiagramOptions{Tables: []string{"customer", "orders", "invoice"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, `erDiagram
+    shop_customer["shop.customer"] {
+        int id PK
+        varchar(100) email UK "login"
+        varchar(50) name
+    }
+    shop_invoice["shop.invoice"] {
+        bigint order_id PK, FK
+    }
+    shop_orders["shop.orders"] {
+        bigint id PK
+        int customer_id FK
+        decimal(10-2)_unsigned total
+    }
+    shop_orders ||--o| shop_invoice : "invoice_ibfk_1"
+    shop_customer ||..o{ shop_orders : "orders_ibfk_1"
+`, d.Mermaid())
+
+	assert.Equal(t, `@startuml
+hide circle
+skinparam linetype ortho
+
+entity "shop.customer" as shop_customer {
+  * id : int <<PK>>
+  --
+  * email : varchar(100) <<UK>> -- login
+  name : varchar(50)
+}
+
+entity "shop.invoice" as shop_invoice {
+  * order_id : bigint <<PK>> <<FK>>
+}
+
+entity "shop.orders" as shop_orders {
+  * id : bigint <<PK>>
+  --
+  * customer_id : int <<FK>>
+  total : decimal(10,2) unsigned
+}
+
+shop_orders ||--o| shop_invoice : invoice_ibfk_1
+shop_customer ||..o{ shop_orders : orders_ibfk_1
+@enduml
+`, d.PlantUML())
+
+	assert.Equal(t, `digraph schema {
+  rankdir=LR;
+  node [shape=plaintext];
+
+  "shop.customer" [label=<<table border="0" cellborder="1" cellspacing="0">
+    <tr><td bgcolor="lightgrey"><b>shop.customer</b></td></tr>
+    <tr><td port="id" align="left"><u>id int</u> PK NOT NULL</td></tr>
+    <tr><td port="email" align="left">email varchar(100) UK NOT NULL</td></tr>
+    <tr><td port="name" align="left">name varchar(50)</td></tr>
+  </table>>];
+  "shop.invoice" [label=<<table border="0" cellborder="1" cellspacing="0">
+    <tr><td bgcolor="lightgrey"><b>shop.invoice</b></td></tr>
+    <tr><td port="order_id" align="left"><u>order_id bigint</u> PK, FK NOT NULL</td></tr>
+  </table>>];
+  "shop.orders" [label=<<table border="0" cellborder="1" cellspacing="0">
+    <tr><td bgcolor="lightgrey"><b>shop.orders</b></td></tr>
+    <tr><td port="id" align="left"><u>id bigint</u> PK NOT NULL</td></tr>
+    <tr><td port="customer_id" align="left">customer_id int FK NOT NULL</td></tr>
+    <tr><td port="total" align="left">total decimal(10,2) unsigned</td></tr>
+  </table>>];
+
+  "shop.invoice":"order_id" -> "shop.orders":"id" [label="invoice_ibfk_1", dir=both, arrowtail=teeodot, arrowhead=teetee, style=solid];
+  "shop.orders":"customer_id" -> "shop.customer":"id" [label="orders_ibfk_1", dir=both, arrowtail=crowodot, arrowhead=teetee, style=dashed];
+}
+`, d.DOT())
+}
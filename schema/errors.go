@@ -0,0 +1,318 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// NoDatabaseSelectedError is returned when an unqualified name is used
+// while there is no current database.
+type NoDatabaseSelectedError struct{}
+
+func (e *NoDatabaseSelectedError) Error() string {
+	return "No database selected"
+}
+
+// UnknownDatabaseError is returned when a statement refers to a database
+// that does not exist.
+type UnknownDatabaseError struct {
+	Database string
+}
+
+func (e *UnknownDatabaseError) Error() string {
+	return fmt.Sprintf("Unknown database '%s'", e.Database)
+}
+
+// DatabaseExistsError is returned when creating a database that exists.
+type DatabaseExistsError struct {
+	Database string
+}
+
+func (e *DatabaseExistsError) Error() string {
+	return fmt.Sprintf("Can't create database '%s'; database exists", e.Database)
+}
+
+// TableExistsError is returned when creating a table or view whose name is
+// already taken.
+type TableExistsError struct {
+	Database string
+	Table    string
+}
+
+func (e *TableExistsError) Error() string {
+	return fmt.Sprintf("Table '%s.%s' already exists", e.Database, e.Table)
+}
+
+// UnknownTableError is returned when a statement refers to a table that does
+// not exist.
+type UnknownTableError struct {
+	Database string
+	Table    string
+}
+
+func (e *UnknownTableError) Error() string {
+	return fmt.Sprintf("Table '%s.%s' doesn't exist", e.Database, e.Table)
+}
+
+// UnknownViewError is returned when a statement refers to a view that does
+// not exist.
+type UnknownViewError struct {
+	Database string
+	View     string
+}
+
+func (e *UnknownViewError) Error() string {
+	return fmt.Sprintf("Unknown view '%s.%s'", e.Database, e.View)
+}
+
+// DuplicateColumnError is returned when a column name is used twice.
+type DuplicateColumnError struct {
+	Table  string
+	Column string
+}
+
+func (e *DuplicateColumnError) Error() string {
+	return fmt.Sprintf("Duplicate column name '%s' in table '%s'", e.Column, e.Table)
+}
+
+// UnknownColumnError is returned when a statement refers to a column that
+// does not exist.
+type UnknownColumnError struct {
+	Table  string
+	Column string
+}
+
+func (e *UnknownColumnError) Error() string {
+	return fmt.Sprintf("Unknown column '%s' in table '%s'", e.Column, e.Table)
+}
+
+// AmbiguousColumnError is returned when an unqualified column of a
+// statement exists in more than one of its tables.
+type AmbiguousColumnError struct {
+	Column string
+}
+
+func (e *AmbiguousColumnError) Error() string {
+	return fmt.Sprintf("Column '%s' in field list is ambiguous", e.Column)
+}
+
+// DuplicateKeyError is returned when an index or constraint name is used
+// twice.
+type DuplicateKeyError struct {
+	Table string
+	Key   string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("Duplicate key name '%s' in table '%s'", e.Key, e.Table)
+}
+
+// UnknownKeyError is returned when a statement refers to an index or
+// constraint that does not exist.
+type UnknownKeyError struct {
+	Table string
+	Key   string
+}
+
+func (e *UnknownKeyError) Error() string {
+	return fmt.Sprintf("Can't DROP '%s'; check that column/key exists in table '%s'", e.Key, e.Table)
+}
+
+// MultiplePrimaryKeysError is returned when a table would end up with more
+// than one primary key.
+type MultiplePrimaryKeysError struct {
+	Table string
+}
+
+func (e *MultiplePrimaryKeysError) Error() string {
+	return fmt.Sprintf("Multiple primary key defined in table '%s'", e.Table)
+}
+
+// DependentColumnError is returned when dropping a column that is still
+// used by a foreign key, a check constraint or a generated column.
+type DependentColumnError struct {
+	Table     string
+	Column    string
+	Dependent string
+}
+
+func (e *DependentColumnError) Error() string {
+	return fmt.Sprintf("Column '%s' in table '%s' is used by '%s'", e.Column, e.Table, e.Dependent)
+}
+
+// DropAllColumnsError is returned when dropping the last column of a table.
+type DropAllColumnsError struct {
+	Table string
+}
+
+func (e *DropAllColumnsError) Error() string {
+	return fmt.Sprintf("You can't delete all columns of table '%s' with ALTER TABLE; use DROP TABLE instead", e.Table)
+}
+
+// IndexNeededByForeignKeyError is returned when dropping the only index that
+// supports a foreign key.
+type IndexNeededByForeignKeyError struct {
+	Table      string
+	Index      string
+	ForeignKey string
+}
+
+func (e *IndexNeededByForeignKeyError) Error() string {
+	return fmt.Sprintf("Cannot drop index '%s' in table '%s': needed in foreign key constraint '%s'", e.Index, e.Table, e.ForeignKey)
+}
+
+// NotPartitionedError is returned when applying a partition operation to a
+// table that is not partitioned.
+type NotPartitionedError struct {
+	Table string
+}
+
+func (e *NotPartitionedError) Error() string {
+	return fmt.Sprintf("Partition management on a not partitioned table '%s' is not possible", e.Table)
+}
+
+// UnknownPartitionError is returned when a statement refers to a partition
+// that does not exist.
+type UnknownPartitionError struct {
+	Table     string
+	Partition string
+}
+
+func (e *UnknownPartitionError) Error() string {
+	return fmt.Sprintf("Unknown partition '%s' in table '%s'", e.Partition, e.Table)
+}
+
+// DuplicatePartitionError is returned when a partition name is used twice.
+type DuplicatePartitionError struct {
+	Table     string
+	Partition string
+}
+
+func (e *DuplicatePartitionError) Error() string {
+	return fmt.Sprintf("Duplicate partition name '%s' in table '%s'", e.Partition, e.Table)
+}
+
+// UnsupportedStatementError is returned for DDL statements that the schema
+// model cannot apply.
+type UnsupportedStatementError struct {
+	Statement string
+}
+
+func (e *UnsupportedStatementError) Error() string {
+	return fmt.Sprintf("unsupported statement: %s", e.Statement)
+}
+
+// UnsupportedAlterOptionError is returned for ALTER TABLE options that the
+// schema model cannot apply.
+type UnsupportedAlterOptionError struct {
+	Table  string
+	Option string
+}
+
+func (e *UnsupportedAlterOptionError) Error() string {
+	return fmt.Sprintf("unsupported alter option in table '%s': %s", e.Table, e.Option)
+}
+
+// DependencyCycleError is returned when objects cannot be ordered because
+// they depend on each other.
+type DependencyCycleError struct {
+	Cycles []Cycle
+}
+
+func (e *DependencyCycleError) Error() string {
+	cycles := make([]string, 0, len(e.Cycles))
+	for _, cycle := range e.Cycles {
+		cycles = append(cycles, cycle.String())
+	}
+	return fmt.Sprintf("dependency cycle: %s", strings.Join(cycles, "; "))
+}
+
+// MissingFieldError is returned when an information_schema result lacks a
+// field needed to load the tables it describes.
+type MissingFieldError struct {
+	Table string
+	Field string
+}
+
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf("information_schema.%s result has no %s field", e.Table, e.Field)
+}
+
+// MissingResultError is returned when an information_schema table needed to
+// load the tables is not given at all.
+type MissingResultError struct {
+	Table string
+}
+
+func (e *MissingResultError) Error() string {
+	return fmt.Sprintf("information_schema.%s result is missing", e.Table)
+}
+
+// InvalidFieldError is returned when a field of an information_schema result
+// has a value that cannot be interpreted.
+type InvalidFieldError struct {
+	Table string
+	Field string
+	Value string
+}
+
+func (e *InvalidFieldError) Error() string {
+	return fmt.Sprintf("information_schema.%s result has invalid %s value '%s'", e.Table, e.Field, e.Value)
+}
+
+// MissingParentRowsError is returned when fixtures cannot fill a NOT NULL
+// foreign key because the referenced table has no rows.
+type MissingParentRowsError struct {
+	Table      string
+	ForeignKey string
+	Parent     string
+}
+
+func (e *MissingParentRowsError) Error() string {
+	return fmt.Sprintf("cannot generate rows of table '%s': foreign key '%s' references table '%s', which has no rows", e.Table, e.ForeignKey, e.Parent)
+}
+
+// UniqueValuesError is returned when fixtures cannot generate enough
+// distinct values for the unique keys of a table.
+type UniqueValuesError struct {
+	Table string
+	Rows  int
+}
+
+func (e *UniqueValuesError) Error() string {
+	return fmt.Sprintf("cannot generate %d rows of table '%s' with distinct unique keys", e.Rows, e.Table)
+}
+
+// ColumnRangeError is returned when fixtures number more rows than the
+// integer type of an AUTO_INCREMENT or primary key column can hold.
+type ColumnRangeError struct {
+	Table  string
+	Column string
+	Rows   int
+}
+
+func (e *ColumnRangeError) Error() string {
+	return fmt.Sprintf("cannot generate %d rows of table '%s': column '%s' is out of range", e.Rows, e.Table, e.Column)
+}
+
+func unsupportedStatement(stmt sqlparser.Statement) error {
+	return &UnsupportedStatementError{Statement: sqlparser.CanonicalString(stmt)}
+}
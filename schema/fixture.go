@@ -0,0 +1,538 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+)
+
+// FixtureOptions configures the rows generated by Fixtures.
+type FixtureOptions struct {
+	// Seed seeds the random values: the same seed and schema generate the
+	// same rows.
+	Seed uint64
+	// Rows is the number of rows generated per table. It defaults to 10.
+	Rows int
+	// TableRows overrides Rows for the tables with the given names.
+	TableRows map[string]int
+	// BatchSize is the maximum number of rows of an INSERT statement. It
+	// defaults to 100.
+	BatchSize int
+	// NullRate is the probability of nullable columns being NULL. It
+	// defaults to 0.1; a negative rate never generates NULL.
+	NullRate float64
+}
+
+// maxAttempts is the number of times a row is generated again when it
+// duplicates a unique key.
+const maxAttempts = 100
+
+// Fixtures returns INSERT statements filling the tables of the schema with
+// random rows. Tables come after the tables their foreign keys reference,
+// and foreign key columns take the values of a generated row of the
+// referenced table.
+//
+// The values fit the type of their column: integers are within the range
+// of their type and signedness, strings and binary strings fit their
+// length, and ENUM and SET columns hold their members. NOT NULL columns are
+// never NULL, and the values of unique keys are distinct. Integer primary
+// keys of a single column and AUTO_INCREMENT columns are numbered from 1.
+// Generated columns are left out, and CHECK constraints are not enforced.
+func (s *Schema) Fixtures(opts FixtureOptions) ([]*sqlparser.Insert, error) {
+	if opts.Rows == 0 {
+		opts.Rows = 10
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.NullRate == 0 {
+		opts.NullRate = 0.1
+	}
+	g := &fixtureGenerator{
+		schema: s,
+		opts:   opts,
+		rand:   rand.New(rand.NewPCG(opts.Seed, opts.Seed)),
+		rows:   map[tableKey][]fixtureRow{},
+	}
+
+	tables := map[tableKey]*Table{}
+	var keys []tableKey
+	for _, db := range s.Databases() {
+		for _, table := range db.Tables() {
+			key := tableKey{db.name, table.Name()}
+			tables[key] = table
+			keys = append(keys, key)
+		}
+	}
+	var inserts []*sqlparser.Insert
+	for _, key := range sortTables(keys, tables) {
+		tableInserts, err := g.table(key, tables[key])
+		if err != nil {
+			return nil, err
+		}
+		inserts = append(inserts, tableInserts...)
+	}
+	return inserts, nil
+}
+
+// TableFixtures returns INSERT statements filling a single table with
+// random rows. The foreign key columns hold random values, as there are no
+// rows to reference.
+func TableFixtures(create *sqlparser.CreateTable, opts FixtureOptions) ([]*sqlparser.Insert, error) {
+	s := New()
+	if err := s.Apply(create); err != nil {
+		return nil, err
+	}
+	return s.Fixtures(opts)
+}
+
+// fixtureRow holds the values of a generated row by lowercase column name.
+type fixtureRow map[string]sqlparser.Expr
+
+type fixtureGenerator struct {
+	schema *Schema
+	opts   FixtureOptions
+	rand   *rand.Rand
+	// rows holds the rows generated for each table, which foreign keys
+	// reference.
+	rows map[tableKey][]fixtureRow
+}
+
+// table generates the rows of a table.
+func (g *fixtureGenerator) table(key tableKey, table *Table) ([]*sqlparser.Insert, error) {
+	count := g.opts.Rows
+	if n, ok := g.opts.TableRows[key.name]; ok {
+		count = n
+	}
+	var columns sqlparser.Columns
+	for _, col := range table.Columns() {
+		if columnOptions(col.Type).As != nil {
+			continue
+		}
+		if _, largest := integerLimits(col.Type.SQLType()); isSequential(table, col) && int64(count) > largest {
+			return nil, &ColumnRangeError{Table: sqlparser.String(key.tableName()), Column: col.Name.String(), Rows: count}
+		}
+		columns = append(columns, col.Name)
+	}
+
+	// seen holds the values of each unique key of the generated rows.
+	seen := map[string]map[string]bool{}
+	var rows []fixtureRow
+	for i := 0; i < count; i++ {
+		var row fixtureRow
+		for attempt := 0; ; attempt++ {
+			var err error
+			row, err = g.row(key, table, columns, i, rows)
+			if err != nil {
+				return nil, err
+			}
+			if g.unique(table, row, seen, false) {
+				break
+			}
+			if attempt == maxAttempts {
+				return nil, &UniqueValuesError{Table: sqlparser.String(key.tableName()), Rows: count}
+			}
+		}
+		g.unique(table, row, seen, true)
+		rows = append(rows, row)
+		g.rows[key] = rows
+	}
+
+	var inserts []*sqlparser.Insert
+	for start := 0; start < len(rows); start += g.opts.BatchSize {
+		var values sqlparser.Values
+		for _, row := range rows[start:min(start+g.opts.BatchSize, len(rows))] {
+			tuple := make(sqlparser.ValTuple, len(columns))
+			for i, col := range columns {
+				tuple[i] = row[col.Lowered()]
+			}
+			values = append(values, tuple)
+		}
+		inserts = append(inserts, &sqlparser.Insert{
+			Action:  sqlparser.InsertAct,
+			Table:   &sqlparser.AliasedTableExpr{Expr: key.tableName()},
+			Columns: columns,
+			Rows:    values,
+		})
+	}
+	return inserts, nil
+}
+
+// row generates the i-th row of a table.
+func (g *fixtureGenerator) row(key tableKey, table *Table, columns sqlparser.Columns, i int, rows []fixtureRow) (fixtureRow, error) {
+	row := fixtureRow{}
+	for _, name := range columns {
+		col := table.Column(name.String())
+		opts := columnOptions(col.Type)
+		switch {
+		case isSequential(table, col):
+			row[name.Lowered()] = sqlparser.NewIntLiteral(strconv.Itoa(i + 1))
+		case (opts.Null == nil || *opts.Null) && g.rand.Float64() < g.opts.NullRate:
+			row[name.Lowered()] = &sqlparser.NullVal{}
+		default:
+			value, err := g.value(col)
+			if err != nil {
+				return nil, fmt.Errorf("table %s: %w", sqlparser.String(key.tableName()), err)
+			}
+			row[name.Lowered()] = value
+		}
+	}
+
+	for _, constraint := range table.ForeignKeys() {
+		fk := constraint.Details.(*sqlparser.ForeignKeyDefinition)
+		parentKey := key.resolve(fk.ReferenceDefinition.ReferencedTable)
+		if g.schema.databases[parentKey.db] == nil || g.schema.databases[parentKey.db].tables[parentKey.name] == nil {
+			// The referenced table is not part of the schema.
+			continue
+		}
+		parents := g.rows[parentKey]
+		if parentKey == key {
+			// A row can reference itself.
+			parents = append(rows[:len(rows):len(rows)], row)
+		}
+		nullable := true
+		for _, col := range fk.Source {
+			null := columnOptions(table.Column(col.String()).Type).Null
+			nullable = nullable && (null == nil || *null)
+		}
+		if len(parents) == 0 || nullable && g.rand.Float64() < g.opts.NullRate {
+			if !nullable {
+				return nil, &MissingParentRowsError{Table: sqlparser.String(key.tableName()), ForeignKey: constraint.Name.String(), Parent: sqlparser.String(parentKey.tableName())}
+			}
+			for _, col := range fk.Source {
+				row[col.Lowered()] = &sqlparser.NullVal{}
+			}
+			continue
+		}
+		parent := parents[g.rand.IntN(len(parents))]
+		for i, col := range fk.Source {
+			row[col.Lowered()] = parent[fk.ReferenceDefinition.ReferencedColumns[i].Lowered()]
+		}
+	}
+	return row, nil
+}
+
+// unique returns whether the values of the unique keys of a row are not
+// seen yet, and marks them seen if record is set. Keys with a NULL value
+// never conflict.
+func (g *fixtureGenerator) unique(table *Table, row fixtureRow, seen map[string]map[string]bool, record bool) bool {
+	for _, index := range table.Indexes() {
+		if !index.Info.IsUnique() {
+			continue
+		}
+		var parts []string
+		for _, part := range index.Columns {
+			value, ok := row[part.Column.Lowered()]
+			if part.Expression != nil || !ok {
+				parts = nil
+				break
+			}
+			if _, ok := value.(*sqlparser.NullVal); ok {
+				parts = nil
+				break
+			}
+			// Unique keys compare prefixes with the collation of the column,
+			// which is assumed to be case insensitive.
+			text := strings.ToLower(sqlparser.String(value))
+			if lit, ok := value.(*sqlparser.Literal); ok && part.Length != nil && len(lit.Val) > *part.Length {
+				text = strings.ToLower(lit.Val[:*part.Length])
+			}
+			parts = append(parts, text)
+		}
+		if parts == nil {
+			continue
+		}
+		name := index.Info.Name.String()
+		value := strings.Join(parts, "\x00")
+		if record {
+			if seen[name] == nil {
+				seen[name] = map[string]bool{}
+			}
+			seen[name][value] = true
+		} else if seen[name][value] {
+			return false
+		}
+	}
+	return true
+}
+
+// isSequential returns whether the values of a column are numbered from 1:
+// AUTO_INCREMENT columns and integer primary keys of a single column.
+func isSequential(table *Table, col *sqlparser.ColumnDefinition) bool {
+	if columnOptions(col.Type).Autoincrement {
+		return true
+	}
+	pk := table.PrimaryKey()
+	return pk != nil && len(pk.Columns) == 1 && pk.Columns[0].Column.Equal(col.Name) && isIntegerType(col.Type)
+}
+
+func isIntegerType(ct *sqlparser.ColumnType) bool {
+	return sqltypes.IsIntegral(ct.SQLType())
+}
+
+// Words used to build string values.
+var (
+	fixtureWords = []string{
+		"alpha", "amber", "apple", "azure", "bright", "cedar", "coral", "delta", "ember", "falcon",
+		"forest", "garnet", "harbor", "island", "jade", "lemon", "maple", "meadow", "nova", "ocean",
+		"pearl", "quartz", "river", "sierra", "stone", "tiger", "union", "velvet", "willow", "zephyr",
+	}
+	fixtureNames = []string{
+		"Alice", "Bob", "Carol", "Dave", "Erin", "Frank", "Grace", "Heidi", "Ivan", "Judy",
+		"Mallory", "Niaj", "Olivia", "Peggy", "Rupert", "Sybil", "Trent", "Victor", "Walter", "Yasmin",
+	}
+	spatialValues = map[string]string{
+		"linestring":         "LINESTRING(0 0,1 1)",
+		"polygon":            "POLYGON((0 0,1 0,1 1,0 0))",
+		"multipoint":         "MULTIPOINT((0 0),(1 1))",
+		"multilinestring":    "MULTILINESTRING((0 0,1 1))",
+		"multipolygon":       "MULTIPOLYGON(((0 0,1 0,1 1,0 0)))",
+		"geometrycollection": "GEOMETRYCOLLECTION(POINT(0 0))",
+		"geomcollection":     "GEOMETRYCOLLECTION(POINT(0 0))",
+	}
+)
+
+// value returns a random value of the type of a column.
+func (g *fixtureGenerator) value(col *sqlparser.ColumnDefinition) (sqlparser.Expr, error) {
+	ct := col.Type
+	typ := strings.ToLower(ct.Type)
+	length := -1
+	if ct.Length != nil {
+		length = *ct.Length
+	}
+	switch sqlType := ct.SQLType(); {
+	case typ == "bool" || typ == "boolean" || typ == "tinyint" && length == 1:
+		return sqlparser.NewIntLiteral(strconv.Itoa(g.rand.IntN(2))), nil
+	case sqlType == sqltypes.Year:
+		return sqlparser.NewIntLiteral(strconv.Itoa(1970 + g.rand.IntN(61))), nil
+	case sqltypes.IsIntegral(sqlType):
+		return sqlparser.NewIntLiteral(strconv.FormatInt(g.integer(sqlType), 10)), nil
+	case sqlType == sqltypes.Decimal:
+		return sqlparser.NewDecimalLiteral(g.decimal(ct)), nil
+	case sqlType == sqltypes.Float32 || sqlType == sqltypes.Float64:
+		if ct.Length != nil && ct.Scale != nil {
+			return sqlparser.NewFloatLiteral(g.decimal(ct)), nil
+		}
+		value := g.rand.Float64() * 1000
+		if !ct.Unsigned && g.rand.IntN(4) == 0 {
+			value = -value
+		}
+		return sqlparser.NewFloatLiteral(strconv.FormatFloat(value, 'f', 2, 64)), nil
+	case sqlType == sqltypes.Date:
+		return sqlparser.NewStrLiteral(g.date()), nil
+	case sqlType == sqltypes.Datetime || sqlType == sqltypes.Timestamp:
+		return sqlparser.NewStrLiteral(g.date() + " " + g.time(ct.Length)), nil
+	case sqlType == sqltypes.Time:
+		return sqlparser.NewStrLiteral(g.time(ct.Length)), nil
+	case sqlType == sqltypes.Enum:
+		members, err := decodeMembers(ct.EnumValues)
+		if err != nil {
+			return nil, err
+		}
+		return sqlparser.NewStrLiteral(members[g.rand.IntN(len(members))]), nil
+	case sqlType == sqltypes.Set:
+		members, err := decodeMembers(ct.EnumValues)
+		if err != nil {
+			return nil, err
+		}
+		var chosen []string
+		for _, member := range members {
+			if g.rand.IntN(2) == 0 {
+				chosen = append(chosen, member)
+			}
+		}
+		return sqlparser.NewStrLiteral(strings.Join(chosen, ",")), nil
+	case sqlType == sqltypes.TypeJSON:
+		return sqlparser.NewStrLiteral(fmt.Sprintf(`{"id": %d, "tag": %q}`, g.rand.IntN(1000), g.word())), nil
+	case sqlType == sqltypes.Bit:
+		if length < 0 {
+			length = 1
+		}
+		bits := make([]byte, length)
+		for i := range bits {
+			bits[i] = byte('0' + g.rand.IntN(2))
+		}
+		return sqlparser.NewBitLiteral("0b" + string(bits)), nil
+	case sqlType == sqltypes.Binary || sqlType == sqltypes.VarBinary || sqlType == sqltypes.Blob:
+		size := 16
+		switch {
+		case typ == "binary" && length < 0:
+			size = 1
+		case typ == "binary":
+			size = length
+		case length >= 0:
+			size = min(size, length)
+		}
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(g.rand.IntN(256))
+		}
+		return sqlparser.NewHexLiteral(hex.EncodeToString(data)), nil
+	case sqlType == sqltypes.Char || sqlType == sqltypes.VarChar || sqlType == sqltypes.Text:
+		switch {
+		case typ == "char" && length < 0:
+			length = 1
+		case sqlType == sqltypes.Text:
+			length = 64
+		}
+		return sqlparser.NewStrLiteral(g.text(col.Name.Lowered(), length)), nil
+	case sqlType == sqltypes.Geometry:
+		wkt, ok := spatialValues[typ]
+		if !ok {
+			wkt = fmt.Sprintf("POINT(%d %d)", g.rand.IntN(180)-90, g.rand.IntN(180)-90)
+		}
+		return &sqlparser.FuncExpr{
+			Name:  sqlparser.NewIdentifierCI("ST_GeomFromText"),
+			Exprs: sqlparser.Exprs{sqlparser.NewStrLiteral(wkt)},
+		}, nil
+	}
+	return nil, fmt.Errorf("column %s: unsupported type %s", col.Name.String(), ct.Type)
+}
+
+// integer returns a random integer of a type. Values are at most one
+// million in absolute value, which fits realistic quantities and
+// identifiers.
+func (g *fixtureGenerator) integer(typ sqltypes.Type) int64 {
+	smallest, largest := integerLimits(typ)
+	smallest, largest = max(smallest, -1_000_000), min(largest, 1_000_000)
+	return smallest + g.rand.Int64N(largest-smallest+1)
+}
+
+// integerLimits returns the smallest and largest values of an integer type.
+// BIGINT UNSIGNED values are limited to the values of an int64.
+func integerLimits(typ sqltypes.Type) (int64, int64) {
+	switch typ {
+	case sqltypes.Int8:
+		return math.MinInt8, math.MaxInt8
+	case sqltypes.Uint8:
+		return 0, math.MaxUint8
+	case sqltypes.Int16:
+		return math.MinInt16, math.MaxInt16
+	case sqltypes.Uint16:
+		return 0, math.MaxUint16
+	case sqltypes.Int24:
+		return -1 << 23, 1<<23 - 1
+	case sqltypes.Uint24:
+		return 0, 1<<24 - 1
+	case sqltypes.Int32:
+		return math.MinInt32, math.MaxInt32
+	case sqltypes.Uint32:
+		return 0, math.MaxUint32
+	case sqltypes.Uint64:
+		return 0, math.MaxInt64
+	}
+	return math.MinInt64, math.MaxInt64
+}
+
+// decimal returns a random value of a DECIMAL(M, D), FLOAT(M, D) or
+// DOUBLE(M, D) column, with at most six digits before the decimal point.
+func (g *fixtureGenerator) decimal(ct *sqlparser.ColumnType) string {
+	precision, scale := 10, 0
+	if ct.Length != nil {
+		precision = *ct.Length
+	}
+	if ct.Scale != nil {
+		scale = *ct.Scale
+	}
+	digits := min(precision-scale, 6)
+	var b strings.Builder
+	if !ct.Unsigned && g.rand.IntN(4) == 0 {
+		b.WriteByte('-')
+	}
+	limit := int64(1)
+	for i := 0; i < digits; i++ {
+		limit *= 10
+	}
+	b.WriteString(strconv.FormatInt(g.rand.Int64N(limit), 10))
+	if scale > 0 {
+		b.WriteByte('.')
+		for i := 0; i < scale; i++ {
+			b.WriteByte(byte('0' + g.rand.IntN(10)))
+		}
+	}
+	return b.String()
+}
+
+// date returns a random date between 2000 and 2030.
+func (g *fixtureGenerator) date() string {
+	return fmt.Sprintf("%04d-%02d-%02d", 2000+g.rand.IntN(31), 1+g.rand.IntN(12), 1+g.rand.IntN(28))
+}
+
+// time returns a random time of day with the given fractional seconds
+// precision.
+func (g *fixtureGenerator) time(precision *int) string {
+	value := fmt.Sprintf("%02d:%02d:%02d", g.rand.IntN(24), g.rand.IntN(60), g.rand.IntN(60))
+	if precision != nil && *precision > 0 {
+		value += "."
+		for i := 0; i < *precision; i++ {
+			value += strconv.Itoa(g.rand.IntN(10))
+		}
+	}
+	return value
+}
+
+func (g *fixtureGenerator) word() string {
+	return fixtureWords[g.rand.IntN(len(fixtureWords))]
+}
+
+// text returns a random string of at most length characters, shaped after
+// the name of its column: e-mail addresses, URLs, phone numbers, names or
+// words.
+func (g *fixtureGenerator) text(column string, length int) string {
+	var value string
+	switch {
+	case strings.Contains(column, "email"):
+		value = fmt.Sprintf("%s%d@example.com", g.word(), g.rand.IntN(10000))
+	case strings.Contains(column, "url"):
+		value = fmt.Sprintf("https://example.com/%s/%d", g.word(), g.rand.IntN(10000))
+	case strings.Contains(column, "phone"):
+		value = fmt.Sprintf("+1-555-%04d", g.rand.IntN(10000))
+	case strings.Contains(column, "name"):
+		value = fixtureNames[g.rand.IntN(len(fixtureNames))]
+	default:
+		words := make([]string, 1+g.rand.IntN(3))
+		for i := range words {
+			words[i] = g.word()
+		}
+		value = strings.Join(words, " ")
+	}
+	if length >= 0 && len(value) > length {
+		value = strings.TrimRight(value[:length], " ")
+	}
+	return value
+}
+
+// decodeMembers returns the unquoted members of an ENUM or SET.
+func decodeMembers(values []string) ([]string, error) {
+	members := make([]string, len(values))
+	for i, value := range values {
+		member, err := sqltypes.DecodeStringSQL(value)
+		if err != nil {
+			return nil, err
+		}
+		members[i] = member
+	}
+	return members, nil
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func fixtureSchema(t *testing.T) *Schema {
+	t.Helper()
+	s, err := NewFromSQL(sqlparser.NewTestParser(), `
+		create database shop;
+		use shop;
+		create table orders (id bigint primary key, customer_id int unsigned not null, parent_id bigint,
+			foreign key (customer_id) references customer (id), foreign key (parent_id) references orders (id));
+		create table customer (id int unsigned primary key auto_increment, email varchar(16) not null unique,
+			kind enum('retail','wholesale') not null, tags set('new','vip'), score tinyint unsigned, total int as (score * 2));
+	`)
+	require.NoError(t, err)
+	return s
+}
+
+// fixtureRows returns the rows of the inserts into a table by column name.
+func fixtureRows(inserts []*sqlparser.Insert, table string) []map[string]sqlparser.Expr {
+	var rows []map[string]sqlparser.Expr
+	for _, ins := range inserts {
+		if ins.Table.Expr.(sqlparser.TableName).Name.String() != table {
+			continue
+		}
+		for _, tuple := range ins.Rows.(sqlparser.Values) {
+			row := map[string]sqlparser.Expr{}
+			for i, col := range ins.Columns {
+				row[col.String()] = tuple[i]
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func TestFixtures(t *testing.T) {
+	s := fixtureSchema(t)
+	inserts, err := s.Fixtures(FixtureOptions{Seed: 7, Rows: 50, TableRows: map[string]int{"customer": 20}, BatchSize: 15})
+	require.NoError(t, err)
+
+	var tables []string
+	for _, ins := range inserts {
+		tables = append(tables, sqlparser.String(ins.Table))
+	}
+	assert.Equal(t, []string{"shop.customer", "shop.customer", "shop.orders", "shop.orders", "shop.orders", "shop.orders"}, tables)
+
+	customers := fixtureRows(inserts, "customer")
+	require.Len(t, customers, 20)
+	ids := map[string]bool{}
+	emails := map[string]bool{}
+	for i, row := range customers {
+		assert.NotContains(t, row, "total")
+		assert.Equal(t, strconv.Itoa(i+1), sqlparser.String(row["id"]))
+		ids[sqlparser.String(row["id"])] = true
+
+		email := row["email"].(*sqlparser.Literal).Val
+		assert.LessOrEqual(t, len(email), 16)
+		assert.False(t, emails[email], "duplicate email %s", email)
+		emails[email] = true
+
+		assert.Contains(t, []string{"retail", "wholesale"}, row["kind"].(*sqlparser.Literal).Val)
+		if tags, ok := row["tags"].(*sqlparser.Literal); ok && tags.Val != "" {
+			for _, tag := range strings.Split(tags.Val, ",") {
+				assert.Contains(t, []string{"new", "vip"}, tag)
+			}
+		}
+		if score, ok := row["score"].(*sqlparser.Literal); ok {
+			n, err := strconv.Atoi(score.Val)
+			require.NoError(t, err)
+			assert.True(t, n >= 0 && n <= 255, "score %d", n)
+		}
+	}
+
+	orders := fixtureRows(inserts, "orders")
+	require.Len(t, orders, 50)
+	for i, row := range orders {
+		assert.True(t, ids[sqlparser.String(row["customer_id"])], "customer_id %s", sqlparser.String(row["customer_id"]))
+		if parent, ok := row["parent_id"].(*sqlparser.Literal); ok {
+			n, err := strconv.Atoi(parent.Val)
+			require.NoError(t, err)
+			assert.True(t, n >= 1 && n <= i+1, "parent_id %d of order %d", n, i+1)
+		}
+	}
+}
+
+func TestFixturesSeed(t *testing.T) {
+	generate := func(seed uint64) string {
+		inserts, err := fixtureSchema(t).Fixtures(FixtureOptions{Seed: seed})
+		require.NoError(t, err)
+		var b strings.Builder
+		for _, ins := range inserts {
+			b.WriteString(sqlparser.String(ins))
+			b.WriteString(";\n")
+		}
+		return b.String()
+	}
+	assert.Equal(t, generate(1), generate(1))
+	assert.NotEqual(t, generate(1), generate(2))
+}
+
+func TestFixturesValues(t *testing.T) {
+	testcases := []struct {
+		column string
+		check  func(t *testing.T, value string)
+	}{{
+		column: "a tinyint(1) not null",
+		check: func(t *testing.T, value string) {
+			assert.Contains(t, []string{"0", "1"}, value)
+		},
+	}, {
+		column: "a smallint not null",
+		check: func(t *testing.T, value string) {
+			n, err := strconv.Atoi(value)
+			require.NoError(t, err)
+			assert.True(t, n >= -32768 && n < 32768, value)
+		},
+	}, {
+		column: "a bigint unsigned not null",
+		check: func(t *testing.T, value string) {
+			assert.NotContains(t, value, "-")
+		},
+	}, {
+		column: "a decimal(5,2) not null",
+		check: func(t *testing.T, value string) {
+			integer, fraction, ok := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+			assert.True(t, ok, value)
+			assert.LessOrEqual(t, len(integer), 3)
+			assert.Len(t, fraction, 2)
+		},
+	}, {
+		column: "a float(4,2) not null",
+		check: func(t *testing.T, value string) {
+			integer, fraction, ok := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+			assert.True(t, ok, value)
+			assert.LessOrEqual(t, len(integer), 2)
+			assert.Len(t, fraction, 2)
+		},
+	}, {
+		column: "a char(3) not null",
+		check: func(t *testing.T, value string) {
+			assert.LessOrEqual(t, len(value), len("'abc'"))
+		},
+	}, {
+		column: "a binary(4) not null",
+		check: func(t *testing.T, value string) {
+			assert.Regexp(t, `^X'[0-9a-f]{8}'$`, value)
+		},
+	}, {
+		column: "a bit(5) not null",
+		check: func(t *testing.T, value string) {
+			assert.Regexp(t, `^0b[01]{5}$`, value)
+		},
+	}, {
+		column: "a datetime(3) not null",
+		check: func(t *testing.T, value string) {
+			assert.Regexp(t, `^'\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}'$`, value)
+		},
+	}, {
+		column: "a year not null",
+		check: func(t *testing.T, value string) {
+			n, err := strconv.Atoi(value)
+			require.NoError(t, err)
+			assert.True(t, n >= 1970 && n <= 2030, value)
+		},
+	}, {
+		column: "a polygon not null",
+		check: func(t *testing.T, value string) {
+			assert.Equal(t, "ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))')", value)
+		},
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.column, func(t *testing.T) {
+			stmt, err := parser.Parse("create table t (" + tcase.column + ")")
+			require.NoError(t, err)
+			inserts, err := TableFixtures(stmt.(*sqlparser.CreateTable), FixtureOptions{Seed: 3, Rows: 20})
+			require.NoError(t, err)
+			for _, row := range fixtureRows(inserts, "t") {
+				tcase.check(t, sqlparser.String(row["a"]))
+			}
+		})
+	}
+}
+
+func TestFixturesErrors(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		opts FixtureOptions
+		err  string
+	}{{
+		sql: `create table p (id int primary key);
+			create table c (id int primary key, p_id int not null, constraint fk_p foreign key (p_id) references p (id))`,
+		opts: FixtureOptions{TableRows: map[string]int{"p": 0}},
+		err:  "cannot generate rows of table 'db.c': foreign key 'fk_p' references table 'db.p', which has no rows",
+	}, {
+		sql:  "create table t (a tinyint unsigned not null unique)",
+		opts: FixtureOptions{Rows: 300},
+		err:  "cannot generate 300 rows of table 'db.t' with distinct unique keys",
+	}, {
+		sql:  "create table t (id tinyint primary key)",
+		opts: FixtureOptions{Rows: 200},
+		err:  "cannot generate 200 rows of table 'db.t': column 'id' is out of range",
+	}, {
+		sql:  "create table t (id tinyint unsigned auto_increment, key (id))",
+		opts: FixtureOptions{Rows: 300},
+		err:  "cannot generate 300 rows of table 'db.t': column 'id' is out of range",
+	}, {
+		sql: "create table t (a vector(3))",
+		err: "table db.t: column a: unsupported type vector",
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.err, func(t *testing.T) {
+			s, err := NewFromSQL(sqlparser.NewTestParser(), "create database db; use db; "+tcase.sql)
+			require.NoError(t, err)
+			_, err = s.Fixtures(tcase.opts)
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
@@ -0,0 +1,545 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// ObjectKind is the kind of a schema object.
+type ObjectKind int8
+
+const (
+	// ObjectTable is a base table.
+	ObjectTable ObjectKind = iota
+	// ObjectView is a view.
+	ObjectView
+	// ObjectRoutine is a stored procedure or function.
+	ObjectRoutine
+)
+
+// String returns the name of the kind as used in messages.
+func (k ObjectKind) String() string {
+	switch k {
+	case ObjectTable:
+		return "table"
+	case ObjectView:
+		return "view"
+	case ObjectRoutine:
+		return "routine"
+	}
+	return fmt.Sprintf("ObjectKind(%d)", int8(k))
+}
+
+// Object identifies a table, view or stored routine.
+type Object struct {
+	Kind     ObjectKind
+	Database string
+	Name     string
+}
+
+// String returns the kind and qualified name of the object.
+func (o Object) String() string {
+	if o.Database == "" {
+		return fmt.Sprintf("%s %s", o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s %s.%s", o.Kind, o.Database, o.Name)
+}
+
+func (o Object) key() tableKey {
+	return tableKey{o.Database, o.Name}
+}
+
+func (o Object) less(other Object) bool {
+	if o.key() != other.key() {
+		return o.key().less(other.key())
+	}
+	return o.Kind < other.Kind
+}
+
+// DependencyKind is the construct a dependency comes from.
+type DependencyKind int8
+
+const (
+	// DependencyForeignKey comes from a foreign key referencing a table.
+	DependencyForeignKey DependencyKind = iota
+	// DependencyView comes from a view selecting from an object.
+	DependencyView
+	// DependencyGeneratedColumn comes from the expression of a generated
+	// column.
+	DependencyGeneratedColumn
+	// DependencyCheck comes from the expression of a check constraint.
+	DependencyCheck
+)
+
+// String returns the name of the construct the dependency comes from.
+func (k DependencyKind) String() string {
+	switch k {
+	case DependencyForeignKey:
+		return "foreign key"
+	case DependencyView:
+		return "view"
+	case DependencyGeneratedColumn:
+		return "generated column"
+	case DependencyCheck:
+		return "check constraint"
+	}
+	return fmt.Sprintf("DependencyKind(%d)", int8(k))
+}
+
+// Dependency records that one object depends on another, so it must be
+// created after it and dropped before it.
+type Dependency struct {
+	From Object
+	To   Object
+	Kind DependencyKind
+	// Name is the name of the foreign key, generated column or check
+	// constraint the dependency comes from. It is empty for views.
+	Name string
+	// Columns are the columns of To the dependency refers to. Generated
+	// columns and check constraints only refer to the columns of their own
+	// table, so their dependencies have the same From and To.
+	Columns []string
+}
+
+// String describes the dependency.
+func (d *Dependency) String() string {
+	switch d.Kind {
+	case DependencyView:
+		if d.To.Kind == ObjectRoutine {
+			return fmt.Sprintf("%s calls %s", d.From, d.To)
+		}
+		return fmt.Sprintf("%s reads from %s", d.From, d.To)
+	case DependencyGeneratedColumn:
+		return fmt.Sprintf("generated column %s of %s uses columns %s", d.Name, d.From, strings.Join(d.Columns, ", "))
+	case DependencyCheck:
+		return fmt.Sprintf("check constraint %s of %s uses columns %s", d.Name, d.From, strings.Join(d.Columns, ", "))
+	default:
+		return fmt.Sprintf("%s references %s through foreign key %s", d.From, d.To, d.Name)
+	}
+}
+
+// Cycle is a chain of dependencies that leads back to the object it starts
+// from.
+type Cycle []*Dependency
+
+// String returns the objects of the cycle in order, each followed by the
+// construct that makes the previous object depend on it.
+func (c Cycle) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(c[0].From.String())
+	for _, dep := range c {
+		fmt.Fprintf(&b, " -> %s", dep.To)
+		if dep.Name != "" {
+			fmt.Fprintf(&b, " (%s %s)", dep.Kind, dep.Name)
+		}
+	}
+	return b.String()
+}
+
+// Graph is the dependency graph of the objects of a schema. Tables depend on
+// the tables their foreign keys reference, and views on the tables and views
+// they read from and the routines they call. Generated columns and check
+// constraints add dependencies of a table on its own columns, which do not
+// affect the order of objects but are reported by ColumnImpact.
+type Graph struct {
+	objects []Object
+	// uses and usedBy index the dependencies by their From and To objects.
+	uses   map[Object][]*Dependency
+	usedBy map[Object][]*Dependency
+}
+
+// NewGraph returns the dependency graph of the tables and views of a schema.
+//
+// The schema does not model stored routines. Calls of qualified functions
+// from views, such as shop.price(id), are always taken to be routine calls,
+// while unqualified calls only are if the routine is listed in routines.
+// Unqualified routine names refer to the database of the calling view.
+func NewGraph(s *Schema, routines ...sqlparser.TableName) *Graph {
+	g := &Graph{
+		uses:   map[Object][]*Dependency{},
+		usedBy: map[Object][]*Dependency{},
+	}
+	known := map[Object]bool{}
+	add := func(obj Object) {
+		if !known[obj] {
+			known[obj] = true
+			g.objects = append(g.objects, obj)
+		}
+	}
+	for _, db := range s.Databases() {
+		for _, table := range db.Tables() {
+			add(Object{Kind: ObjectTable, Database: db.Name(), Name: table.Name()})
+		}
+		for _, view := range db.Views() {
+			add(Object{Kind: ObjectView, Database: db.Name(), Name: view.Name()})
+		}
+	}
+	current, _ := s.CurrentDatabase()
+	for _, name := range routines {
+		add(routineObject(tableKey{db: current}.resolve(name)))
+	}
+
+	for _, db := range s.Databases() {
+		for _, table := range db.Tables() {
+			from := Object{Kind: ObjectTable, Database: db.Name(), Name: table.Name()}
+			for _, dep := range tableDependencies(from, table) {
+				if known[dep.To] {
+					g.addDependency(dep)
+				}
+			}
+		}
+		for _, view := range db.Views() {
+			from := Object{Kind: ObjectView, Database: db.Name(), Name: view.Name()}
+			for _, dep := range viewDependencies(s, from, view, known) {
+				add(dep.To)
+				g.addDependency(dep)
+			}
+		}
+	}
+	sort.Slice(g.objects, func(i, j int) bool {
+		return g.objects[i].less(g.objects[j])
+	})
+	return g
+}
+
+func (g *Graph) addDependency(dep *Dependency) {
+	g.uses[dep.From] = append(g.uses[dep.From], dep)
+	g.usedBy[dep.To] = append(g.usedBy[dep.To], dep)
+}
+
+// tableDependencies returns the dependencies of a table through its foreign
+// keys, generated columns and check constraints.
+func tableDependencies(from Object, table *Table) []*Dependency {
+	var deps []*Dependency
+	for _, col := range table.Columns() {
+		if col.Type.Options == nil || col.Type.Options.As == nil {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			From:    from,
+			To:      from,
+			Kind:    DependencyGeneratedColumn,
+			Name:    col.Name.String(),
+			Columns: columnNames(col.Type.Options.As),
+		})
+	}
+	for _, constraint := range table.ForeignKeys() {
+		ref := constraint.Details.(*sqlparser.ForeignKeyDefinition).ReferenceDefinition
+		var cols []string
+		for _, col := range ref.ReferencedColumns {
+			cols = append(cols, col.String())
+		}
+		deps = append(deps, &Dependency{
+			From:    from,
+			To:      Object{Kind: ObjectTable, Database: from.Database, Name: ref.ReferencedTable.Name.String()},
+			Kind:    DependencyForeignKey,
+			Name:    constraint.Name.String(),
+			Columns: cols,
+		})
+		if ref.ReferencedTable.Qualifier.NotEmpty() {
+			deps[len(deps)-1].To.Database = ref.ReferencedTable.Qualifier.String()
+		}
+	}
+	for _, constraint := range table.Checks() {
+		deps = append(deps, &Dependency{
+			From:    from,
+			To:      from,
+			Kind:    DependencyCheck,
+			Name:    constraint.Name.String(),
+			Columns: columnNames(constraint.Details.(*sqlparser.CheckConstraintDefinition).Expr),
+		})
+	}
+	return deps
+}
+
+// viewDependencies returns the dependencies of a view on the tables and
+// views it reads from and the routines it calls. Names of common table
+// expressions and of objects that do not exist are skipped.
+func viewDependencies(s *Schema, from Object, view *View, routines map[Object]bool) []*Dependency {
+	ctes := map[string]bool{}
+	var deps []*Dependency
+	seen := map[Object]bool{}
+	use := func(to Object) {
+		if !seen[to] {
+			seen[to] = true
+			deps = append(deps, &Dependency{From: from, To: to, Kind: DependencyView})
+		}
+	}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.CommonTableExpr:
+			ctes[node.ID.String()] = true
+		case *sqlparser.AliasedTableExpr:
+			name, ok := node.Expr.(sqlparser.TableName)
+			if !ok || (name.Qualifier.IsEmpty() && ctes[name.Name.String()]) {
+				break
+			}
+			key := from.key().resolve(name)
+			db := s.databases[key.db]
+			switch {
+			case db == nil:
+			case db.views[key.name] != nil:
+				use(Object{Kind: ObjectView, Database: key.db, Name: key.name})
+			case db.tables[key.name] != nil:
+				use(Object{Kind: ObjectTable, Database: key.db, Name: key.name})
+			}
+		case *sqlparser.FuncExpr:
+			routine := routineObject(from.key().resolve(sqlparser.TableName{Name: sqlparser.NewIdentifierCS(node.Name.String()), Qualifier: node.Qualifier}))
+			if node.Qualifier.NotEmpty() || routines[routine] {
+				use(routine)
+			}
+		}
+		return true, nil
+	}, view.create.Select)
+	return deps
+}
+
+func routineObject(key tableKey) Object {
+	return Object{Kind: ObjectRoutine, Database: key.db, Name: key.name}
+}
+
+// columnNames returns the distinct columns an expression refers to, in
+// order of appearance.
+func columnNames(expr sqlparser.Expr) []string {
+	var names []string
+	for _, col := range columnsOf(expr) {
+		if !slices.Contains(names, col.String()) {
+			names = append(names, col.String())
+		}
+	}
+	return names
+}
+
+// Objects returns the objects of the graph sorted by database and name.
+func (g *Graph) Objects() []Object {
+	return g.objects
+}
+
+// Dependencies returns the dependencies of an object: the objects it uses.
+func (g *Graph) Dependencies(obj Object) []*Dependency {
+	return g.uses[obj]
+}
+
+// Dependents returns the dependencies on an object: the objects that use it
+// directly.
+func (g *Graph) Dependents(obj Object) []*Dependency {
+	return g.usedBy[obj]
+}
+
+// Impact returns the objects that depend on an object directly or
+// indirectly, such as the views reading from a table and the views built on
+// them. They are in the order they can be dropped in, so an object comes
+// before those it depends on.
+func (g *Graph) Impact(obj Object) []Object {
+	impacted := map[Object]bool{}
+	queue := []Object{obj}
+	for len(queue) > 0 {
+		for _, dep := range g.usedBy[queue[0]] {
+			if !impacted[dep.From] && dep.From != obj {
+				impacted[dep.From] = true
+				queue = append(queue, dep.From)
+			}
+		}
+		queue = queue[1:]
+	}
+	var objects []Object
+	for _, o := range g.sort() {
+		if impacted[o] {
+			objects = append(objects, o)
+		}
+	}
+	slices.Reverse(objects)
+	return objects
+}
+
+// ColumnImpact returns the dependencies on a column of a table: the foreign
+// keys that reference it and the generated columns and check constraints
+// that use it.
+func (g *Graph) ColumnImpact(table Object, column string) []*Dependency {
+	var deps []*Dependency
+	for _, dep := range g.usedBy[table] {
+		if slices.ContainsFunc(dep.Columns, func(col string) bool {
+			return strings.EqualFold(col, column)
+		}) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// CreateOrder returns the objects in an order they can be created in: each
+// object comes after the objects it depends on. It returns a
+// DependencyCycleError if the graph has cycles.
+func (g *Graph) CreateOrder() ([]Object, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &DependencyCycleError{Cycles: cycles}
+	}
+	return g.sort(), nil
+}
+
+// DropOrder returns the objects in an order they can be dropped in: each
+// object comes before the objects it depends on. It returns a
+// DependencyCycleError if the graph has cycles.
+func (g *Graph) DropOrder() ([]Object, error) {
+	order, err := g.CreateOrder()
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(order)
+	return order, nil
+}
+
+// sort orders the objects so that each one comes after its dependencies.
+// Cycles are broken deterministically.
+func (g *Graph) sort() []Object {
+	visited := map[Object]bool{}
+	var sorted []Object
+	var visit func(obj Object)
+	visit = func(obj Object) {
+		if visited[obj] {
+			return
+		}
+		visited[obj] = true
+		for _, dep := range g.sortedUses(obj) {
+			visit(dep.To)
+		}
+		sorted = append(sorted, obj)
+	}
+	for _, obj := range g.objects {
+		visit(obj)
+	}
+	return sorted
+}
+
+// sortedUses returns the dependencies of an object on other objects, sorted
+// by the object they depend on.
+func (g *Graph) sortedUses(obj Object) []*Dependency {
+	var deps []*Dependency
+	for _, dep := range g.uses[obj] {
+		if dep.To != obj {
+			deps = append(deps, dep)
+		}
+	}
+	sort.SliceStable(deps, func(i, j int) bool {
+		return deps[i].To.less(deps[j].To)
+	})
+	return deps
+}
+
+// Cycles returns one cycle for each group of objects that depend on each
+// other, such as two tables with foreign keys referencing each other. Tables
+// whose foreign keys reference the table itself do not form a cycle.
+func (g *Graph) Cycles() []Cycle {
+	// Tarjan's algorithm finds the strongly connected components; each one
+	// with more than one object holds at least one cycle.
+	index := map[Object]int{}
+	low := map[Object]int{}
+	onStack := map[Object]bool{}
+	var stack []Object
+	var components [][]Object
+	var connect func(obj Object)
+	connect = func(obj Object) {
+		index[obj] = len(index)
+		low[obj] = index[obj]
+		stack = append(stack, obj)
+		onStack[obj] = true
+		for _, dep := range g.sortedUses(obj) {
+			if _, ok := index[dep.To]; !ok {
+				connect(dep.To)
+				low[obj] = min(low[obj], low[dep.To])
+			} else if onStack[dep.To] {
+				low[obj] = min(low[obj], index[dep.To])
+			}
+		}
+		if low[obj] == index[obj] {
+			var component []Object
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == obj {
+					break
+				}
+			}
+			if len(component) > 1 {
+				components = append(components, component)
+			}
+		}
+	}
+	for _, obj := range g.objects {
+		if _, ok := index[obj]; !ok {
+			connect(obj)
+		}
+	}
+
+	var cycles []Cycle
+	for _, component := range components {
+		members := map[Object]bool{}
+		start := component[0]
+		for _, obj := range component {
+			members[obj] = true
+			if obj.less(start) {
+				start = obj
+			}
+		}
+		cycles = append(cycles, g.cycleFrom(start, members))
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0].From.less(cycles[j][0].From)
+	})
+	return cycles
+}
+
+// cycleFrom returns the shortest cycle through start whose objects are all
+// members of the same strongly connected component.
+func (g *Graph) cycleFrom(start Object, members map[Object]bool) Cycle {
+	via := map[Object]*Dependency{}
+	queue := []Object{start}
+	for len(queue) > 0 {
+		obj := queue[0]
+		queue = queue[1:]
+		for _, dep := range g.sortedUses(obj) {
+			if !members[dep.To] {
+				continue
+			}
+			if dep.To == start {
+				cycle := Cycle{dep}
+				for obj != start {
+					cycle = append(cycle, via[obj])
+					obj = via[obj].From
+				}
+				slices.Reverse(cycle)
+				return cycle
+			}
+			if via[dep.To] == nil {
+				via[dep.To] = dep
+				queue = append(queue, dep.To)
+			}
+		}
+	}
+	return nil
+}
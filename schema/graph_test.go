@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func objectNames(objects []Object) []string {
+	var names []string
+	for _, obj := range objects {
+		names = append(names, obj.String())
+	}
+	return names
+}
+
+func dependencyNames(deps []*Dependency) []string {
+	var names []string
+	for _, dep := range deps {
+		names = append(names, dep.String())
+	}
+	return names
+}
+
+func TestGraph(t *testing.T) {
+	s, err := NewFromSQL(sqlparser.NewTestParser(), `
+		create table customer (id int primary key, email varchar(100), check (email like '%@%'));
+		create table orders (id int primary key, customer_id int, total decimal(10,2), tax decimal(10,2) as (total * 0.2), foreign key (customer_id) references customer (id));
+		create table item (id int primary key, order_id int, parent_id int, foreign key (order_id) references orders (id), foreign key (parent_id) references item (id));
+		create view big_orders as select id from orders where total > 100;
+		create view report as with recent as (select * from big_orders) select c.email, shop.price(r.id), discount(r.id) from recent r join customer c on c.id = r.id;
+		create database shop;
+		create table shop.audit (id int);
+	`)
+	require.NoError(t, err)
+	g := NewGraph(s, sqlparser.NewTableName("discount"))
+
+	assert.Equal(t, []string{
+		"view big_orders", "table customer", "routine discount", "table item", "table orders", "view report",
+		"table shop.audit", "routine shop.price",
+	}, objectNames(g.Objects()))
+
+	order, err := g.CreateOrder()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"table customer", "table orders", "view big_orders", "routine discount", "table item", "routine shop.price",
+		"view report", "table shop.audit",
+	}, objectNames(order))
+	order, err = g.DropOrder()
+	require.NoError(t, err)
+	assert.Equal(t, "table shop.audit", order[0].String())
+	assert.Equal(t, "table customer", order[len(order)-1].String())
+
+	report := Object{Kind: ObjectView, Name: "report"}
+	assert.Equal(t, []string{
+		"view report reads from view big_orders",
+		"view report reads from table customer",
+		"view report calls routine shop.price",
+		"view report calls routine discount",
+	}, dependencyNames(g.Dependencies(report)))
+
+	orders := Object{Kind: ObjectTable, Name: "orders"}
+	assert.Equal(t, []string{
+		"table item references table orders through foreign key item_ibfk_1",
+		"generated column tax of table orders uses columns total",
+		"view big_orders reads from table orders",
+	}, dependencyNames(g.Dependents(orders)))
+	assert.Equal(t, []string{"view report", "table item", "view big_orders"}, objectNames(g.Impact(orders)))
+	assert.Empty(t, g.Impact(Object{Kind: ObjectTable, Database: "shop", Name: "audit"}))
+
+	assert.Equal(t, []string{"generated column tax of table orders uses columns total"}, dependencyNames(g.ColumnImpact(orders, "TOTAL")))
+	assert.Equal(t, []string{
+		"check constraint customer_chk_1 of table customer uses columns email",
+	}, dependencyNames(g.ColumnImpact(Object{Kind: ObjectTable, Name: "customer"}, "email")))
+	assert.Equal(t, []string{
+		"table orders references table customer through foreign key orders_ibfk_1",
+	}, dependencyNames(g.ColumnImpact(Object{Kind: ObjectTable, Name: "customer"}, "id")))
+
+	assert.Empty(t, g.Cycles())
+}
+
+func TestGraphCycles(t *testing.T) {
+	s, err := NewFromSQL(sqlparser.NewTestParser(), `
+		create table a (id int primary key, b_id int);
+		create table b (id int primary key, c_id int);
+		create table c (id int primary key, a_id int, foreign key (a_id) references a (id));
+		create table d (id int primary key, e_id int);
+		create table e (id int primary key, d_id int, foreign key (d_id) references d (id));
+		alter table a add foreign key (b_id) references b (id);
+		alter table b add foreign key (c_id) references c (id);
+		alter table d add constraint fk_e foreign key (e_id) references e (id);
+	`)
+	require.NoError(t, err)
+	g := NewGraph(s)
+
+	cycles := g.Cycles()
+	require.Len(t, cycles, 2)
+	assert.Equal(t, "table a -> table b (foreign key a_ibfk_1) -> table c (foreign key b_ibfk_1) -> table a (foreign key c_ibfk_1)", cycles[0].String())
+	assert.Equal(t, "table d -> table e (foreign key fk_e) -> table d (foreign key e_ibfk_1)", cycles[1].String())
+
+	_, err = g.CreateOrder()
+	assert.EqualError(t, err, "dependency cycle: "+cycles[0].String()+"; "+cycles[1].String())
+	_, err = g.DropOrder()
+	var cycleErr *DependencyCycleError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Len(t, cycleErr.Cycles, 2)
+
+	assert.Equal(t, []string{"table b", "table c"}, objectNames(g.Impact(Object{Kind: ObjectTable, Name: "a"})))
+}
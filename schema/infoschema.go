@@ -0,0 +1,467 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+)
+
+// InformationSchema holds the results of queries on the information_schema
+// tables that describe tables, such as
+//
+//	SELECT * FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = 'shop'
+//
+// Fields are looked up by their information_schema column names, ignoring
+// case. Only Columns is required; the tables whose columns it lists are
+// loaded, and the other results add their options, indexes and foreign
+// keys.
+type InformationSchema struct {
+	// Tables is the result of information_schema.TABLES. Views are skipped.
+	Tables *sqltypes.Result
+	// Columns is the result of information_schema.COLUMNS.
+	Columns *sqltypes.Result
+	// Statistics is the result of information_schema.STATISTICS.
+	Statistics *sqltypes.Result
+	// KeyColumnUsage is the result of information_schema.KEY_COLUMN_USAGE.
+	KeyColumnUsage *sqltypes.Result
+	// ReferentialConstraints is the result of
+	// information_schema.REFERENTIAL_CONSTRAINTS, which holds the ON DELETE
+	// and ON UPDATE rules of foreign keys.
+	ReferentialConstraints *sqltypes.Result
+}
+
+var currentTimestamp = regexp.MustCompile(`(?i)^current_timestamp(\(\d*\))?$`)
+
+// NewFromInformationSchema returns a schema with a database for each schema
+// and the tables described by the information_schema results.
+func NewFromInformationSchema(parser *sqlparser.Parser, is *InformationSchema) (*Schema, error) {
+	creates, err := is.CreateTables(parser)
+	if err != nil {
+		return nil, err
+	}
+	s := New()
+	for _, create := range creates {
+		if db := create.Table.Qualifier; s.Database(db.String()) == nil {
+			if err := s.Apply(&sqlparser.CreateDatabase{DBName: db, FullyParsed: true}); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.Apply(create); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// CreateTables returns the CREATE TABLE statements of the tables described
+// by the results, sorted by schema and name. The table names are qualified
+// with their schema.
+//
+// The statements are built the way SHOW CREATE TABLE prints them and parsed
+// with the given parser, so they are the same AST nodes as the ones parsed
+// from a dump of the tables.
+func (is *InformationSchema) CreateTables(parser *sqlparser.Parser) ([]*sqlparser.CreateTable, error) {
+	tables := map[tableKey]*infoTable{}
+	var views []tableKey
+	if is.Tables != nil {
+		rows, err := infoRows("TABLES", is.Tables, "TABLE_SCHEMA", "TABLE_NAME")
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			key := row.key("TABLE_SCHEMA", "TABLE_NAME")
+			if strings.EqualFold(row.str("TABLE_TYPE"), "VIEW") {
+				views = append(views, key)
+				continue
+			}
+			tables[key] = &infoTable{key: key, options: row}
+		}
+	}
+
+	if is.Columns == nil {
+		return nil, &MissingResultError{Table: "COLUMNS"}
+	}
+	rows, err := infoRows("COLUMNS", is.Columns, "TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE")
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].int("ORDINAL_POSITION") < rows[j].int("ORDINAL_POSITION")
+	})
+	for _, row := range rows {
+		key := row.key("TABLE_SCHEMA", "TABLE_NAME")
+		if slices.Contains(views, key) {
+			continue
+		}
+		if tables[key] == nil {
+			tables[key] = &infoTable{key: key}
+		}
+		tables[key].columns = append(tables[key].columns, row)
+	}
+
+	if is.Statistics != nil {
+		rows, err := infoRows("STATISTICS", is.Statistics, "TABLE_SCHEMA", "TABLE_NAME", "INDEX_NAME", "NON_UNIQUE", "SEQ_IN_INDEX")
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].int("SEQ_IN_INDEX") < rows[j].int("SEQ_IN_INDEX")
+		})
+		for _, row := range rows {
+			if table := tables[row.key("TABLE_SCHEMA", "TABLE_NAME")]; table != nil {
+				table.indexes = appendGroup(table.indexes, row.str("INDEX_NAME"), row)
+			}
+		}
+	}
+
+	rules := map[tableKey]infoRow{}
+	if is.ReferentialConstraints != nil {
+		rows, err := infoRows("REFERENTIAL_CONSTRAINTS", is.ReferentialConstraints, "CONSTRAINT_SCHEMA", "CONSTRAINT_NAME")
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			rules[row.key("CONSTRAINT_SCHEMA", "CONSTRAINT_NAME")] = row
+		}
+	}
+	if is.KeyColumnUsage != nil {
+		rows, err := infoRows("KEY_COLUMN_USAGE", is.KeyColumnUsage, "CONSTRAINT_SCHEMA", "CONSTRAINT_NAME", "TABLE_SCHEMA", "TABLE_NAME",
+			"COLUMN_NAME", "ORDINAL_POSITION", "REFERENCED_TABLE_SCHEMA", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME")
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].int("ORDINAL_POSITION") < rows[j].int("ORDINAL_POSITION")
+		})
+		for _, row := range rows {
+			table := tables[row.key("TABLE_SCHEMA", "TABLE_NAME")]
+			if table == nil || row.isNull("REFERENCED_TABLE_NAME") {
+				continue
+			}
+			table.foreignKeys = appendGroup(table.foreignKeys, row.str("CONSTRAINT_NAME"), row)
+		}
+	}
+
+	keys := make([]tableKey, 0, len(tables))
+	for key, table := range tables {
+		if len(table.columns) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+	creates := make([]*sqlparser.CreateTable, 0, len(keys))
+	for _, key := range keys {
+		sql, err := tables[key].createTable(rules)
+		if err != nil {
+			return nil, fmt.Errorf("table %s.%s: %w", key.db, key.name, err)
+		}
+		stmt, err := parser.ParseStrictDDL(sql)
+		if err != nil {
+			return nil, fmt.Errorf("table %s.%s: %w", key.db, key.name, err)
+		}
+		create := stmt.(*sqlparser.CreateTable)
+		create.Table.Qualifier = sqlparser.NewIdentifierCS(key.db)
+		creates = append(creates, create)
+	}
+	return creates, nil
+}
+
+// infoRow is a row of an information_schema result, by upper case field
+// name.
+type infoRow map[string]sqltypes.Value
+
+// infoRows returns the rows of a result, checking that it has the given
+// fields.
+func infoRows(table string, result *sqltypes.Result, fields ...string) ([]infoRow, error) {
+	names := make([]string, len(result.Fields))
+	for i, field := range result.Fields {
+		names[i] = strings.ToUpper(field.Name)
+	}
+	for _, field := range fields {
+		if !slices.Contains(names, field) {
+			return nil, &MissingFieldError{Table: table, Field: field}
+		}
+	}
+	rows := make([]infoRow, 0, len(result.Rows))
+	for _, values := range result.Rows {
+		row := infoRow{}
+		for i, value := range values {
+			row[names[i]] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (r infoRow) isNull(field string) bool {
+	return r[field].IsNull()
+}
+
+func (r infoRow) str(field string) string {
+	return r[field].ToString()
+}
+
+func (r infoRow) int(field string) int {
+	n, _ := strconv.Atoi(r.str(field))
+	return n
+}
+
+func (r infoRow) key(db, name string) tableKey {
+	return tableKey{r.str(db), r.str(name)}
+}
+
+// infoGroup holds the rows of an index or foreign key.
+type infoGroup struct {
+	name string
+	rows []infoRow
+}
+
+func appendGroup(groups []*infoGroup, name string, row infoRow) []*infoGroup {
+	for _, group := range groups {
+		if group.name == name {
+			group.rows = append(group.rows, row)
+			return groups
+		}
+	}
+	return append(groups, &infoGroup{name: name, rows: []infoRow{row}})
+}
+
+// infoTable collects the rows describing a single table.
+type infoTable struct {
+	key         tableKey
+	options     infoRow
+	columns     []infoRow
+	indexes     []*infoGroup
+	foreignKeys []*infoGroup
+}
+
+// createTable returns the CREATE TABLE statement of the table in the format
+// of SHOW CREATE TABLE.
+func (t *infoTable) createTable(rules map[tableKey]infoRow) (string, error) {
+	collation := t.options.str("TABLE_COLLATION")
+	charset := collationCharset(collation)
+
+	var defs []string
+	for _, col := range t.columns {
+		def, err := infoColumn(col, charset, collation)
+		if err != nil {
+			return "", err
+		}
+		defs = append(defs, def)
+	}
+	// SHOW CREATE TABLE lists the primary key first.
+	slices.SortStableFunc(t.indexes, func(a, b *infoGroup) int {
+		switch {
+		case a.name == "PRIMARY" && b.name != "PRIMARY":
+			return -1
+		case b.name == "PRIMARY" && a.name != "PRIMARY":
+			return 1
+		}
+		return 0
+	})
+	for _, index := range t.indexes {
+		defs = append(defs, infoIndex(index))
+	}
+	for _, fk := range t.foreignKeys {
+		defs = append(defs, infoForeignKey(t.key.db, fk, rules[tableKey{fk.rows[0].str("CONSTRAINT_SCHEMA"), fk.name}]))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n  %s\n)", identifier(t.key.name), strings.Join(defs, ",\n  "))
+	if engine := t.options.str("ENGINE"); engine != "" {
+		fmt.Fprintf(&b, " ENGINE=%s", engine)
+	}
+	if collation != "" {
+		fmt.Fprintf(&b, " DEFAULT CHARSET=%s COLLATE=%s", charset, collation)
+	}
+	// CREATE_OPTIONS holds the options given explicitly, such as
+	// "row_format=COMPRESSED key_block_size=8", and flags partitioned tables.
+	for _, option := range strings.Fields(t.options.str("CREATE_OPTIONS")) {
+		if name, value, ok := strings.Cut(option, "="); ok {
+			fmt.Fprintf(&b, " %s=%s", strings.ToUpper(name), value)
+		}
+	}
+	if comment := t.options.str("TABLE_COMMENT"); comment != "" {
+		fmt.Fprintf(&b, " COMMENT=%s", stringLiteral(comment))
+	}
+	return b.String(), nil
+}
+
+// infoColumn returns the definition of a column in the format of SHOW
+// CREATE TABLE.
+func infoColumn(col infoRow, tableCharset, tableCollation string) (string, error) {
+	var b strings.Builder
+	dataType := strings.ToLower(col.str("DATA_TYPE"))
+	if dataType == "" {
+		dataType, _, _ = strings.Cut(col.str("COLUMN_TYPE"), "(")
+	}
+	fmt.Fprintf(&b, "%s %s", identifier(col.str("COLUMN_NAME")), col.str("COLUMN_TYPE"))
+	charset, collation := col.str("CHARACTER_SET_NAME"), col.str("COLLATION_NAME")
+	if charset != "" && charset != tableCharset {
+		fmt.Fprintf(&b, " CHARACTER SET %s", charset)
+	}
+	if collation != "" && collation != tableCollation {
+		fmt.Fprintf(&b, " COLLATE %s", collation)
+	}
+
+	extra := strings.ToLower(col.str("EXTRA"))
+	generated := strings.Contains(extra, "generated") && !strings.Contains(extra, "default_generated")
+	if generated {
+		storage := "VIRTUAL"
+		if strings.Contains(extra, "stored") {
+			storage = "STORED"
+		}
+		fmt.Fprintf(&b, " GENERATED ALWAYS AS (%s) %s", col.str("GENERATION_EXPRESSION"), storage)
+	}
+
+	nullable := strings.EqualFold(col.str("IS_NULLABLE"), "YES")
+	switch {
+	case !nullable:
+		b.WriteString(" NOT NULL")
+	case dataType == "timestamp":
+		b.WriteString(" NULL")
+	}
+	switch {
+	case generated:
+	case !col.isNull("COLUMN_DEFAULT"):
+		value := col.str("COLUMN_DEFAULT")
+		switch {
+		case currentTimestamp.MatchString(value):
+			fmt.Fprintf(&b, " DEFAULT %s", value)
+		case strings.Contains(extra, "default_generated"):
+			fmt.Fprintf(&b, " DEFAULT (%s)", value)
+		case dataType == "bit" && strings.HasPrefix(value, "b'"):
+			fmt.Fprintf(&b, " DEFAULT %s", value)
+		default:
+			fmt.Fprintf(&b, " DEFAULT %s", stringLiteral(value))
+		}
+	case nullable && !hasImplicitDefault(dataType) && !strings.Contains(extra, "auto_increment"):
+		b.WriteString(" DEFAULT NULL")
+	}
+	if strings.Contains(extra, "auto_increment") {
+		b.WriteString(" AUTO_INCREMENT")
+	}
+	if _, onUpdate, ok := strings.Cut(extra, "on update "); ok {
+		fields := strings.Fields(onUpdate)
+		if len(fields) == 0 {
+			return "", &InvalidFieldError{Table: "COLUMNS", Field: "EXTRA", Value: col.str("EXTRA")}
+		}
+		fmt.Fprintf(&b, " ON UPDATE %s", fields[0])
+	}
+	if strings.Contains(extra, "invisible") {
+		b.WriteString(" INVISIBLE")
+	}
+	if comment := col.str("COLUMN_COMMENT"); comment != "" {
+		fmt.Fprintf(&b, " COMMENT %s", stringLiteral(comment))
+	}
+	return b.String(), nil
+}
+
+// infoIndex returns the definition of an index in the format of SHOW
+// CREATE TABLE.
+func infoIndex(index *infoGroup) string {
+	var parts []string
+	for _, row := range index.rows {
+		var part string
+		if row.isNull("COLUMN_NAME") || row.str("COLUMN_NAME") == "" {
+			// Functional key parts have an expression instead of a column.
+			part = fmt.Sprintf("(%s)", row.str("EXPRESSION"))
+		} else {
+			part = identifier(row.str("COLUMN_NAME"))
+			if subPart := row.str("SUB_PART"); subPart != "" {
+				part += fmt.Sprintf("(%s)", subPart)
+			}
+		}
+		if row.str("COLLATION") == "D" {
+			part += " DESC"
+		}
+		parts = append(parts, part)
+	}
+
+	first := index.rows[0]
+	indexType := strings.ToUpper(first.str("INDEX_TYPE"))
+	var b strings.Builder
+	switch {
+	case index.name == "PRIMARY":
+		b.WriteString("PRIMARY KEY")
+	case indexType == "FULLTEXT":
+		fmt.Fprintf(&b, "FULLTEXT KEY %s", identifier(index.name))
+	case indexType == "SPATIAL":
+		fmt.Fprintf(&b, "SPATIAL KEY %s", identifier(index.name))
+	case first.str("NON_UNIQUE") == "0":
+		fmt.Fprintf(&b, "UNIQUE KEY %s", identifier(index.name))
+	default:
+		fmt.Fprintf(&b, "KEY %s", identifier(index.name))
+	}
+	fmt.Fprintf(&b, " (%s)", strings.Join(parts, ", "))
+	if indexType == "HASH" {
+		b.WriteString(" USING HASH")
+	}
+	if comment := first.str("INDEX_COMMENT"); comment != "" {
+		fmt.Fprintf(&b, " COMMENT %s", stringLiteral(comment))
+	}
+	if strings.EqualFold(first.str("IS_VISIBLE"), "NO") {
+		b.WriteString(" INVISIBLE")
+	}
+	return b.String()
+}
+
+// infoForeignKey returns the definition of a foreign key in the format of
+// SHOW CREATE TABLE. The referenced table is qualified only if it is in
+// another schema.
+func infoForeignKey(db string, fk *infoGroup, rules infoRow) string {
+	var cols, refCols []string
+	for _, row := range fk.rows {
+		cols = append(cols, identifier(row.str("COLUMN_NAME")))
+		refCols = append(refCols, identifier(row.str("REFERENCED_COLUMN_NAME")))
+	}
+	first := fk.rows[0]
+	ref := identifier(first.str("REFERENCED_TABLE_NAME"))
+	if refDB := first.str("REFERENCED_TABLE_SCHEMA"); refDB != "" && refDB != db {
+		ref = identifier(refDB) + "." + ref
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		identifier(fk.name), strings.Join(cols, ", "), ref, strings.Join(refCols, ", "))
+	for _, rule := range []string{"DELETE_RULE", "UPDATE_RULE"} {
+		action := strings.ToUpper(rules.str(rule))
+		if action == "" || action == "RESTRICT" || action == "NO ACTION" {
+			continue
+		}
+		fmt.Fprintf(&b, " ON %s %s", strings.TrimSuffix(rule, "_RULE"), action)
+	}
+	return b.String()
+}
+
+func identifier(name string) string {
+	return sqlparser.String(sqlparser.NewIdentifierCS(name))
+}
+
+func stringLiteral(value string) string {
+	return sqlparser.String(sqlparser.NewStrLiteral(value))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+)
+
+func informationSchemaFixture() *InformationSchema {
+	return &InformationSchema{
+		Tables: sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"TABLE_SCHEMA|TABLE_NAME|TABLE_TYPE|ENGINE|TABLE_COLLATION|CREATE_OPTIONS|TABLE_COMMENT",
+				"varchar|varchar|varchar|varchar|varchar|varchar|varchar"),
+			"shop|customer|BASE TABLE|InnoDB|utf8mb4_0900_ai_ci||customers",
+			"shop|orders|BASE TABLE|InnoDB|utf8mb4_0900_ai_ci|row_format=COMPRESSED partitioned|",
+			"shop|recent|VIEW|null|null|null|VIEW",
+		),
+		Columns: sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"TABLE_SCHEMA|TABLE_NAME|COLUMN_NAME|ORDINAL_POSITION|COLUMN_DEFAULT|IS_NULLABLE|DATA_TYPE|COLUMN_TYPE|CHARACTER_SET_NAME|COLLATION_NAME|EXTRA|COLUMN_COMMENT|GENERATION_EXPRESSION",
+				"varchar|varchar|varchar|uint64|text|varchar|varchar|text|varchar|varchar|varchar|text|text"),
+			"shop|customer|email|2|null|NO|varchar|varchar(100)|utf8mb4|utf8mb4_0900_ai_ci||login|",
+			"shop|customer|id|1|null|NO|int|int unsigned|null|null|auto_increment||",
+			"shop|customer|name|3|null|YES|varchar|varchar(50)|latin1|latin1_swedish_ci|||",
+			"shop|customer|created|4|CURRENT_TIMESTAMP|NO|timestamp|timestamp|null|null|DEFAULT_GENERATED on update CURRENT_TIMESTAMP||",
+			"shop|customer|deleted|5|null|YES|timestamp|timestamp|null|null|||",
+			"shop|customer|token|6|rand()|YES|double|double|null|null|DEFAULT_GENERATED||",
+			"shop|orders|id|1|null|NO|bigint|bigint|null|null|||",
+			"shop|orders|customer_id|2|null|YES|int|int unsigned|null|null|||",
+			"shop|orders|status|3|new|NO|enum|enum('new','paid')|utf8mb4|utf8mb4_0900_ai_ci|||",
+			"shop|orders|total|4|0.00|NO|decimal|decimal(10,2)|null|null|||",
+			"shop|orders|tax|5|null|YES|decimal|decimal(10,2)|null|null|VIRTUAL GENERATED||(`total` * 0.2)",
+			"shop|orders|note|6|null|YES|text|text|utf8mb4|utf8mb4_0900_ai_ci|||",
+			"shop|recent|id|1|null|NO|bigint|bigint|null|null|||",
+		),
+		Statistics: sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"TABLE_SCHEMA|TABLE_NAME|NON_UNIQUE|INDEX_NAME|SEQ_IN_INDEX|COLUMN_NAME|COLLATION|SUB_PART|INDEX_TYPE|INDEX_COMMENT|IS_VISIBLE|EXPRESSION",
+				"varchar|varchar|int64|varchar|uint64|varchar|varchar|int64|varchar|varchar|varchar|text"),
+			"shop|customer|0|email|1|email|A|null|BTREE||YES|null",
+			"shop|customer|0|PRIMARY|1|id|A|null|BTREE||YES|null",
+			"shop|customer|1|name_created|2|created|D|null|BTREE||NO|null",
+			"shop|customer|1|name_created|1|name|A|10|BTREE|lookups|NO|null",
+			"shop|customer|1|lower_email|1|null|A|null|BTREE||YES|lower(`email`)",
+			"shop|orders|0|PRIMARY|1|id|A|null|BTREE||YES|null",
+			"shop|orders|1|customer_id|1|customer_id|A|null|BTREE||YES|null",
+			"shop|orders|1|note|1|note|null|null|FULLTEXT||YES|null",
+		),
+		KeyColumnUsage: sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"CONSTRAINT_SCHEMA|CONSTRAINT_NAME|TABLE_SCHEMA|TABLE_NAME|COLUMN_NAME|ORDINAL_POSITION|REFERENCED_TABLE_SCHEMA|REFERENCED_TABLE_NAME|REFERENCED_COLUMN_NAME",
+				"varchar|varchar|varchar|varchar|varchar|uint64|varchar|varchar|varchar"),
+			"shop|PRIMARY|shop|customer|id|1|null|null|null",
+			"shop|orders_ibfk_1|shop|orders|customer_id|1|shop|customer|id",
+		),
+		ReferentialConstraints: sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"CONSTRAINT_SCHEMA|CONSTRAINT_NAME|UPDATE_RULE|DELETE_RULE",
+				"varchar|varchar|varchar|varchar"),
+			"shop|orders_ibfk_1|RESTRICT|CASCADE",
+		),
+	}
+}
+
+func TestInformationSchemaCreateTables(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	creates, err := informationSchemaFixture().CreateTables(parser)
+	require.NoError(t, err)
+
+	// The tables as SHOW CREATE TABLE prints them.
+	expected := []string{
+		"CREATE TABLE `customer` (\n" +
+			"  `id` int unsigned NOT NULL AUTO_INCREMENT,\n" +
+			"  `email` varchar(100) NOT NULL COMMENT 'login',\n" +
+			"  `name` varchar(50) CHARACTER SET latin1 COLLATE latin1_swedish_ci DEFAULT NULL,\n" +
+			"  `created` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+			"  `deleted` timestamp NULL DEFAULT NULL,\n" +
+			"  `token` double DEFAULT (rand()),\n" +
+			"  PRIMARY KEY (`id`),\n" +
+			"  UNIQUE KEY `email` (`email`),\n" +
+			"  KEY `name_created` (`name`(10),`created` DESC) COMMENT 'lookups' /*!80000 INVISIBLE */,\n" +
+			"  KEY `lower_email` ((lower(`email`)))\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='customers'",
+		"CREATE TABLE `orders` (\n" +
+			"  `id` bigint NOT NULL,\n" +
+			"  `customer_id` int unsigned DEFAULT NULL,\n" +
+			"  `status` enum('new','paid') NOT NULL DEFAULT 'new',\n" +
+			"  `total` decimal(10,2) NOT NULL DEFAULT '0.00',\n" +
+			"  `tax` decimal(10,2) GENERATED ALWAYS AS ((`total` * 0.2)) VIRTUAL,\n" +
+			"  `note` text,\n" +
+			"  PRIMARY KEY (`id`),\n" +
+			"  KEY `customer_id` (`customer_id`),\n" +
+			"  FULLTEXT KEY `note` (`note`),\n" +
+			"  CONSTRAINT `orders_ibfk_1` FOREIGN KEY (`customer_id`) REFERENCES `customer` (`id`) ON DELETE CASCADE\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci ROW_FORMAT=COMPRESSED",
+	}
+	require.Len(t, creates, len(expected))
+	for i, sql := range expected {
+		stmt, err := parser.ParseStrictDDL(sql)
+		require.NoError(t, err)
+		want := stmt.(*sqlparser.CreateTable)
+		want.Table.Qualifier = sqlparser.NewIdentifierCS("shop")
+		assert.Equal(t, sqlparser.String(want), sqlparser.String(creates[i]))
+		assert.True(t, sqlparser.Equals.RefOfCreateTable(want, creates[i]), sqlparser.String(creates[i]))
+	}
+}
+
+func TestNewFromInformationSchema(t *testing.T) {
+	s, err := NewFromInformationSchema(sqlparser.NewTestParser(), informationSchemaFixture())
+	require.NoError(t, err)
+	db := s.Database("shop")
+	require.NotNil(t, db)
+	require.Len(t, db.Tables(), 2)
+	assert.Empty(t, db.Views())
+
+	orders := db.Table("orders")
+	require.NotNil(t, orders)
+	require.Len(t, orders.ForeignKeys(), 1)
+	tree, err := s.Cascade(&sqlparser.Delete{TableExprs: []sqlparser.TableExpr{
+		&sqlparser.AliasedTableExpr{Expr: sqlparser.NewTableNameWithQualifier("customer", "shop")},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE shop.customer\n  DELETE shop.orders via foreign key orders_ibfk_1 (customer_id)\n", tree.String())
+}
+
+func TestInformationSchemaErrors(t *testing.T) {
+	_, err := (&InformationSchema{}).CreateTables(sqlparser.NewTestParser())
+	assert.EqualError(t, err, "information_schema.COLUMNS result is missing")
+
+	is := informationSchemaFixture()
+	is.Statistics = sqltypes.MakeTestResult(sqltypes.MakeTestFields("table_schema|table_name|index_name", "varchar|varchar|varchar"))
+	_, err = is.CreateTables(sqlparser.NewTestParser())
+	assert.EqualError(t, err, "information_schema.STATISTICS result has no NON_UNIQUE field")
+
+	is = informationSchemaFixture()
+	is.Columns = sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"TABLE_SCHEMA|TABLE_NAME|COLUMN_NAME|COLUMN_DEFAULT|IS_NULLABLE|DATA_TYPE|COLUMN_TYPE|EXTRA",
+			"varchar|varchar|varchar|text|varchar|varchar|text|varchar"),
+		"shop|customer|created|null|YES|timestamp|timestamp|on update ",
+	)
+	_, err = is.CreateTables(sqlparser.NewTestParser())
+	assert.EqualError(t, err, "table shop.customer: information_schema.COLUMNS result has invalid EXTRA value 'on update '")
+}
@@ -0,0 +1,489 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/ptr"
+)
+
+// Comment versions of the MySQL releases that changed how table definitions
+// are shown.
+const (
+	// checkConstraintsVersion is the first version that enforces CHECK
+	// constraints instead of ignoring them.
+	checkConstraintsVersion = "80016"
+	// displayWidthVersion is the first version that drops the deprecated
+	// display width of integer and YEAR columns.
+	displayWidthVersion = "80019"
+	// utf8mb3Version is the first version that shows utf8 as utf8mb3.
+	utf8mb3Version = "80030"
+)
+
+// integerWidths holds the display width MySQL versions before 8.0.19 give to
+// signed and unsigned integer columns.
+var integerWidths = map[string][2]int{
+	"tinyint":   {4, 3},
+	"smallint":  {6, 5},
+	"mediumint": {9, 8},
+	"int":       {11, 10},
+	"bigint":    {20, 20},
+}
+
+// typeAliases maps type synonyms to the type MySQL stores.
+var typeAliases = map[string]string{
+	"integer": "int",
+	"int4":    "int",
+	"numeric": "decimal",
+	"real":    "double",
+	"float4":  "float",
+	"float8":  "double",
+}
+
+// binaryTypes maps character string types to the binary string type they
+// become with the binary character set.
+var binaryTypes = map[string]string{
+	"char":       "binary",
+	"varchar":    "varbinary",
+	"tinytext":   "tinyblob",
+	"text":       "blob",
+	"mediumtext": "mediumblob",
+	"longtext":   "longblob",
+}
+
+// defaultCollations holds the default collation of the character sets whose
+// default collation is not named <charset>_general_ci.
+var defaultCollations = map[string]string{
+	"binary":  "binary",
+	"latin1":  "latin1_swedish_ci",
+	"big5":    "big5_chinese_ci",
+	"gb2312":  "gb2312_chinese_ci",
+	"gb18030": "gb18030_chinese_ci",
+	"gbk":     "gbk_chinese_ci",
+	"euckr":   "euckr_korean_ci",
+	"sjis":    "sjis_japanese_ci",
+	"ujis":    "ujis_japanese_ci",
+	"cp932":   "cp932_japanese_ci",
+	"eucjpms": "eucjpms_japanese_ci",
+	"tis620":  "tis620_thai_ci",
+}
+
+// engines holds the canonical spelling of the storage engine names.
+var engines = map[string]string{
+	"innodb":     "InnoDB",
+	"myisam":     "MyISAM",
+	"memory":     "MEMORY",
+	"heap":       "MEMORY",
+	"csv":        "CSV",
+	"archive":    "ARCHIVE",
+	"blackhole":  "BLACKHOLE",
+	"merge":      "MRG_MYISAM",
+	"mrg_myisam": "MRG_MYISAM",
+	"federated":  "FEDERATED",
+	"ndb":        "ndbcluster",
+	"ndbcluster": "ndbcluster",
+}
+
+// Normalizer rewrites CREATE TABLE statements into the canonical form a MySQL
+// server of a given version shows for them in SHOW CREATE TABLE, so that
+// semantically identical definitions compare equal with sqlparser.Equals.
+//
+// Timestamp columns are normalized as with explicit_defaults_for_timestamp
+// enabled, the default since MySQL 8.0.2.
+type Normalizer struct {
+	// Charset and Collation are the defaults of tables that specify
+	// neither, i.e. the defaults of their database. NewNormalizer sets them
+	// to the server defaults of the version.
+	Charset   string
+	Collation string
+
+	version string
+}
+
+// NewNormalizer returns a Normalizer for the given MySQL server version, such
+// as "8.0.30" or "5.7.44".
+func NewNormalizer(version string) (*Normalizer, error) {
+	version, err := sqlparser.ConvertMySQLVersionToCommentVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	n := &Normalizer{version: version}
+	if version >= "80000" {
+		n.Charset, n.Collation = "utf8mb4", "utf8mb4_0900_ai_ci"
+	} else {
+		n.Charset, n.Collation = "latin1", "latin1_swedish_ci"
+	}
+	return n, nil
+}
+
+// Normalize returns the canonical form of the given table definition. The
+// definition itself is not modified. Besides the normalization every Table
+// goes through, it:
+//
+//   - resolves type synonyms, e.g. BOOL becomes tinyint(1) and INTEGER int;
+//   - adds or removes integer display widths depending on the version;
+//   - adds the implicit DEFAULT NULL of nullable columns and writes literal
+//     defaults as strings;
+//   - resolves the character set and collation of the table and its columns,
+//     only keeping the column ones that differ from the table;
+//   - adds the default storage engine and drops the AUTO_INCREMENT option;
+//   - drops defaults that are spelled out, such as VISIBLE, USING BTREE and
+//     ON DELETE RESTRICT.
+func (n *Normalizer) Normalize(create *sqlparser.CreateTable) (*sqlparser.CreateTable, error) {
+	if !create.FullyParsed || create.OptLike != nil || create.TableSpec == nil {
+		return nil, unsupportedStatement(create)
+	}
+	create = sqlparser.CloneRefOfCreateTable(create)
+	create.Table = sqlparser.TableName{Name: create.Table.Name}
+	create.IfNotExists = false
+	create.Comments = nil
+
+	table := &Table{create: create}
+	if err := table.normalize(); err != nil {
+		return nil, err
+	}
+
+	spec := create.TableSpec
+	engine, charset, collation := n.normalizeOptions(spec)
+	for _, col := range spec.Columns {
+		n.normalizeColumn(col.Type, charset, collation)
+	}
+	for _, index := range spec.Indexes {
+		normalizeIndexOptions(index, engine)
+	}
+	constraints := spec.Constraints[:0]
+	for _, constraint := range spec.Constraints {
+		switch details := constraint.Details.(type) {
+		case *sqlparser.ForeignKeyDefinition:
+			ref := details.ReferenceDefinition
+			ref.OnDelete = normalizeReferenceAction(ref.OnDelete)
+			ref.OnUpdate = normalizeReferenceAction(ref.OnUpdate)
+			ref.Match = sqlparser.DefaultMatch
+		case *sqlparser.CheckConstraintDefinition:
+			if n.version < checkConstraintsVersion {
+				continue
+			}
+		}
+		constraints = append(constraints, constraint)
+	}
+	spec.Constraints = constraints
+	return create, nil
+}
+
+// Equal reports whether the given table definitions are semantically
+// identical, i.e. whether their normalized forms are equal.
+func (n *Normalizer) Equal(a, b *sqlparser.CreateTable) (bool, error) {
+	a, err := n.Normalize(a)
+	if err != nil {
+		return false, err
+	}
+	b, err = n.Normalize(b)
+	if err != nil {
+		return false, err
+	}
+	return sqlparser.Equals.RefOfCreateTable(a, b), nil
+}
+
+// normalizeOptions rewrites the table options into their canonical order,
+// engine, charset, collate and the others as given, and returns the storage
+// engine, character set and collation of the table.
+func (n *Normalizer) normalizeOptions(spec *sqlparser.TableSpec) (engine, charset, collation string) {
+	var others sqlparser.TableOptions
+	for _, option := range spec.Options {
+		option.Name = strings.ToLower(option.Name)
+		switch option.Name {
+		case "engine":
+			engine = option.String
+		case "charset":
+			charset = option.String
+		case "collate":
+			collation = option.String
+		case "auto_increment":
+		case "comment":
+			if option.Value != nil && option.Value.Val != "" {
+				others = append(others, option)
+			}
+		default:
+			others = append(others, option)
+		}
+	}
+
+	if canonical, ok := engines[strings.ToLower(engine)]; ok {
+		engine = canonical
+	} else if engine == "" {
+		engine = "InnoDB"
+	}
+	charset, collation = n.resolveCharset(charset, collation, false)
+	if charset == "" {
+		charset, collation = n.resolveCharset(n.Charset, n.Collation, false)
+	}
+
+	spec.Options = append(sqlparser.TableOptions{
+		{Name: "engine", String: engine, CaseSensitive: true},
+		{Name: "charset", String: charset, CaseSensitive: true},
+		{Name: "collate", String: collation, CaseSensitive: true},
+	}, others...)
+	return engine, charset, collation
+}
+
+// normalizeColumn rewrites the type and options of a column of a table with
+// the given character set and collation.
+func (n *Normalizer) normalizeColumn(ct *sqlparser.ColumnType, charset, collation string) {
+	ct.Type = strings.ToLower(ct.Type)
+	if alias, ok := typeAliases[ct.Type]; ok {
+		ct.Type = alias
+	}
+	if ct.Options == nil {
+		ct.Options = &sqlparser.ColumnTypeOptions{}
+	}
+	opts := ct.Options
+
+	// Character set and collation.
+	if isCharacterType(ct.Type) {
+		colCharset, colCollation := n.resolveCharset(ct.Charset.Name, opts.Collate, ct.Charset.Binary)
+		if colCharset == "" && ct.Charset.Binary {
+			colCharset, colCollation = n.resolveCharset(charset, "", true)
+		}
+		if binaryType, ok := binaryTypes[ct.Type]; ok && colCharset == "binary" {
+			ct.Type = binaryType
+			colCharset, colCollation = "", ""
+		}
+		if colCharset == charset && colCollation == collation {
+			colCharset, colCollation = "", ""
+		}
+		ct.Charset = sqlparser.ColumnCharset{Name: colCharset}
+		opts.Collate = colCollation
+	} else {
+		ct.Charset = sqlparser.ColumnCharset{}
+		opts.Collate = ""
+	}
+
+	// Lengths.
+	switch ct.Type {
+	case "bool", "boolean":
+		ct.Type, ct.Length = "tinyint", ptr.Of(1)
+	case "tinyint", "smallint", "mediumint", "int", "bigint":
+		switch {
+		case ct.Zerofill:
+			ct.Unsigned = true
+			if ct.Length == nil {
+				ct.Length = ptr.Of(integerWidths[ct.Type][1])
+			}
+		case n.version >= displayWidthVersion:
+			if ct.Type != "tinyint" || ct.Length == nil || *ct.Length != 1 {
+				ct.Length = nil
+			}
+		case ct.Length == nil && ct.Unsigned:
+			ct.Length = ptr.Of(integerWidths[ct.Type][1])
+		case ct.Length == nil:
+			ct.Length = ptr.Of(integerWidths[ct.Type][0])
+		}
+	case "year":
+		if n.version >= displayWidthVersion {
+			ct.Length = nil
+		} else {
+			ct.Length = ptr.Of(4)
+		}
+	case "decimal":
+		if ct.Length == nil {
+			ct.Length = ptr.Of(10)
+		}
+		if ct.Scale == nil {
+			ct.Scale = ptr.Of(0)
+		}
+	case "float":
+		// FLOAT(p) is a FLOAT or a DOUBLE depending on the precision.
+		if ct.Length != nil && ct.Scale == nil {
+			if *ct.Length > 24 {
+				ct.Type = "double"
+			}
+			ct.Length = nil
+		}
+	case "char", "binary", "bit":
+		if ct.Length == nil {
+			ct.Length = ptr.Of(1)
+		}
+	}
+	if ct.Zerofill {
+		ct.Unsigned = true
+	}
+
+	// Nullability and defaults.
+	isTimestamp := ct.Type == "timestamp"
+	if opts.Null != nil && *opts.Null && !isTimestamp {
+		opts.Null = nil
+	}
+	nullable := opts.Null == nil || *opts.Null
+	if isTimestamp && opts.Null == nil && opts.As == nil {
+		opts.Null = ptr.Of(true)
+	}
+	opts.Default = normalizeDefault(opts.Default)
+	opts.OnUpdate = normalizeDefault(opts.OnUpdate)
+	if opts.Default == nil && nullable && opts.As == nil && !opts.Autoincrement && !hasImplicitDefault(ct.Type) {
+		opts.Default = &sqlparser.NullVal{}
+	}
+	if _, ok := opts.Default.(*sqlparser.NullVal); ok || sqlparser.IsLiteral(opts.Default) {
+		opts.DefaultLiteral = true
+	}
+
+	// Options spelled out with their default value.
+	if opts.Invisible != nil && !*opts.Invisible {
+		opts.Invisible = nil
+	}
+	if opts.Comment != nil && opts.Comment.Val == "" {
+		opts.Comment = nil
+	}
+	// MySQL parses and ignores column level REFERENCES.
+	opts.Reference = nil
+}
+
+// resolveCharset returns the character set and collation given by a
+// character set, a collation and the BINARY attribute, each possibly empty.
+// It returns two empty strings if neither is given.
+func (n *Normalizer) resolveCharset(charset, collation string, binary bool) (string, string) {
+	charset = n.charsetName(strings.ToLower(charset))
+	collation = n.collationName(strings.ToLower(collation))
+	switch {
+	case collation != "":
+		charset = n.charsetName(collationCharset(collation))
+	case charset == "":
+		return "", ""
+	case binary:
+		collation = charset + "_bin"
+	default:
+		collation = n.defaultCollation(charset)
+	}
+	return charset, collation
+}
+
+// charsetName returns the name the version shows for the given character set.
+func (n *Normalizer) charsetName(charset string) string {
+	switch charset {
+	case "utf8", "utf8mb3":
+		if n.version >= utf8mb3Version {
+			return "utf8mb3"
+		}
+		return "utf8"
+	}
+	return charset
+}
+
+// collationName returns the name the version shows for the given collation.
+func (n *Normalizer) collationName(collation string) string {
+	charset, suffix, ok := strings.Cut(collation, "_")
+	if !ok {
+		return collation
+	}
+	return n.charsetName(charset) + "_" + suffix
+}
+
+// defaultCollation returns the default collation of the given character set.
+func (n *Normalizer) defaultCollation(charset string) string {
+	if charset == "utf8mb4" && n.version >= "80000" {
+		return "utf8mb4_0900_ai_ci"
+	}
+	if collation, ok := defaultCollations[charset]; ok {
+		return collation
+	}
+	return charset + "_general_ci"
+}
+
+// collationCharset returns the character set of the given collation.
+func collationCharset(collation string) string {
+	charset, _, _ := strings.Cut(collation, "_")
+	return charset
+}
+
+// isCharacterType returns whether the given column type has a character set.
+func isCharacterType(typ string) bool {
+	switch typ {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "set":
+		return true
+	}
+	return false
+}
+
+// hasImplicitDefault returns whether columns of the given type have no
+// DEFAULT NULL shown when they are nullable.
+func hasImplicitDefault(typ string) bool {
+	switch typ {
+	case "tinytext", "text", "mediumtext", "longtext", "tinyblob", "blob", "mediumblob", "longblob", "json",
+		"geometry", "point", "linestring", "polygon", "multipoint", "multilinestring", "multipolygon", "geometrycollection":
+		return true
+	}
+	return false
+}
+
+// normalizeDefault rewrites a column default or ON UPDATE value: numeric
+// literals become strings and the synonyms of CURRENT_TIMESTAMP become
+// CURRENT_TIMESTAMP.
+func normalizeDefault(expr sqlparser.Expr) sqlparser.Expr {
+	switch expr := expr.(type) {
+	case *sqlparser.Literal:
+		switch expr.Type {
+		case sqlparser.IntVal, sqlparser.DecimalVal, sqlparser.FloatVal:
+			return sqlparser.NewStrLiteral(expr.Val)
+		}
+	case sqlparser.BoolVal:
+		if expr {
+			return sqlparser.NewStrLiteral("1")
+		}
+		return sqlparser.NewStrLiteral("0")
+	case *sqlparser.UnaryExpr:
+		if lit, ok := expr.Expr.(*sqlparser.Literal); ok && expr.Operator == sqlparser.UMinusOp {
+			switch lit.Type {
+			case sqlparser.IntVal, sqlparser.DecimalVal, sqlparser.FloatVal:
+				return sqlparser.NewStrLiteral("-" + lit.Val)
+			}
+		}
+	case *sqlparser.CurTimeFuncExpr:
+		switch expr.Name.Lowered() {
+		case "now", "localtime", "localtimestamp", "current_timestamp":
+			expr.Name = sqlparser.NewIdentifierCI("current_timestamp")
+		}
+	}
+	return expr
+}
+
+// normalizeIndexOptions drops the index options that are spelled out with
+// their default value.
+func normalizeIndexOptions(index *sqlparser.IndexDefinition, engine string) {
+	var options []*sqlparser.IndexOption
+	for _, option := range index.Options {
+		option.Name = strings.ToLower(option.Name)
+		switch {
+		case option.Name == "visible":
+		case option.Name == "using" && strings.EqualFold(option.String, "btree") && engine == "InnoDB":
+		case option.Name == "comment" && option.Value != nil && option.Value.Val == "":
+		default:
+			options = append(options, option)
+		}
+	}
+	index.Options = options
+}
+
+// normalizeReferenceAction returns the action InnoDB shows for the given
+// foreign key action: RESTRICT and NO ACTION are the default.
+func normalizeReferenceAction(action sqlparser.ReferenceAction) sqlparser.ReferenceAction {
+	switch action {
+	case sqlparser.Restrict, sqlparser.NoAction:
+		return sqlparser.DefaultAction
+	}
+	return action
+}
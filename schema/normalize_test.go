@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func parseCreateTable(t *testing.T, sql string) *sqlparser.CreateTable {
+	t.Helper()
+	stmt, err := sqlparser.NewTestParser().ParseStrictDDL(sql)
+	require.NoError(t, err)
+	create, ok := stmt.(*sqlparser.CreateTable)
+	require.True(t, ok, sql)
+	return create
+}
+
+func TestNormalize(t *testing.T) {
+	testcases := []struct {
+		name    string
+		version string
+		sql     string
+		out     string
+	}{{
+		name:    "mysql 8.0",
+		version: "8.0.30",
+		sql:     "create table db.t (id integer(11) primary key auto_increment, flag boolean, n numeric, ts timestamp default now() on update localtimestamp, c char, b varchar(10) binary, j json, key (flag) using btree visible) auto_increment 5",
+		out:     "create table t (\n\tid int not null auto_increment,\n\tflag tinyint(1) default null,\n\tn decimal(10,0) default null,\n\tts timestamp null default current_timestamp() on update current_timestamp(),\n\tc char(1) default null,\n\tb varchar(10) character set utf8mb4 collate utf8mb4_bin default null,\n\tj json,\n\tprimary key (id),\n\tkey flag (flag)\n) engine InnoDB,\n  charset utf8mb4,\n  collate utf8mb4_0900_ai_ci",
+	}, {
+		name:    "mysql 5.7",
+		version: "5.7.44",
+		sql:     "create table t (a int, b int unsigned, c bigint zerofill, y year, check (a > 0)) engine=myisam default charset=utf8",
+		out:     "create table t (\n\ta int(11) default null,\n\tb int(10) unsigned default null,\n\tc bigint(20) unsigned zerofill default null,\n\ty year(4) default null\n) engine MyISAM,\n  charset utf8,\n  collate utf8_general_ci",
+	}, {
+		name:    "column charsets",
+		version: "8.0.30",
+		sql:     "create table t (a varchar(10) character set latin1, b text collate utf8mb4_0900_ai_ci, c char(3) character set binary, d enum('x', 'y') character set utf8) collate utf8mb4_bin",
+		out:     "create table t (\n\ta varchar(10) character set latin1 collate latin1_swedish_ci default null,\n\tb text character set utf8mb4 collate utf8mb4_0900_ai_ci,\n\tc binary(3) default null,\n\td enum('x', 'y') character set utf8mb3 collate utf8mb3_general_ci default null\n) engine InnoDB,\n  charset utf8mb4,\n  collate utf8mb4_bin",
+	}, {
+		name:    "defaults",
+		version: "8.0.30",
+		sql:     "create table t (a int not null default 0, b decimal(5,2) default -1.5, c tinyint(1) default true, d int default null, e int as (a + 1), f datetime(3) default localtime(3))",
+		out:     "create table t (\n\ta int not null default '0',\n\tb decimal(5,2) default '-1.5',\n\tc tinyint(1) default '1',\n\td int default null,\n\te int as (a + 1) virtual,\n\tf datetime(3) default current_timestamp(3)\n) engine InnoDB,\n  charset utf8mb4,\n  collate utf8mb4_0900_ai_ci",
+	}, {
+		name:    "foreign keys",
+		version: "8.0.30",
+		sql:     "create table t (id int, pid int, foreign key (pid) references p (id) on delete restrict on update no action)",
+		out:     "create table t (\n\tid int default null,\n\tpid int default null,\n\tkey pid (pid),\n\tconstraint t_ibfk_1 foreign key (pid) references p (id)\n) engine InnoDB,\n  charset utf8mb4,\n  collate utf8mb4_0900_ai_ci",
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			n, err := NewNormalizer(tcase.version)
+			require.NoError(t, err)
+			create := parseCreateTable(t, tcase.sql)
+			before := sqlparser.String(create)
+
+			normalized, err := n.Normalize(create)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.out, sqlparser.String(normalized))
+			assert.Equal(t, before, sqlparser.String(create), "Normalize must not modify its input")
+
+			again, err := n.Normalize(normalized)
+			require.NoError(t, err)
+			assert.True(t, sqlparser.Equals.RefOfCreateTable(normalized, again), "Normalize must be idempotent")
+		})
+	}
+}
+
+func TestNormalizerEqual(t *testing.T) {
+	testcases := []struct {
+		version string
+		a, b    string
+		equal   bool
+	}{{
+		version: "8.0.30",
+		a:       "create table t (id int(11) not null primary key, name varchar(50) null, active bool, unique index (name))",
+		b:       "CREATE TABLE `t` (`id` int NOT NULL, `name` varchar(50) DEFAULT NULL, `active` tinyint(1) DEFAULT NULL, PRIMARY KEY (`id`), UNIQUE KEY `name` (`name`)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci",
+		equal:   true,
+	}, {
+		version: "8.0.30",
+		a:       "create table t (a varchar(10) character set utf8mb4) default charset utf8mb4",
+		b:       "create table t (a varchar(10))",
+		equal:   true,
+	}, {
+		version: "8.0.30",
+		a:       "create table t (a int, check (a > 0))",
+		b:       "create table t (a int)",
+		equal:   false,
+	}, {
+		version: "5.7.44",
+		a:       "create table t (a int, check (a > 0))",
+		b:       "create table t (a int(11))",
+		equal:   true,
+	}, {
+		version: "8.0.30",
+		a:       "create table t (a int) charset latin1",
+		b:       "create table t (a int)",
+		equal:   false,
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.a, func(t *testing.T) {
+			n, err := NewNormalizer(tcase.version)
+			require.NoError(t, err)
+			equal, err := n.Equal(parseCreateTable(t, tcase.a), parseCreateTable(t, tcase.b))
+			require.NoError(t, err)
+			assert.Equal(t, tcase.equal, equal)
+		})
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	n, err := NewNormalizer("8.0.30")
+	require.NoError(t, err)
+
+	_, err = n.Normalize(parseCreateTable(t, "create table t like u"))
+	assert.EqualError(t, err, "unsupported statement: CREATE TABLE `t` LIKE `u`")
+	_, err = n.Normalize(parseCreateTable(t, "create table t (a int, key (b))"))
+	assert.EqualError(t, err, "Unknown column 'b' in table 't'")
+
+	_, err = NewNormalizer("x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,739 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// Comment versions of the MySQL releases that extended online DDL.
+const (
+	// instantVersion is the first version with ALGORITHM=INSTANT, which
+	// adds columns as the last column.
+	instantVersion = "80012"
+	// instantRenameVersion is the first version that renames columns
+	// instantly.
+	instantRenameVersion = "80028"
+	// instantColumnVersion is the first version that adds columns at any
+	// position and drops columns instantly.
+	instantColumnVersion = "80029"
+	// utf8mb4Version is the first version whose default character set is
+	// utf8mb4 rather than latin1.
+	utf8mb4Version = "80000"
+)
+
+// charsetMaxBytes holds the maximum length of a character in the multibyte
+// character sets. Other character sets use a byte per character.
+var charsetMaxBytes = map[string]int{
+	"utf8mb4": 4,
+	"utf8mb3": 3,
+	"utf8":    3,
+	"ucs2":    2,
+	"utf16":   4,
+	"utf16le": 4,
+	"utf32":   4,
+	"big5":    2,
+	"cp932":   2,
+	"eucjpms": 3,
+	"euckr":   2,
+	"gb18030": 4,
+	"gb2312":  2,
+	"gbk":     2,
+	"sjis":    2,
+	"ujis":    3,
+}
+
+// Algorithm is the algorithm MySQL uses to perform an ALTER TABLE, from the
+// cheapest to the most expensive.
+type Algorithm int8
+
+const (
+	// AlgorithmInstant only modifies the metadata in the data dictionary.
+	AlgorithmInstant Algorithm = iota
+	// AlgorithmInplace works on the table in place, possibly rebuilding it.
+	AlgorithmInplace
+	// AlgorithmCopy copies the rows into a new table.
+	AlgorithmCopy
+)
+
+// String returns the name of the algorithm as used in ALGORITHM=.
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmInstant:
+		return "INSTANT"
+	case AlgorithmInplace:
+		return "INPLACE"
+	case AlgorithmCopy:
+		return "COPY"
+	}
+	return fmt.Sprintf("Algorithm(%d)", int8(a))
+}
+
+// LockLevel is the lock an ALTER TABLE holds on the table while it runs,
+// from the least to the most restrictive.
+type LockLevel int8
+
+const (
+	// LockNone permits concurrent reads and writes.
+	LockNone LockLevel = iota
+	// LockShared permits concurrent reads and blocks writes.
+	LockShared
+	// LockExclusive blocks concurrent reads and writes.
+	LockExclusive
+)
+
+// String returns the name of the lock level as used in LOCK=.
+func (l LockLevel) String() string {
+	switch l {
+	case LockNone:
+		return "NONE"
+	case LockShared:
+		return "SHARED"
+	case LockExclusive:
+		return "EXCLUSIVE"
+	}
+	return fmt.Sprintf("LockLevel(%d)", int8(l))
+}
+
+// AlterOperation is the classification of one operation of an ALTER TABLE.
+type AlterOperation struct {
+	// Option is the operation: one of the sqlparser.AlterOption of the
+	// statement, or its *sqlparser.PartitionSpec or *sqlparser.PartitionOption.
+	Option sqlparser.SQLNode
+	// Algorithm is the cheapest algorithm supporting the operation.
+	Algorithm Algorithm
+	// Lock is the least restrictive lock the operation permits.
+	Lock LockLevel
+	// RebuildsTable is set if the operation rebuilds the table.
+	RebuildsTable bool
+	// Reason explains the classification.
+	Reason string
+
+	// rebuildsInplace is set if the operation is instant but rebuilds the
+	// table when the statement as a whole is performed in place.
+	rebuildsInplace bool
+}
+
+// AlterAnalysis is the classification of an ALTER TABLE statement.
+type AlterAnalysis struct {
+	// Operations holds the classification of every operation of the
+	// statement, in order.
+	Operations []*AlterOperation
+	// Algorithm, Lock and RebuildsTable apply to the statement as a whole:
+	// MySQL performs all operations with the most expensive algorithm and
+	// the most restrictive lock any of them requires.
+	Algorithm     Algorithm
+	Lock          LockLevel
+	RebuildsTable bool
+}
+
+// BlocksWrites returns whether the statement blocks concurrent writes.
+func (a *AlterAnalysis) BlocksWrites() bool {
+	return a.Lock != LockNone
+}
+
+// String returns a summary of the analysis, such as
+// "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table".
+func (a *AlterAnalysis) String() string {
+	summary := fmt.Sprintf("ALGORITHM=%s, LOCK=%s", a.Algorithm, a.Lock)
+	if a.RebuildsTable {
+		summary += ", rebuilds table"
+	}
+	return summary
+}
+
+// AnalyzeAlter classifies the operations of an ALTER TABLE on the given
+// table by the algorithm and lock MySQL 8.0 uses for them, following the
+// online DDL rules of the given server version, such as "8.0.30". It returns
+// an error if the statement refers to columns or keys the table does not
+// have.
+func AnalyzeAlter(version string, table *Table, alter *sqlparser.AlterTable) (*AlterAnalysis, error) {
+	if !alter.FullyParsed {
+		return nil, unsupportedStatement(alter)
+	}
+	version, err := sqlparser.ConvertMySQLVersionToCommentVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	a := &onlineAnalyzer{version: version, table: table, alter: alter}
+	analysis := &AlterAnalysis{}
+	add := func(op *AlterOperation) {
+		if op.Algorithm == AlgorithmInstant && a.version < instantVersion {
+			op.Algorithm = AlgorithmInplace
+		}
+		analysis.Operations = append(analysis.Operations, op)
+	}
+	for _, option := range alter.AlterOptions {
+		switch option.(type) {
+		case *sqlparser.LockOption, sqlparser.AlgorithmValue, *sqlparser.Validation:
+			// These clauses control how the other operations are performed.
+			continue
+		}
+		op, err := a.option(option)
+		if err != nil {
+			return nil, err
+		}
+		add(op)
+	}
+	if spec := alter.PartitionSpec; spec != nil {
+		op, err := a.partitionSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		add(op)
+	}
+	if option := alter.PartitionOption; option != nil {
+		add(copyOperation(option, "repartitioning the table"))
+	}
+
+	for _, op := range analysis.Operations {
+		analysis.Algorithm = max(analysis.Algorithm, op.Algorithm)
+		analysis.Lock = max(analysis.Lock, op.Lock)
+		analysis.RebuildsTable = analysis.RebuildsTable || op.RebuildsTable
+	}
+	if analysis.Algorithm != AlgorithmInstant {
+		for _, op := range analysis.Operations {
+			analysis.RebuildsTable = analysis.RebuildsTable || op.rebuildsInplace
+		}
+	}
+	if analysis.Algorithm == AlgorithmCopy {
+		analysis.Lock = max(analysis.Lock, LockShared)
+		analysis.RebuildsTable = true
+	}
+	return analysis, nil
+}
+
+// onlineAnalyzer classifies the operations of an ALTER TABLE.
+type onlineAnalyzer struct {
+	version string
+	table   *Table
+	alter   *sqlparser.AlterTable
+}
+
+// instantOperation returns an operation that only changes metadata.
+func instantOperation(option sqlparser.SQLNode, reason string) *AlterOperation {
+	return &AlterOperation{Option: option, Algorithm: AlgorithmInstant, Lock: LockNone, Reason: reason}
+}
+
+// inplaceOperation returns an operation performed in place.
+func inplaceOperation(option sqlparser.SQLNode, lock LockLevel, rebuild bool, reason string) *AlterOperation {
+	return &AlterOperation{Option: option, Algorithm: AlgorithmInplace, Lock: lock, RebuildsTable: rebuild, Reason: reason}
+}
+
+// copyOperation returns an operation that copies the table.
+func copyOperation(option sqlparser.SQLNode, reason string) *AlterOperation {
+	return &AlterOperation{Option: option, Algorithm: AlgorithmCopy, Lock: LockShared, RebuildsTable: true, Reason: reason}
+}
+
+// merge combines the classification of another part of the same operation.
+func (op *AlterOperation) merge(other *AlterOperation) {
+	op.Algorithm = max(op.Algorithm, other.Algorithm)
+	op.Lock = max(op.Lock, other.Lock)
+	op.RebuildsTable = op.RebuildsTable || other.RebuildsTable
+	op.rebuildsInplace = op.rebuildsInplace || other.rebuildsInplace
+	switch {
+	case op.Reason == "":
+		op.Reason = other.Reason
+	case other.Reason != "" && !strings.Contains(op.Reason, other.Reason):
+		op.Reason += "; " + other.Reason
+	}
+}
+
+func (a *onlineAnalyzer) option(option sqlparser.AlterOption) (*AlterOperation, error) {
+	t := a.table
+	switch option := option.(type) {
+	case *sqlparser.AddColumns:
+		return a.addColumns(option)
+	case *sqlparser.DropColumn:
+		return a.dropColumn(option)
+	case *sqlparser.RenameColumn:
+		if t.Column(option.OldName.Name.String()) == nil {
+			return nil, &UnknownColumnError{Table: t.Name(), Column: option.OldName.Name.String()}
+		}
+		return a.renameColumn(option), nil
+	case *sqlparser.AlterColumn:
+		if t.Column(option.Column.Name.String()) == nil {
+			return nil, &UnknownColumnError{Table: t.Name(), Column: option.Column.Name.String()}
+		}
+		if option.Invisible != nil {
+			return instantOperation(option, "changing the column visibility"), nil
+		}
+		return instantOperation(option, "changing the column default"), nil
+	case *sqlparser.ChangeColumn:
+		return a.changeColumn(option, option.OldColumn.Name.String(), option.NewColDefinition, option.First, option.After)
+	case *sqlparser.ModifyColumn:
+		return a.changeColumn(option, option.NewColDefinition.Name.String(), option.NewColDefinition, option.First, option.After)
+	case *sqlparser.AddIndexDefinition:
+		return a.addIndex(option, option.IndexDefinition.Info.Type), nil
+	case *sqlparser.DropKey:
+		return a.dropKey(option)
+	case *sqlparser.RenameIndex:
+		if t.Index(option.OldName.String()) == nil {
+			return nil, &UnknownKeyError{Table: t.Name(), Key: option.OldName.String()}
+		}
+		return instantOperation(option, "renaming an index"), nil
+	case *sqlparser.AlterIndex:
+		if t.Index(option.Name.String()) == nil {
+			return nil, &UnknownKeyError{Table: t.Name(), Key: option.Name.String()}
+		}
+		return instantOperation(option, "changing the index visibility"), nil
+	case *sqlparser.AddConstraintDefinition:
+		switch details := option.ConstraintDefinition.Details.(type) {
+		case *sqlparser.ForeignKeyDefinition:
+			return copyOperation(option, "adding a foreign key with foreign_key_checks enabled"), nil
+		case *sqlparser.CheckConstraintDefinition:
+			if !details.Enforced {
+				return instantOperation(option, "adding an unenforced check constraint"), nil
+			}
+			return copyOperation(option, "adding a check constraint validates all rows"), nil
+		}
+	case *sqlparser.AlterCheck:
+		constraint := t.Constraint(option.Name.String())
+		if constraint == nil {
+			return nil, &UnknownKeyError{Table: t.Name(), Key: option.Name.String()}
+		}
+		if _, ok := constraint.Details.(*sqlparser.CheckConstraintDefinition); !ok {
+			return nil, &UnknownKeyError{Table: t.Name(), Key: option.Name.String()}
+		}
+		if option.Enforced {
+			return copyOperation(option, "enforcing a check constraint validates all rows"), nil
+		}
+		return instantOperation(option, "no longer enforcing a check constraint"), nil
+	case *sqlparser.AlterCharset:
+		return copyOperation(option, "converting the character set"), nil
+	case sqlparser.TableOptions:
+		return a.tableOptions(option), nil
+	case *sqlparser.RenameTableName:
+		return instantOperation(option, "renaming the table"), nil
+	case *sqlparser.Force:
+		return inplaceOperation(option, LockNone, true, "rebuilding the table"), nil
+	case *sqlparser.OrderByOption:
+		return copyOperation(option, "ordering the rows"), nil
+	case *sqlparser.KeyState:
+		return inplaceOperation(option, LockNone, false, "InnoDB ignores ENABLE and DISABLE KEYS"), nil
+	case *sqlparser.TablespaceOperation:
+		return inplaceOperation(option, LockExclusive, false, "discarding or importing the tablespace"), nil
+	}
+	return nil, &UnsupportedAlterOptionError{Table: t.Name(), Option: sqlparser.String(option)}
+}
+
+func (a *onlineAnalyzer) addColumns(option *sqlparser.AddColumns) (*AlterOperation, error) {
+	t := a.table
+	if option.After != nil && t.Column(option.After.Name.String()) == nil {
+		return nil, &UnknownColumnError{Table: t.Name(), Column: option.After.Name.String()}
+	}
+	last := len(t.Columns()) - 1
+	atEnd := !option.First && (option.After == nil || (last >= 0 && t.Columns()[last].Name.Equal(option.After.Name)))
+
+	op := &AlterOperation{Option: option}
+	for _, col := range option.Columns {
+		if t.Column(col.Name.String()) != nil {
+			return nil, &DuplicateColumnError{Table: t.Name(), Column: col.Name.String()}
+		}
+		opts := col.Type.Options
+		switch {
+		case opts != nil && opts.As != nil && opts.Storage == sqlparser.StoredStorage:
+			op.merge(copyOperation(option, "adding a stored generated column"))
+		case opts != nil && opts.As != nil:
+			op.merge(instantOperation(option, "adding a virtual generated column"))
+		case opts != nil && opts.Autoincrement:
+			op.merge(inplaceOperation(option, LockShared, true, "adding an auto-increment column"))
+		default:
+			if reason := a.noInstantColumns(); reason != "" {
+				op.merge(inplaceOperation(option, LockNone, true, "adding a column; "+reason))
+			} else if a.version < instantColumnVersion && !atEnd {
+				op.merge(inplaceOperation(option, LockNone, true, "adding a column other than the last before MySQL 8.0.29"))
+			} else {
+				added := instantOperation(option, "adding a column")
+				added.rebuildsInplace = true
+				op.merge(added)
+			}
+		}
+		if opts != nil {
+			switch opts.KeyOpt {
+			case sqlparser.ColKeyPrimary, sqlparser.ColKey:
+				op.merge(a.addIndex(option, sqlparser.IndexTypePrimary))
+			case sqlparser.ColKeyUnique, sqlparser.ColKeyUniqueKey:
+				op.merge(a.addIndex(option, sqlparser.IndexTypeUnique))
+			case sqlparser.ColKeySpatialKey:
+				op.merge(a.addIndex(option, sqlparser.IndexTypeSpatial))
+			case sqlparser.ColKeyFulltextKey:
+				op.merge(a.addIndex(option, sqlparser.IndexTypeFullText))
+			}
+		}
+	}
+	return op, nil
+}
+
+func (a *onlineAnalyzer) dropColumn(option *sqlparser.DropColumn) (*AlterOperation, error) {
+	t := a.table
+	col := t.Column(option.Name.Name.String())
+	if col == nil {
+		return nil, &UnknownColumnError{Table: t.Name(), Column: option.Name.Name.String()}
+	}
+	opts := col.Type.Options
+	switch {
+	case opts != nil && opts.As != nil && opts.Storage == sqlparser.StoredStorage:
+		return inplaceOperation(option, LockNone, true, "dropping a stored generated column"), nil
+	case opts != nil && opts.As != nil:
+		return instantOperation(option, "dropping a virtual generated column"), nil
+	}
+	if reason := a.noInstantColumns(); reason != "" {
+		return inplaceOperation(option, LockNone, true, "dropping a column; "+reason), nil
+	}
+	if a.version < instantColumnVersion {
+		return inplaceOperation(option, LockNone, true, "dropping a column before MySQL 8.0.29"), nil
+	}
+	op := instantOperation(option, "dropping a column")
+	op.rebuildsInplace = true
+	return op, nil
+}
+
+// noInstantColumns returns why columns of the table cannot be added or
+// dropped instantly, or an empty string if they can.
+func (a *onlineAnalyzer) noInstantColumns() string {
+	for _, index := range a.table.Indexes() {
+		if index.Info.Type == sqlparser.IndexTypeFullText {
+			return "the table has a FULLTEXT index"
+		}
+	}
+	for _, option := range a.table.Options() {
+		if strings.EqualFold(option.Name, "row_format") && strings.EqualFold(option.String, "compressed") {
+			return "the table uses ROW_FORMAT=COMPRESSED"
+		}
+	}
+	return ""
+}
+
+func (a *onlineAnalyzer) renameColumn(option sqlparser.AlterOption) *AlterOperation {
+	if a.version < instantRenameVersion {
+		return inplaceOperation(option, LockNone, false, "renaming a column")
+	}
+	return instantOperation(option, "renaming a column")
+}
+
+// changeColumn classifies CHANGE COLUMN and MODIFY COLUMN by comparing the
+// new definition of the column with the current one.
+func (a *onlineAnalyzer) changeColumn(option sqlparser.AlterOption, name string, def *sqlparser.ColumnDefinition, first bool, after *sqlparser.ColName) (*AlterOperation, error) {
+	t := a.table
+	i := t.columnIndex(name)
+	if i < 0 {
+		return nil, &UnknownColumnError{Table: t.Name(), Column: name}
+	}
+	old := t.Columns()[i]
+	if after != nil && t.Column(after.Name.String()) == nil {
+		return nil, &UnknownColumnError{Table: t.Name(), Column: after.Name.String()}
+	}
+
+	op := instantOperation(option, "")
+	oldType, newType := old.Type, def.Type
+	oldOpts, newOpts := columnOptions(oldType), columnOptions(newType)
+
+	if !sqlparser.Equals.Expr(oldOpts.As, newOpts.As) || (oldOpts.As != nil && oldOpts.Storage != newOpts.Storage) {
+		op.merge(copyOperation(option, "changing a generated column"))
+	}
+	if !a.sameType(old, def) {
+		op.merge(a.changeType(option, old, def))
+	}
+	if oldOpts.Autoincrement != newOpts.Autoincrement {
+		op.merge(copyOperation(option, "changing the AUTO_INCREMENT attribute"))
+	}
+
+	pk := t.PrimaryKey()
+	inPK := pk != nil && indexHasColumn(pk, old.Name.String())
+	oldNullable := oldOpts.Null == nil || *oldOpts.Null
+	newNullable := !inPK && (newOpts.Null == nil || *newOpts.Null)
+	if oldNullable != newNullable {
+		op.merge(inplaceOperation(option, LockNone, true, "changing the column nullability"))
+	}
+
+	pos := i
+	if first {
+		pos = 0
+	} else if after != nil {
+		pos = t.columnIndex(after.Name.String())
+		if pos < i {
+			pos++
+		}
+	}
+	if pos != i {
+		op.merge(inplaceOperation(option, LockNone, true, "reordering columns"))
+	}
+
+	if !old.Name.Equal(def.Name) {
+		op.merge(a.renameColumn(option))
+	}
+	if !sqlparser.Equals.Expr(oldOpts.Default, newOpts.Default) || !sqlparser.Equals.Expr(oldOpts.OnUpdate, newOpts.OnUpdate) {
+		op.merge(instantOperation(option, "changing the column default"))
+	}
+	if (oldOpts.Invisible != nil && *oldOpts.Invisible) != (newOpts.Invisible != nil && *newOpts.Invisible) {
+		op.merge(instantOperation(option, "changing the column visibility"))
+	}
+	if !sqlparser.Equals.RefOfLiteral(oldOpts.Comment, newOpts.Comment) {
+		op.merge(instantOperation(option, "changing the column comment"))
+	}
+	if op.Reason == "" {
+		op.Reason = "the column definition is unchanged"
+	}
+	return op, nil
+}
+
+// changeType classifies changing the data type of a column.
+func (a *onlineAnalyzer) changeType(option sqlparser.AlterOption, old, def *sqlparser.ColumnDefinition) *AlterOperation {
+	oldType, newType := old.Type, def.Type
+	oldName, newName := typeName(oldType), typeName(newType)
+	sameCharset := a.columnCharset(oldType) == a.columnCharset(newType) &&
+		strings.EqualFold(columnOptions(oldType).Collate, columnOptions(newType).Collate)
+
+	switch {
+	case oldName == "varchar" && newName == "varchar" && sameCharset && oldType.Length != nil && newType.Length != nil:
+		if *newType.Length < *oldType.Length {
+			return copyOperation(option, "shortening a VARCHAR column")
+		}
+		// The length of a VARCHAR value is stored in one byte up to 255 bytes
+		// and in two bytes beyond, so crossing that boundary rewrites all rows.
+		maxBytes := charsetMaxBytes[a.columnCharset(oldType)]
+		if maxBytes == 0 {
+			maxBytes = 1
+		}
+		if (*oldType.Length*maxBytes < 256) != (*newType.Length*maxBytes < 256) {
+			return copyOperation(option, "extending a VARCHAR column across the 255 byte length boundary")
+		}
+		return inplaceOperation(option, LockNone, false, "extending a VARCHAR column")
+	case (oldName == "enum" || oldName == "set") && oldName == newName && sameCharset:
+		if appendsMembers(oldType.EnumValues, newType.EnumValues) && memberStorage(oldName, len(oldType.EnumValues)) == memberStorage(newName, len(newType.EnumValues)) {
+			return instantOperation(option, "adding members at the end of an ENUM or SET column")
+		}
+	}
+	return copyOperation(option, "changing the column data type")
+}
+
+// sameType returns whether two column definitions have the same data type,
+// including the character set and collation.
+func (a *onlineAnalyzer) sameType(old, def *sqlparser.ColumnDefinition) bool {
+	oldType, newType := old.Type, def.Type
+	return typeName(oldType) == typeName(newType) &&
+		equalInt(oldType.Length, newType.Length) &&
+		equalInt(oldType.Scale, newType.Scale) &&
+		oldType.Unsigned == newType.Unsigned &&
+		oldType.Zerofill == newType.Zerofill &&
+		oldType.Charset.Binary == newType.Charset.Binary &&
+		a.columnCharset(oldType) == a.columnCharset(newType) &&
+		strings.EqualFold(columnOptions(oldType).Collate, columnOptions(newType).Collate) &&
+		slices.Equal(oldType.EnumValues, newType.EnumValues)
+}
+
+// columnCharset returns the character set of a character column.
+func (a *onlineAnalyzer) columnCharset(ct *sqlparser.ColumnType) string {
+	switch {
+	case !isCharacterType(typeName(ct)):
+		return ""
+	case ct.Charset.Name != "":
+		return strings.ToLower(ct.Charset.Name)
+	case columnOptions(ct).Collate != "":
+		return collationCharset(strings.ToLower(columnOptions(ct).Collate))
+	}
+	var charset, collation string
+	for _, option := range a.table.Options() {
+		switch strings.ToLower(option.Name) {
+		case "charset":
+			charset = strings.ToLower(option.String)
+		case "collate":
+			collation = strings.ToLower(option.String)
+		}
+	}
+	switch {
+	case charset != "":
+		return charset
+	case collation != "":
+		return collationCharset(collation)
+	case a.version < utf8mb4Version:
+		return "latin1"
+	}
+	return "utf8mb4"
+}
+
+func (a *onlineAnalyzer) addIndex(option sqlparser.AlterOption, typ sqlparser.IndexType) *AlterOperation {
+	switch typ {
+	case sqlparser.IndexTypePrimary:
+		return inplaceOperation(option, LockNone, true, "adding a primary key")
+	case sqlparser.IndexTypeFullText:
+		for _, index := range a.table.Indexes() {
+			if index.Info.Type == sqlparser.IndexTypeFullText {
+				return inplaceOperation(option, LockShared, false, "adding a FULLTEXT index")
+			}
+		}
+		return inplaceOperation(option, LockShared, true, "adding the first FULLTEXT index")
+	case sqlparser.IndexTypeSpatial:
+		return inplaceOperation(option, LockShared, false, "adding a SPATIAL index")
+	}
+	return inplaceOperation(option, LockNone, false, "adding a secondary index")
+}
+
+func (a *onlineAnalyzer) dropKey(option *sqlparser.DropKey) (*AlterOperation, error) {
+	t := a.table
+	name := option.Name.String()
+	switch option.Type {
+	case sqlparser.PrimaryKeyType:
+		if t.PrimaryKey() == nil {
+			return nil, &UnknownKeyError{Table: t.Name(), Key: PrimaryKeyName}
+		}
+		if a.addsPrimaryKey() {
+			return inplaceOperation(option, LockNone, true, "replacing the primary key"), nil
+		}
+		return copyOperation(option, "dropping the primary key without adding a new one"), nil
+	case sqlparser.NormalKeyType:
+		if t.Index(name) != nil {
+			return inplaceOperation(option, LockNone, false, "dropping a secondary index"), nil
+		}
+	case sqlparser.ForeignKeyType, sqlparser.CheckKeyType:
+		if constraint := t.Constraint(name); constraint != nil {
+			if _, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition); ok {
+				return inplaceOperation(option, LockNone, false, "dropping a foreign key"), nil
+			}
+			if option.Type == sqlparser.CheckKeyType {
+				return instantOperation(option, "dropping a check constraint"), nil
+			}
+		}
+		if index := t.Index(name); option.Type == sqlparser.CheckKeyType && index != nil && index.Info.Type == sqlparser.IndexTypeUnique {
+			return inplaceOperation(option, LockNone, false, "dropping a secondary index"), nil
+		}
+	}
+	return nil, &UnknownKeyError{Table: t.Name(), Key: name}
+}
+
+// addsPrimaryKey returns whether the statement adds a primary key.
+func (a *onlineAnalyzer) addsPrimaryKey() bool {
+	for _, option := range a.alter.AlterOptions {
+		switch option := option.(type) {
+		case *sqlparser.AddIndexDefinition:
+			if option.IndexDefinition.Info.Type == sqlparser.IndexTypePrimary {
+				return true
+			}
+		case *sqlparser.AddColumns:
+			for _, col := range option.Columns {
+				if opts := col.Type.Options; opts != nil && (opts.KeyOpt == sqlparser.ColKeyPrimary || opts.KeyOpt == sqlparser.ColKey) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (a *onlineAnalyzer) tableOptions(options sqlparser.TableOptions) *AlterOperation {
+	op := instantOperation(options, "")
+	for _, option := range options {
+		switch strings.ToLower(option.Name) {
+		case "engine":
+			engine := "innodb"
+			for _, current := range a.table.Options() {
+				if strings.EqualFold(current.Name, "engine") {
+					engine = strings.ToLower(current.String)
+				}
+			}
+			if strings.EqualFold(option.String, engine) {
+				op.merge(inplaceOperation(options, LockNone, true, "rebuilding the table with the same storage engine"))
+			} else {
+				op.merge(copyOperation(options, "changing the storage engine"))
+			}
+		case "auto_increment":
+			op.merge(inplaceOperation(options, LockNone, false, "changing the auto-increment value"))
+		case "comment":
+			op.merge(instantOperation(options, "changing the table comment"))
+		case "charset", "collate":
+			op.merge(inplaceOperation(options, LockShared, true, "changing the default character set"))
+		case "stats_persistent", "stats_auto_recalc", "stats_sample_pages":
+			op.merge(inplaceOperation(options, LockNone, false, "changing the table statistics options"))
+		default:
+			op.merge(inplaceOperation(options, LockNone, true, fmt.Sprintf("changing the %s option", strings.ToUpper(option.Name))))
+		}
+	}
+	return op
+}
+
+func (a *onlineAnalyzer) partitionSpec(spec *sqlparser.PartitionSpec) (*AlterOperation, error) {
+	t := a.table
+	partitions := t.Partitioning()
+	if spec.Action != sqlparser.RemoveAction && spec.Action != sqlparser.UpgradeAction && partitions == nil {
+		return nil, &NotPartitionedError{Table: t.Name()}
+	}
+	switch spec.Action {
+	case sqlparser.AddAction:
+		if partitions.Type == sqlparser.RangeType || partitions.Type == sqlparser.ListType {
+			return inplaceOperation(spec, LockNone, false, "adding a RANGE or LIST partition"), nil
+		}
+		return inplaceOperation(spec, LockShared, true, "adding a HASH or KEY partition redistributes the rows"), nil
+	case sqlparser.CoalesceAction, sqlparser.ReorganizeAction, sqlparser.RebuildAction:
+		return inplaceOperation(spec, LockShared, true, "copying the rows of the affected partitions"), nil
+	case sqlparser.DropAction, sqlparser.TruncateAction, sqlparser.DiscardAction, sqlparser.ImportAction, sqlparser.ExchangeAction:
+		return inplaceOperation(spec, LockExclusive, false, "changing partitions without copying rows"), nil
+	case sqlparser.AnalyzeAction, sqlparser.CheckAction, sqlparser.OptimizeAction, sqlparser.RepairAction:
+		return inplaceOperation(spec, LockShared, spec.Action == sqlparser.OptimizeAction, "maintaining partitions"), nil
+	case sqlparser.RemoveAction:
+		return copyOperation(spec, "removing partitioning"), nil
+	}
+	return inplaceOperation(spec, LockNone, false, "upgrading partitioning"), nil
+}
+
+// columnOptions returns the options of a column type, which may be nil.
+func columnOptions(ct *sqlparser.ColumnType) *sqlparser.ColumnTypeOptions {
+	if ct.Options == nil {
+		return &sqlparser.ColumnTypeOptions{}
+	}
+	return ct.Options
+}
+
+// typeName returns the lowercase type name of a column, resolving synonyms.
+func typeName(ct *sqlparser.ColumnType) string {
+	name := strings.ToLower(ct.Type)
+	if alias, ok := typeAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// appendsMembers returns whether the members of an ENUM or SET are the old
+// ones followed by at least one new member.
+func appendsMembers(old, members []string) bool {
+	return len(members) > len(old) && slices.Equal(old, members[:len(old)])
+}
+
+// memberStorage returns the number of bytes an ENUM or SET with the given
+// number of members uses.
+func memberStorage(typ string, members int) int {
+	if typ == "enum" {
+		if members <= 255 {
+			return 1
+		}
+		return 2
+	}
+	switch bytes := (members + 7) / 8; {
+	case bytes > 4:
+		return 8
+	default:
+		return bytes
+	}
+}
+
+func equalInt(a, b *int) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestAnalyzeAlter(t *testing.T) {
+	testcases := []struct {
+		name       string
+		version    string
+		create     string
+		alter      string
+		operations []string
+		summary    string
+	}{{
+		name:       "add column at the end",
+		version:    "8.0.20",
+		create:     "create table t (id int primary key, a int)",
+		alter:      "alter table t add column b int",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE"},
+		summary:    "ALGORITHM=INSTANT, LOCK=NONE",
+	}, {
+		name:       "add column after the last column",
+		version:    "8.0.20",
+		create:     "create table t (id int primary key, a int)",
+		alter:      "alter table t add column b int after a",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE"},
+		summary:    "ALGORITHM=INSTANT, LOCK=NONE",
+	}, {
+		name:       "add column first before 8.0.29",
+		version:    "8.0.20",
+		create:     "create table t (id int primary key, a int)",
+		alter:      "alter table t add column b int first",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE, rebuilds table"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table",
+	}, {
+		name:       "add column first since 8.0.29",
+		version:    "8.0.30",
+		create:     "create table t (id int primary key, a int)",
+		alter:      "alter table t add column b int first, drop column a",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=INSTANT, LOCK=NONE"},
+		summary:    "ALGORITHM=INSTANT, LOCK=NONE",
+	}, {
+		name:       "instant column combined with an index",
+		version:    "8.0.30",
+		create:     "create table t (id int primary key, a int)",
+		alter:      "alter table t add column b int, add index a (a)",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=INPLACE, LOCK=NONE"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table",
+	}, {
+		name:       "add column to a table with a fulltext index",
+		version:    "8.0.30",
+		create:     "create table t (id int primary key, doc text, fulltext key doc (doc))",
+		alter:      "alter table t add column b int",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE, rebuilds table"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table",
+	}, {
+		name:       "generated and auto-increment columns",
+		version:    "8.0.30",
+		create:     "create table t (a int)",
+		alter:      "alter table t add column v int as (a + 1) virtual, add column s int as (a + 1) stored, add column id int auto_increment primary key",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=COPY, LOCK=SHARED, rebuilds table", "ALGORITHM=INPLACE, LOCK=SHARED, rebuilds table"},
+		summary:    "ALGORITHM=COPY, LOCK=SHARED, rebuilds table",
+	}, {
+		name:       "widen varchar within the length byte",
+		version:    "8.0.30",
+		create:     "create table t (a varchar(10)) charset latin1",
+		alter:      "alter table t modify column a varchar(255)",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE",
+	}, {
+		name:       "widen varchar across the 255 byte boundary",
+		version:    "8.0.30",
+		create:     "create table t (a varchar(60))",
+		alter:      "alter table t modify column a varchar(64)",
+		operations: []string{"ALGORITHM=COPY, LOCK=SHARED, rebuilds table"},
+		summary:    "ALGORITHM=COPY, LOCK=SHARED, rebuilds table",
+	}, {
+		name:       "widen varchar within the length byte of the 5.7 default character set",
+		version:    "5.7.40",
+		create:     "create table t (a varchar(100))",
+		alter:      "alter table t modify column a varchar(200)",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE",
+	}, {
+		name:       "change column type",
+		version:    "8.0.30",
+		create:     "create table t (a int, b varchar(10))",
+		alter:      "alter table t modify column a bigint, modify column b varchar(5)",
+		operations: []string{"ALGORITHM=COPY, LOCK=SHARED, rebuilds table", "ALGORITHM=COPY, LOCK=SHARED, rebuilds table"},
+		summary:    "ALGORITHM=COPY, LOCK=SHARED, rebuilds table",
+	}, {
+		name:       "column metadata",
+		version:    "8.0.30",
+		create:     "create table t (a int, b enum('x', 'y'), c int)",
+		alter:      "alter table t change column a aa int default 5, modify column b enum('x', 'y', 'z'), alter column c set invisible",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=INSTANT, LOCK=NONE"},
+		summary:    "ALGORITHM=INSTANT, LOCK=NONE",
+	}, {
+		name:       "nullability and order",
+		version:    "8.0.30",
+		create:     "create table t (a int, b int)",
+		alter:      "alter table t modify column a int not null, modify column b int first",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE, rebuilds table", "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table",
+	}, {
+		name:       "rename column before 8.0.28",
+		version:    "8.0.20",
+		create:     "create table t (a int)",
+		alter:      "alter table t rename column a to b",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE",
+	}, {
+		name:       "indexes",
+		version:    "8.0.30",
+		create:     "create table t (id int, a int, doc text, key a (a), key b (id))",
+		alter:      "alter table t drop index a, add unique key u (a), rename index b to c, add fulltext key doc (doc)",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE", "ALGORITHM=INPLACE, LOCK=NONE", "ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=INPLACE, LOCK=SHARED, rebuilds table"},
+		summary:    "ALGORITHM=INPLACE, LOCK=SHARED, rebuilds table",
+	}, {
+		name:       "primary keys",
+		version:    "8.0.30",
+		create:     "create table t (id int primary key, a int not null)",
+		alter:      "alter table t drop primary key, add primary key (id, a)",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE, rebuilds table", "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table",
+	}, {
+		name:       "drop primary key",
+		version:    "8.0.30",
+		create:     "create table t (id int primary key)",
+		alter:      "alter table t drop primary key",
+		operations: []string{"ALGORITHM=COPY, LOCK=SHARED, rebuilds table"},
+		summary:    "ALGORITHM=COPY, LOCK=SHARED, rebuilds table",
+	}, {
+		name:       "constraints",
+		version:    "8.0.30",
+		create:     "create table t (id int, pid int, constraint c check (id > 0), constraint fk foreign key (pid) references p (id))",
+		alter:      "alter table t drop check c, drop foreign key fk, add foreign key (pid) references q (id)",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=INPLACE, LOCK=NONE", "ALGORITHM=COPY, LOCK=SHARED, rebuilds table"},
+		summary:    "ALGORITHM=COPY, LOCK=SHARED, rebuilds table",
+	}, {
+		name:       "table options",
+		version:    "8.0.30",
+		create:     "create table t (id int) engine InnoDB",
+		alter:      "alter table t comment 'x', auto_increment 100, rename to u",
+		operations: []string{"ALGORITHM=INSTANT, LOCK=NONE", "ALGORITHM=INPLACE, LOCK=NONE", "ALGORITHM=INSTANT, LOCK=NONE"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE",
+	}, {
+		name:       "engine",
+		version:    "8.0.30",
+		create:     "create table t (id int) engine InnoDB",
+		alter:      "alter table t engine MyISAM",
+		operations: []string{"ALGORITHM=COPY, LOCK=SHARED, rebuilds table"},
+		summary:    "ALGORITHM=COPY, LOCK=SHARED, rebuilds table",
+	}, {
+		name:       "null rebuild",
+		version:    "8.0.30",
+		create:     "create table t (id int)",
+		alter:      "alter table t engine innodb, algorithm = inplace, lock = none",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE, rebuilds table"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE, rebuilds table",
+	}, {
+		name:       "partitions",
+		version:    "8.0.30",
+		create:     "create table t (id int) partition by range (id) (partition p0 values less than (10))",
+		alter:      "alter table t drop partition p0",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=EXCLUSIVE"},
+		summary:    "ALGORITHM=INPLACE, LOCK=EXCLUSIVE",
+	}, {
+		name:       "mysql 5.7 has no instant operations",
+		version:    "5.7.44",
+		create:     "create table t (a int, key a (a))",
+		alter:      "alter table t rename index a to b",
+		operations: []string{"ALGORITHM=INPLACE, LOCK=NONE"},
+		summary:    "ALGORITHM=INPLACE, LOCK=NONE",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			s, err := NewFromSQL(parser, tcase.create)
+			require.NoError(t, err)
+			stmt, err := parser.ParseStrictDDL(tcase.alter)
+			require.NoError(t, err)
+
+			analysis, err := AnalyzeAlter(tcase.version, s.Database("").Table("t"), stmt.(*sqlparser.AlterTable))
+			require.NoError(t, err)
+			var operations []string
+			for _, op := range analysis.Operations {
+				summary := (&AlterAnalysis{Algorithm: op.Algorithm, Lock: op.Lock, RebuildsTable: op.RebuildsTable}).String()
+				operations = append(operations, summary)
+				assert.NotEmpty(t, op.Reason)
+			}
+			assert.Equal(t, tcase.operations, operations)
+			assert.Equal(t, tcase.summary, analysis.String())
+		})
+	}
+}
+
+func TestAnalyzeAlterReasons(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, "create table t (a varchar(60))")
+	require.NoError(t, err)
+	stmt, err := parser.ParseStrictDDL("alter table t modify column a varchar(64) not null first")
+	require.NoError(t, err)
+
+	analysis, err := AnalyzeAlter("8.0.30", s.Database("").Table("t"), stmt.(*sqlparser.AlterTable))
+	require.NoError(t, err)
+	require.Len(t, analysis.Operations, 1)
+	assert.Equal(t, "extending a VARCHAR column across the 255 byte length boundary; changing the column nullability", analysis.Operations[0].Reason)
+	assert.True(t, analysis.BlocksWrites())
+}
+
+func TestAnalyzeAlterErrors(t *testing.T) {
+	testcases := []struct {
+		alter string
+		err   string
+	}{{
+		alter: "alter table t drop column x",
+		err:   "Unknown column 'x' in table 't'",
+	}, {
+		alter: "alter table t modify column x int",
+		err:   "Unknown column 'x' in table 't'",
+	}, {
+		alter: "alter table t add column a int",
+		err:   "Duplicate column name 'a' in table 't'",
+	}, {
+		alter: "alter table t drop index x",
+		err:   "Can't DROP 'x'; check that column/key exists in table 't'",
+	}, {
+		alter: "alter table t drop partition p0",
+		err:   "Partition management on a not partitioned table 't' is not possible",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, "create table t (a int)")
+	require.NoError(t, err)
+	for _, tcase := range testcases {
+		t.Run(tcase.alter, func(t *testing.T) {
+			stmt, err := parser.ParseStrictDDL(tcase.alter)
+			require.NoError(t, err)
+			_, err = AnalyzeAlter("8.0.30", s.Database("").Table("t"), stmt.(*sqlparser.AlterTable))
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
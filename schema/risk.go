@@ -0,0 +1,393 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/mysql/config"
+)
+
+// integerRanks orders the integer types by size.
+var integerRanks = map[string]int{"tinyint": 1, "smallint": 2, "mediumint": 3, "int": 4, "bigint": 5}
+
+// textRanks orders the TEXT and BLOB types by size.
+var textRanks = map[string]int{
+	"tinytext": 1, "text": 2, "mediumtext": 3, "longtext": 4,
+	"tinyblob": 1, "blob": 2, "mediumblob": 3, "longblob": 4,
+}
+
+// textLengths are the maximum lengths of TEXT and BLOB types by rank.
+var textLengths = []int64{1: 255, 2: 65535, 3: 16777215, 4: 4294967295}
+
+// Severity is the severity of a finding of CheckMigration.
+type Severity int8
+
+const (
+	// SeverityInfo marks operations worth knowing about.
+	SeverityInfo Severity = iota
+	// SeverityWarning marks operations that may break clients, block writes
+	// or fail on existing data.
+	SeverityWarning
+	// SeverityCritical marks operations that destroy data or fail.
+	SeverityCritical
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	}
+	return fmt.Sprintf("Severity(%d)", int8(s))
+}
+
+// Finding is a risky operation found by CheckMigration.
+type Finding struct {
+	Severity Severity
+	// Statement is the statement of the migration the finding is about.
+	Statement sqlparser.Statement
+	// Node is the offending node within the statement, or the statement
+	// itself.
+	Node sqlparser.SQLNode
+	// Message explains the risk.
+	Message string
+}
+
+// String returns the finding as "severity: message".
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+}
+
+// CheckMigration returns the risky operations of the given migration
+// statements, in order.
+//
+// The schema holds the definitions from before the migration and may be nil.
+// Without it, only the statements themselves are checked. With it, the
+// statements are checked against the definitions they change, which finds
+// narrowed column types, dropped unique keys, columns made NOT NULL and
+// foreign keys to missing tables. The schema is not modified; an error is
+// returned if a statement cannot be applied to it.
+func CheckMigration(s *Schema, stmts ...sqlparser.Statement) ([]*Finding, error) {
+	c := &riskChecker{}
+	if s != nil {
+		c.schema = s.Clone()
+	}
+	for _, stmt := range stmts {
+		c.stmt = stmt
+		c.check(stmt)
+		if c.schema != nil {
+			if err := c.schema.Apply(stmt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c.findings, nil
+}
+
+// riskChecker collects the findings of CheckMigration.
+type riskChecker struct {
+	schema   *Schema
+	stmt     sqlparser.Statement
+	findings []*Finding
+}
+
+func (c *riskChecker) report(severity Severity, node sqlparser.SQLNode, format string, args ...any) {
+	c.findings = append(c.findings, &Finding{
+		Severity:  severity,
+		Statement: c.stmt,
+		Node:      node,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *riskChecker) check(stmt sqlparser.Statement) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.DropDatabase:
+		c.report(SeverityCritical, stmt, "drops database %s and all its tables", stmt.DBName.String())
+	case *sqlparser.DropTable:
+		for _, name := range stmt.FromTables {
+			c.report(SeverityCritical, name, "drops table %s and all its rows", sqlparser.String(name))
+			c.checkReferencingTables(stmt, name, stmt.FromTables)
+		}
+	case *sqlparser.TruncateTable:
+		c.report(SeverityCritical, stmt, "deletes all rows of table %s", sqlparser.String(stmt.Table))
+	case *sqlparser.DropView:
+		for _, name := range stmt.FromTables {
+			c.report(SeverityInfo, name, "drops view %s", sqlparser.String(name))
+		}
+	case *sqlparser.RenameTable:
+		for _, pair := range stmt.TablePairs {
+			c.report(SeverityWarning, stmt, "renames table %s to %s: RENAME TABLE has no IF EXISTS guard and breaks clients using the old name",
+				sqlparser.String(pair.FromTable), sqlparser.String(pair.ToTable))
+		}
+	case *sqlparser.CreateTable:
+		if stmt.TableSpec != nil {
+			for _, constraint := range stmt.TableSpec.Constraints {
+				c.checkForeignKey(stmt.Table, constraint)
+			}
+		}
+	case *sqlparser.AlterTable:
+		c.checkAlter(stmt)
+	}
+}
+
+func (c *riskChecker) checkAlter(alter *sqlparser.AlterTable) {
+	name := sqlparser.String(alter.Table)
+	table := c.table(alter.Table)
+	for _, option := range alter.AlterOptions {
+		switch option := option.(type) {
+		case *sqlparser.DropColumn:
+			c.report(SeverityCritical, option, "drops column %s.%s and its contents", name, option.Name.Name.String())
+		case *sqlparser.AddColumns:
+			for _, col := range option.Columns {
+				if opts := col.Type.Options; opts != nil && opts.Null != nil && !*opts.Null && opts.Default == nil && opts.As == nil && !opts.Autoincrement {
+					c.report(SeverityWarning, col, "adds NOT NULL column %s.%s without a default: existing rows get the implicit default of its type", name, col.Name.String())
+				}
+			}
+		case *sqlparser.ModifyColumn:
+			c.checkColumnChange(name, table, option, option.NewColDefinition.Name.String(), option.NewColDefinition)
+		case *sqlparser.ChangeColumn:
+			c.checkColumnChange(name, table, option, option.OldColumn.Name.String(), option.NewColDefinition)
+			if !option.OldColumn.Name.Equal(option.NewColDefinition.Name) {
+				c.report(SeverityWarning, option, "renames column %s.%s to %s, which breaks clients using the old name", name, option.OldColumn.Name.String(), option.NewColDefinition.Name.String())
+			}
+		case *sqlparser.RenameColumn:
+			c.report(SeverityWarning, option, "renames column %s.%s to %s, which breaks clients using the old name", name, option.OldName.Name.String(), option.NewName.Name.String())
+		case *sqlparser.RenameTableName:
+			c.report(SeverityWarning, option, "renames table %s to %s, which breaks clients using the old name", name, sqlparser.String(option.Table))
+		case *sqlparser.DropKey:
+			c.checkDropKey(name, table, option)
+		case *sqlparser.AlterCharset:
+			c.report(SeverityWarning, option, "converts table %s to character set %s, which rebuilds the table and blocks writes", name, option.CharacterSet)
+		case *sqlparser.AddConstraintDefinition:
+			c.checkForeignKey(alter.Table, option.ConstraintDefinition)
+		}
+	}
+	if spec := alter.PartitionSpec; spec != nil {
+		switch spec.Action {
+		case sqlparser.DropAction:
+			c.report(SeverityCritical, spec, "drops partitions %s of table %s and their rows", partitionNames(spec), name)
+		case sqlparser.TruncateAction:
+			c.report(SeverityCritical, spec, "deletes all rows of partitions %s of table %s", partitionNames(spec), name)
+		}
+	}
+}
+
+// checkColumnChange checks MODIFY COLUMN and CHANGE COLUMN.
+func (c *riskChecker) checkColumnChange(name string, table *Table, option sqlparser.AlterOption, column string, def *sqlparser.ColumnDefinition) {
+	newOpts := columnOptions(def.Type)
+	notNull := newOpts.Null != nil && !*newOpts.Null && newOpts.Default == nil
+	if table == nil {
+		if notNull {
+			c.report(SeverityWarning, option, "makes column %s.%s NOT NULL without a default, which fails if it holds NULL values", name, column)
+		}
+		return
+	}
+	old := table.Column(column)
+	if old == nil {
+		return
+	}
+	oldOpts := columnOptions(old.Type)
+	if notNull && (oldOpts.Null == nil || *oldOpts.Null) {
+		c.report(SeverityWarning, option, "makes column %s.%s NOT NULL without a default, which fails if it holds NULL values", name, column)
+	}
+	if reason := narrowing(old.Type, def.Type); reason != "" {
+		c.report(SeverityCritical, option, "narrows column %s.%s: %s, which may truncate or reject existing values", name, column, reason)
+	}
+	// Columns without a character set have the default of the default
+	// server version.
+	version, _ := sqlparser.ConvertMySQLVersionToCommentVersion(config.DefaultMySQLVersion)
+	a := &onlineAnalyzer{version: version, table: table}
+	if oldCharset, newCharset := a.columnCharset(old.Type), a.columnCharset(def.Type); oldCharset != "" && newCharset != "" && oldCharset != newCharset {
+		c.report(SeverityWarning, option, "changes the character set of column %s.%s from %s to %s, which rebuilds the table and blocks writes", name, column, oldCharset, newCharset)
+	}
+}
+
+// narrowing returns how the new type of a column holds fewer values than the
+// old one, or an empty string if it does not.
+func narrowing(old, def *sqlparser.ColumnType) string {
+	oldName, newName := typeName(old), typeName(def)
+	describe := func(ct *sqlparser.ColumnType) string {
+		ct = sqlparser.CloneRefOfColumnType(ct)
+		ct.Options = nil
+		return sqlparser.String(ct)
+	}
+	change := fmt.Sprintf("%s to %s", describe(old), describe(def))
+	// length returns the maximum length of a string type, comparing
+	// characters and bytes alike.
+	length := func(ct *sqlparser.ColumnType) int64 {
+		if rank := textRanks[typeName(ct)]; rank > 0 {
+			return textLengths[rank]
+		}
+		if ct.Length == nil {
+			return 1
+		}
+		return int64(*ct.Length)
+	}
+
+	switch {
+	case integerRanks[oldName] > 0 && integerRanks[newName] > 0:
+		oldRank, newRank := integerRanks[oldName], integerRanks[newName]
+		switch {
+		case !old.Unsigned && def.Unsigned:
+			return change
+		case old.Unsigned && !def.Unsigned:
+			// Only a larger signed type holds the values of an unsigned one.
+			if newRank <= oldRank {
+				return change
+			}
+		case newRank < oldRank:
+			return change
+		}
+	case oldName == newName && (oldName == "enum" || oldName == "set"):
+		for _, member := range old.EnumValues {
+			if !slices.Contains(def.EnumValues, member) {
+				return fmt.Sprintf("%s drops member %s", change, member)
+			}
+		}
+	case isStringType(oldName) && isStringType(newName),
+		oldName == "bit" && newName == "bit":
+		if length(def) < length(old) {
+			return change
+		}
+	case oldName == "decimal" && newName == "decimal":
+		oldLength, oldScale := ptrValue(old.Length, 10), ptrValue(old.Scale, 0)
+		newLength, newScale := ptrValue(def.Length, 10), ptrValue(def.Scale, 0)
+		// Signed and unsigned decimals have the same digits, and only
+		// signed ones hold negative values.
+		if newScale < oldScale || newLength-newScale < oldLength-oldScale || !old.Unsigned && def.Unsigned {
+			return change
+		}
+	case oldName == newName && (oldName == "datetime" || oldName == "timestamp" || oldName == "time"):
+		if ptrValue(def.Length, 0) < ptrValue(old.Length, 0) {
+			return change
+		}
+	case oldName == "double" && newName == "float":
+		return change
+	case oldName != newName && !(oldName == "float" && newName == "double"):
+		return change
+	}
+	return ""
+}
+
+// isStringType returns whether values of the given type are strings.
+func isStringType(typ string) bool {
+	return isCharacterType(typ) || textRanks[typ] > 0 || typ == "binary" || typ == "varbinary"
+}
+
+func ptrValue(value *int, def int) int {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+func (c *riskChecker) checkDropKey(name string, table *Table, option *sqlparser.DropKey) {
+	switch option.Type {
+	case sqlparser.PrimaryKeyType:
+		c.report(SeverityWarning, option, "drops the primary key of table %s, which rebuilds the table and no longer enforces uniqueness", name)
+		return
+	case sqlparser.NormalKeyType, sqlparser.CheckKeyType:
+	default:
+		return
+	}
+	if table == nil {
+		return
+	}
+	if index := table.Index(option.Name.String()); index != nil && index.Info.Type == sqlparser.IndexTypeUnique {
+		c.report(SeverityWarning, option, "drops unique key %s of table %s, which no longer enforces uniqueness", index.Info.Name.String(), name)
+	}
+}
+
+// checkForeignKey reports a foreign key that references a missing table or
+// missing columns.
+func (c *riskChecker) checkForeignKey(table sqlparser.TableName, constraint *sqlparser.ConstraintDefinition) {
+	fk, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition)
+	if !ok || c.schema == nil {
+		return
+	}
+	ref := fk.ReferenceDefinition
+	target := ref.ReferencedTable
+	if target.Qualifier.IsEmpty() {
+		target.Qualifier = table.Qualifier
+	}
+	if sqlparser.Equals.TableName(target, table) {
+		return
+	}
+	parent := c.table(target)
+	if parent == nil {
+		c.report(SeverityCritical, constraint, "foreign key of table %s references missing table %s", sqlparser.String(table), sqlparser.String(ref.ReferencedTable))
+		return
+	}
+	var missing []string
+	for _, col := range ref.ReferencedColumns {
+		if parent.Column(col.String()) == nil {
+			missing = append(missing, col.String())
+		}
+	}
+	if len(missing) > 0 {
+		c.report(SeverityCritical, constraint, "foreign key of table %s references missing columns %s of table %s", sqlparser.String(table), strings.Join(missing, ", "), sqlparser.String(ref.ReferencedTable))
+	}
+}
+
+// checkReferencingTables reports the foreign keys of other tables that
+// reference a dropped table, which make DROP TABLE fail.
+func (c *riskChecker) checkReferencingTables(stmt *sqlparser.DropTable, name sqlparser.TableName, dropped sqlparser.TableNames) {
+	if c.schema == nil || c.table(name) == nil {
+		return
+	}
+	db, _ := c.schema.qualifier(name)
+	target := tableKey{db, name.Name.String()}
+	isDropped := func(key tableKey) bool {
+		for _, other := range dropped {
+			if otherDB, err := c.schema.qualifier(other); err == nil && (tableKey{otherDB, other.Name.String()}) == key {
+				return true
+			}
+		}
+		return false
+	}
+	for _, database := range c.schema.Databases() {
+		for _, child := range database.Tables() {
+			key := tableKey{database.name, child.Name()}
+			if isDropped(key) {
+				continue
+			}
+			for _, fk := range child.ForeignKeys() {
+				ref := fk.Details.(*sqlparser.ForeignKeyDefinition).ReferenceDefinition
+				if key.resolve(ref.ReferencedTable) == target {
+					c.report(SeverityCritical, stmt, "table %s is referenced by foreign key %s of table %s", sqlparser.String(name), fk.Name.String(), sqlparser.String(key.tableName()))
+				}
+			}
+		}
+	}
+}
+
+// table returns the table with the given name from the schema, or nil.
+func (c *riskChecker) table(name sqlparser.TableName) *Table {
+	if c.schema == nil {
+		return nil
+	}
+	return c.schema.Table(name)
+}
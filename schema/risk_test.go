@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func parseStatements(t *testing.T, sql string) []sqlparser.Statement {
+	t.Helper()
+	parser := sqlparser.NewTestParser()
+	pieces, err := parser.SplitStatementToPieces(sql)
+	require.NoError(t, err)
+	var stmts []sqlparser.Statement
+	for _, piece := range pieces {
+		stmt, err := parser.ParseStrictDDL(piece)
+		require.NoError(t, err)
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+func TestCheckMigrationStatements(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		findings []string
+	}{{
+		sql:      "drop table t, u",
+		findings: []string{"critical: drops table t and all its rows", "critical: drops table u and all its rows"},
+	}, {
+		sql:      "truncate table t",
+		findings: []string{"critical: deletes all rows of table t"},
+	}, {
+		sql:      "drop database shop",
+		findings: []string{"critical: drops database shop and all its tables"},
+	}, {
+		sql:      "rename table a to b",
+		findings: []string{"warning: renames table a to b: RENAME TABLE has no IF EXISTS guard and breaks clients using the old name"},
+	}, {
+		sql: "alter table t drop column a, add column b int not null, add column c int not null default 0, modify column d int not null",
+		findings: []string{
+			"critical: drops column t.a and its contents",
+			"warning: adds NOT NULL column t.b without a default: existing rows get the implicit default of its type",
+			"warning: makes column t.d NOT NULL without a default, which fails if it holds NULL values",
+		},
+	}, {
+		sql:      "alter table t convert to character set utf8mb4, drop primary key",
+		findings: []string{"warning: converts table t to character set utf8mb4, which rebuilds the table and blocks writes", "warning: drops the primary key of table t, which rebuilds the table and no longer enforces uniqueness"},
+	}, {
+		sql:      "alter table t drop partition p0",
+		findings: []string{"critical: drops partitions p0 of table t and their rows"},
+	}, {
+		sql:      "alter table t truncate partition all",
+		findings: []string{"critical: deletes all rows of partitions all of table t"},
+	}, {
+		sql: "create table t (id int); alter table t add column a int; drop view v",
+		findings: []string{
+			"info: drops view v",
+		},
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmts := parseStatements(t, tcase.sql)
+			findings, err := CheckMigration(nil, stmts...)
+			require.NoError(t, err)
+			var messages []string
+			for _, finding := range findings {
+				messages = append(messages, finding.String())
+				assert.NotNil(t, finding.Node)
+				assert.Contains(t, stmts, finding.Statement)
+			}
+			assert.Equal(t, tcase.findings, messages)
+		})
+	}
+}
+
+func TestCheckMigrationWithSchema(t *testing.T) {
+	testcases := []struct {
+		name     string
+		sql      string
+		findings []string
+	}{{
+		name: "narrowing",
+		sql:  "alter table t modify column id int, modify column name varchar(50), modify column amount decimal(8,2), modify column kind enum('a'), modify column body text",
+		findings: []string{
+			"critical: narrows column t.id: bigint to int, which may truncate or reject existing values",
+			"critical: narrows column t.name: varchar(255) to varchar(50), which may truncate or reject existing values",
+			"critical: narrows column t.amount: decimal(10,2) to decimal(8,2), which may truncate or reject existing values",
+			"critical: narrows column t.kind: enum('a', 'b') to enum('a') drops member 'b', which may truncate or reject existing values",
+		},
+	}, {
+		name: "narrowing across string types",
+		sql:  "alter table t modify column name char(10), modify column note text, modify column body varchar(100)",
+		findings: []string{
+			"critical: narrows column t.name: varchar(255) to char(10), which may truncate or reject existing values",
+			"critical: narrows column t.body: text to varchar(100), which may truncate or reject existing values",
+		},
+	}, {
+		name:     "widening",
+		sql:      "alter table t modify column id bigint unsigned not null, modify column name varchar(300), modify column amount decimal(12,2)",
+		findings: []string{"critical: narrows column t.id: bigint to bigint unsigned, which may truncate or reject existing values"},
+	}, {
+		name:     "widening unsigned columns",
+		sql:      "alter table t modify column hits bigint, modify column price decimal(10,2)",
+		findings: nil,
+	}, {
+		name: "narrowing unsigned columns",
+		sql:  "alter table t modify column hits int, modify column price decimal(4,2)",
+		findings: []string{
+			"critical: narrows column t.hits: int unsigned to int, which may truncate or reject existing values",
+			"critical: narrows column t.price: decimal(5,2) unsigned to decimal(4,2), which may truncate or reject existing values",
+		},
+	}, {
+		name: "not null and charset",
+		sql:  "alter table t modify column name varchar(255) not null, change column note note varchar(10) character set latin1",
+		findings: []string{
+			"warning: makes column t.name NOT NULL without a default, which fails if it holds NULL values",
+			"warning: changes the character set of column t.note from utf8mb4 to latin1, which rebuilds the table and blocks writes",
+		},
+	}, {
+		name:     "dropped unique key",
+		sql:      "alter table t drop index name, drop index body",
+		findings: []string{"warning: drops unique key name of table t, which no longer enforces uniqueness"},
+	}, {
+		name: "foreign keys",
+		sql:  "create table c (id int, tid bigint, pid int, foreign key (tid) references t (id), foreign key (pid) references missing (id)); alter table c add foreign key (tid) references t (nope)",
+		findings: []string{
+			"critical: foreign key of table c references missing table missing",
+			"critical: foreign key of table c references missing columns nope of table t",
+		},
+	}, {
+		name: "referenced table",
+		sql:  "create table c (tid bigint, foreign key (tid) references t (id)); drop table t",
+		findings: []string{
+			"critical: drops table t and all its rows",
+			"critical: table t is referenced by foreign key c_ibfk_1 of table c",
+		},
+	}}
+
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, "create table t (id bigint primary key, name varchar(255), note varchar(10), amount decimal(10,2), kind enum('a', 'b'), body text, hits int unsigned, price decimal(5,2) unsigned, unique key name (name), key body (body(10)))")
+	require.NoError(t, err)
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			findings, err := CheckMigration(s, parseStatements(t, tcase.sql)...)
+			require.NoError(t, err)
+			var messages []string
+			for _, finding := range findings {
+				messages = append(messages, finding.String())
+			}
+			assert.Equal(t, tcase.findings, messages)
+		})
+	}
+
+	_, err = CheckMigration(s, parseStatements(t, "alter table missing drop column a")...)
+	assert.EqualError(t, err, "Table '.missing' doesn't exist")
+}
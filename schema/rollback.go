@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// Rollback holds the statements that undo a migration.
+type Rollback struct {
+	// Statements restore the schema from before the migration when applied
+	// in order to the migrated schema.
+	Statements []sqlparser.Statement
+	// DataLoss flags the data the rollback does not restore or destroys.
+	DataLoss []*DataLoss
+}
+
+// DataLoss flags data that is lost when a migration is rolled back.
+type DataLoss struct {
+	// Statement is the rollback statement that loses the data, or the
+	// migration statement whose changes to the data cannot be undone, such
+	// as TRUNCATE TABLE.
+	Statement sqlparser.Statement
+	// Reason describes the data that is lost.
+	Reason string
+}
+
+// Rollback returns the statements that undo the given migration statements
+// on the schema. The schema holds the definitions from before the migration
+// and is not modified.
+//
+// Renamed tables, columns and indexes are renamed back. All other changes
+// are undone by restoring the previous definitions, so a dropped column or
+// table comes back with its full definition but without its contents, which
+// Rollback flags as data loss.
+func (s *Schema) Rollback(stmts ...sqlparser.Statement) (*Rollback, error) {
+	state := s.Clone()
+	var steps [][]sqlparser.Statement
+	rollback := &Rollback{}
+	for _, stmt := range stmts {
+		before := state.Clone()
+		if err := state.Apply(stmt); err != nil {
+			return nil, err
+		}
+		if truncate, ok := stmt.(*sqlparser.TruncateTable); ok {
+			rollback.DataLoss = append(rollback.DataLoss, &DataLoss{
+				Statement: stmt,
+				Reason:    fmt.Sprintf("the rows of table %s deleted by TRUNCATE TABLE cannot be restored", sqlparser.String(truncate.Table)),
+			})
+		}
+
+		undo := state.Clone()
+		renames, err := renamesBack(before, stmt)
+		if err != nil {
+			return nil, err
+		}
+		step := renames
+		for _, rename := range renames {
+			if err := undo.Apply(rename); err != nil {
+				return nil, err
+			}
+		}
+		steps = append(steps, append(step, Diff(undo, before)...))
+	}
+
+	// The migration is undone statement by statement, from the last one.
+	for i := len(steps) - 1; i >= 0; i-- {
+		for _, stmt := range steps[i] {
+			for _, reason := range dataLoss(state, stmt) {
+				rollback.DataLoss = append(rollback.DataLoss, &DataLoss{Statement: stmt, Reason: reason})
+			}
+			if err := state.Apply(stmt); err != nil {
+				return nil, err
+			}
+			rollback.Statements = append(rollback.Statements, stmt)
+		}
+	}
+	return rollback, nil
+}
+
+// RollbackAlter returns the statements that undo an ALTER TABLE on the table
+// with the given definition.
+func RollbackAlter(create *sqlparser.CreateTable, alter *sqlparser.AlterTable) (*Rollback, error) {
+	s := New()
+	create = sqlparser.CloneRefOfCreateTable(create)
+	create.Table = sqlparser.TableName{Name: create.Table.Name, Qualifier: alter.Table.Qualifier}
+	if db := alter.Table.Qualifier; db.NotEmpty() {
+		if err := s.Apply(&sqlparser.CreateDatabase{DBName: db, FullyParsed: true}); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.Apply(create); err != nil {
+		return nil, err
+	}
+	return s.Rollback(alter)
+}
+
+// renamesBack returns the statements that revert the renames of tables,
+// columns and indexes done by a statement applied to the given schema.
+func renamesBack(before *Schema, stmt sqlparser.Statement) ([]sqlparser.Statement, error) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.RenameTable:
+		inverse := &sqlparser.RenameTable{}
+		for i := len(stmt.TablePairs) - 1; i >= 0; i-- {
+			pair := stmt.TablePairs[i]
+			inverse.TablePairs = append(inverse.TablePairs, &sqlparser.RenameTablePair{FromTable: pair.ToTable, ToTable: pair.FromTable})
+		}
+		return []sqlparser.Statement{inverse}, nil
+	case *sqlparser.AlterTable:
+		db, err := before.qualifier(stmt.Table)
+		if err != nil {
+			return nil, err
+		}
+		table := tableKey{db, stmt.Table.Name.String()}.tableName()
+		var options []sqlparser.AlterOption
+		var rename *sqlparser.RenameTableName
+		for i := len(stmt.AlterOptions) - 1; i >= 0; i-- {
+			switch option := stmt.AlterOptions[i].(type) {
+			case *sqlparser.RenameColumn:
+				options = append(options, &sqlparser.RenameColumn{OldName: option.NewName, NewName: option.OldName})
+			case *sqlparser.ChangeColumn:
+				if !option.OldColumn.Name.Equal(option.NewColDefinition.Name) {
+					options = append(options, &sqlparser.RenameColumn{
+						OldName: &sqlparser.ColName{Name: option.NewColDefinition.Name},
+						NewName: option.OldColumn,
+					})
+				}
+			case *sqlparser.RenameIndex:
+				options = append(options, &sqlparser.RenameIndex{OldName: option.NewName, NewName: option.OldName})
+			case *sqlparser.RenameTableName:
+				if rename == nil {
+					target, err := before.qualifier(option.Table)
+					if err != nil {
+						return nil, err
+					}
+					rename = &sqlparser.RenameTableName{Table: table}
+					table = tableKey{target, option.Table.Name.String()}.tableName()
+				}
+			}
+		}
+		if rename != nil {
+			options = append(options, rename)
+		}
+		if len(options) == 0 {
+			return nil, nil
+		}
+		return []sqlparser.Statement{&sqlparser.AlterTable{Table: table, AlterOptions: options, FullyParsed: true}}, nil
+	}
+	return nil, nil
+}
+
+// dataLoss returns the data lost by applying a rollback statement to the
+// given schema.
+func dataLoss(s *Schema, stmt sqlparser.Statement) []string {
+	var reasons []string
+	switch stmt := stmt.(type) {
+	case *sqlparser.DropTable:
+		for _, name := range stmt.FromTables {
+			reasons = append(reasons, fmt.Sprintf("drops table %s and the rows written to it since the migration", sqlparser.String(name)))
+		}
+	case *sqlparser.CreateTable:
+		reasons = append(reasons, fmt.Sprintf("restores table %s without the rows it had before the migration", sqlparser.String(stmt.Table)))
+	case *sqlparser.DropDatabase:
+		reasons = append(reasons, fmt.Sprintf("drops database %s and the rows of its tables", stmt.DBName.String()))
+	case *sqlparser.AlterTable:
+		name := sqlparser.String(stmt.Table)
+		table := s.Table(stmt.Table)
+		for _, option := range stmt.AlterOptions {
+			switch option := option.(type) {
+			case *sqlparser.DropColumn:
+				reasons = append(reasons, fmt.Sprintf("drops column %s.%s and its contents", name, option.Name.Name.String()))
+			case *sqlparser.AddColumns:
+				for _, col := range option.Columns {
+					reasons = append(reasons, fmt.Sprintf("restores column %s.%s without the contents it had before the migration", name, col.Name.String()))
+				}
+			case *sqlparser.ModifyColumn:
+				if table == nil {
+					continue
+				}
+				if old := table.Column(option.NewColDefinition.Name.String()); old != nil && typeChanged(old.Type, option.NewColDefinition.Type) {
+					typ := sqlparser.CloneRefOfColumnType(option.NewColDefinition.Type)
+					typ.Options = nil
+					reasons = append(reasons, fmt.Sprintf("converts column %s.%s back to %s, which may truncate the values written since the migration",
+						name, old.Name.String(), sqlparser.String(typ)))
+				}
+			}
+		}
+		if spec := stmt.PartitionSpec; spec != nil {
+			switch spec.Action {
+			case sqlparser.DropAction:
+				reasons = append(reasons, fmt.Sprintf("drops partitions %s of table %s and their rows", partitionNames(spec), name))
+			case sqlparser.AddAction:
+				for _, definition := range spec.Definitions {
+					reasons = append(reasons, fmt.Sprintf("restores partition %s of table %s without the rows it had before the migration", definition.Name.String(), name))
+				}
+			}
+		}
+	}
+	return reasons
+}
+
+// typeChanged returns whether two column types differ in anything but their
+// options, such as the default or nullability.
+func typeChanged(a, b *sqlparser.ColumnType) bool {
+	a, b = sqlparser.CloneRefOfColumnType(a), sqlparser.CloneRefOfColumnType(b)
+	a.Options, b.Options = nil, nil
+	return !sqlparser.Equals.RefOfColumnType(a, b)
+}
+
+// partitionNames returns the comma separated partitions named by a partition
+// spec.
+func partitionNames(spec *sqlparser.PartitionSpec) string {
+	if spec.IsAll {
+		return "all"
+	}
+	names := make([]string, 0, len(spec.Names))
+	for _, name := range spec.Names {
+		names = append(names, name.String())
+	}
+	return strings.Join(names, ", ")
+}
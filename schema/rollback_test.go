@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestRollback(t *testing.T) {
+	testcases := []struct {
+		name      string
+		schema    string
+		migration string
+		rollback  []string
+		dataLoss  []string
+	}{{
+		name:      "add column",
+		schema:    "create table t (id int primary key)",
+		migration: "alter table t add column a int, add key a (a)",
+		rollback:  []string{"alter table t drop key a, drop column a"},
+		dataLoss:  []string{"drops column t.a and its contents"},
+	}, {
+		name:      "drop column",
+		schema:    "create table t (id int primary key, a varchar(10) not null default 'x' comment 'note', b int, key ab (a, b))",
+		migration: "alter table t drop column a",
+		rollback:  []string{"alter table t drop key ab, add column a varchar(10) not null default 'x' comment 'note' after id, add key ab (a, b)"},
+		dataLoss:  []string{"restores column t.a without the contents it had before the migration"},
+	}, {
+		name:      "renames",
+		schema:    "create table t (id int primary key, a int, key a (a))",
+		migration: "alter table t rename column a to b, rename index a to b, rename to u",
+		rollback:  []string{"alter table u rename index b to a, rename column b to a, rename t"},
+	}, {
+		name:      "change column",
+		schema:    "create table t (a int not null)",
+		migration: "alter table t change column a b bigint",
+		rollback:  []string{"alter table t rename column b to a", "alter table t modify column a int not null"},
+		dataLoss:  []string{"converts column t.a back to int, which may truncate the values written since the migration"},
+	}, {
+		name:      "rename tables",
+		schema:    "create table a (x int); create table b (x int)",
+		migration: "rename table a to tmp, b to a, tmp to b",
+		rollback:  []string{"rename table b to tmp, a to b, tmp to a"},
+	}, {
+		name:      "create and drop tables",
+		schema:    "create table p (id int primary key); create table c (id int, pid int, foreign key (pid) references p (id))",
+		migration: "drop table c, p; create table n (id int)",
+		rollback: []string{
+			"drop table n",
+			"create table p (\n\tid int not null,\n\tprimary key (id)\n)",
+			"create table c (\n\tid int,\n\tpid int,\n\tkey pid (pid),\n\tconstraint c_ibfk_1 foreign key (pid) references p (id)\n)",
+		},
+		dataLoss: []string{
+			"drops table n and the rows written to it since the migration",
+			"restores table p without the rows it had before the migration",
+			"restores table c without the rows it had before the migration",
+		},
+	}, {
+		name:      "partitions",
+		schema:    "create table t (id int) partition by range (id) (partition p0 values less than (10), partition p1 values less than (20))",
+		migration: "alter table t drop partition p1",
+		rollback:  []string{"alter table t add partition (partition p1 values less than (20))"},
+		dataLoss:  []string{"restores partition p1 of table t without the rows it had before the migration"},
+	}, {
+		name:      "truncate",
+		schema:    "create table t (id int)",
+		migration: "truncate table t",
+		dataLoss:  []string{"the rows of table t deleted by TRUNCATE TABLE cannot be restored"},
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			before, err := NewFromSQL(parser, tcase.schema)
+			require.NoError(t, err)
+			var migration []sqlparser.Statement
+			pieces, err := parser.SplitStatementToPieces(tcase.migration)
+			require.NoError(t, err)
+			for _, piece := range pieces {
+				stmt, err := parser.ParseStrictDDL(piece)
+				require.NoError(t, err)
+				migration = append(migration, stmt)
+			}
+
+			rollback, err := before.Rollback(migration...)
+			require.NoError(t, err)
+			var stmts []string
+			for _, stmt := range rollback.Statements {
+				stmts = append(stmts, sqlparser.String(stmt))
+			}
+			assert.Equal(t, tcase.rollback, stmts)
+			var dataLoss []string
+			for _, loss := range rollback.DataLoss {
+				dataLoss = append(dataLoss, loss.Reason)
+			}
+			assert.Equal(t, tcase.dataLoss, dataLoss)
+
+			// Migrating and rolling back must restore the schema.
+			after := before.Clone()
+			for _, stmt := range append(migration, rollback.Statements...) {
+				require.NoError(t, after.Apply(stmt), sqlparser.String(stmt))
+			}
+			assert.Empty(t, Diff(after, before))
+		})
+	}
+}
+
+func TestRollbackAlter(t *testing.T) {
+	create := parseCreateTable(t, "create table t (id int primary key, name varchar(50))")
+	stmt, err := sqlparser.NewTestParser().ParseStrictDDL("alter table shop.t modify column name varchar(100) not null, add unique key name (name)")
+	require.NoError(t, err)
+
+	rollback, err := RollbackAlter(create, stmt.(*sqlparser.AlterTable))
+	require.NoError(t, err)
+	require.Len(t, rollback.Statements, 1)
+	assert.Equal(t, "alter table shop.t drop key `name`, modify column `name` varchar(50)", sqlparser.String(rollback.Statements[0]))
+	require.Len(t, rollback.DataLoss, 1)
+	assert.Equal(t, "converts column shop.t.name back to varchar(50), which may truncate the values written since the migration", rollback.DataLoss[0].Reason)
+}
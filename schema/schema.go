@@ -0,0 +1,569 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema maintains an in-memory model of databases, tables and views
+// by applying parsed DDL statements in order, the way a MySQL server would.
+//
+// Definitions are kept as sqlparser AST nodes. Applying a CREATE TABLE
+// normalizes its definition: column-level keys are moved to the index list,
+// unnamed indexes and constraints get the names MySQL would give them and
+// foreign keys get a supporting index. ALTER TABLE statements are validated
+// and applied atomically: if any of the options fails, the table is left
+// unchanged.
+package schema
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// Schema is a set of databases. The zero value is not usable; use New.
+type Schema struct {
+	databases map[string]*Database
+	// current is the database used for unqualified names, and selected is
+	// false once the current database has been dropped.
+	current  string
+	selected bool
+}
+
+// Database holds the tables and views of a single database.
+type Database struct {
+	name    string
+	options []sqlparser.DatabaseOption
+	tables  map[string]*Table
+	views   map[string]*View
+}
+
+// View is a view definition.
+type View struct {
+	create *sqlparser.CreateView
+}
+
+// New returns an empty schema. Unqualified names refer to an implicit
+// database with an empty name until a USE statement selects another one.
+func New() *Schema {
+	return &Schema{
+		databases: map[string]*Database{},
+		selected:  true,
+	}
+}
+
+// NewFromSQL returns a schema built by applying the given statements.
+func NewFromSQL(parser *sqlparser.Parser, sql string) (*Schema, error) {
+	s := New()
+	if err := s.ApplySQL(parser, sql); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ApplySQL parses the given statements and applies them in order. It stops at
+// the first statement that fails to parse or apply.
+func (s *Schema) ApplySQL(parser *sqlparser.Parser, sql string) error {
+	pieces, err := parser.SplitStatementToPieces(sql)
+	if err != nil {
+		return err
+	}
+	for _, piece := range pieces {
+		stmt, err := parser.ParseStrictDDL(piece)
+		if err != nil {
+			return err
+		}
+		if err := s.Apply(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply applies a single statement to the schema. Statements that do not
+// change the schema, such as DML, are ignored, as are statements on
+// temporary tables. DDL statements the model cannot represent return an
+// UnsupportedStatementError.
+func (s *Schema) Apply(stmt sqlparser.Statement) error {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Use:
+		return s.use(stmt.DBName.String())
+	case *sqlparser.CreateDatabase:
+		return s.createDatabase(stmt)
+	case *sqlparser.AlterDatabase:
+		return s.alterDatabase(stmt)
+	case *sqlparser.DropDatabase:
+		return s.dropDatabase(stmt)
+	case *sqlparser.CreateTable:
+		if stmt.Temp {
+			return nil
+		}
+		return s.createTable(stmt)
+	case *sqlparser.AlterTable:
+		return s.alterTable(stmt)
+	case *sqlparser.DropTable:
+		if stmt.Temp {
+			return nil
+		}
+		return s.dropTables(stmt)
+	case *sqlparser.RenameTable:
+		return s.renameTables(stmt)
+	case *sqlparser.TruncateTable:
+		_, _, err := s.table(stmt.Table)
+		return err
+	case *sqlparser.CreateView:
+		return s.createView(stmt)
+	case *sqlparser.AlterView:
+		return s.alterView(stmt)
+	case *sqlparser.DropView:
+		return s.dropViews(stmt)
+	case sqlparser.DDLStatement, sqlparser.DBDDLStatement:
+		return unsupportedStatement(stmt)
+	}
+	return nil
+}
+
+// Databases returns the databases of the schema sorted by name.
+func (s *Schema) Databases() []*Database {
+	dbs := make([]*Database, 0, len(s.databases))
+	for _, db := range s.databases {
+		dbs = append(dbs, db)
+	}
+	sort.Slice(dbs, func(i, j int) bool {
+		return dbs[i].name < dbs[j].name
+	})
+	return dbs
+}
+
+// Database returns the database with the given name, or nil.
+func (s *Schema) Database(name string) *Database {
+	return s.databases[name]
+}
+
+// CurrentDatabase returns the name of the database used for unqualified
+// names, and false if no database is selected.
+func (s *Schema) CurrentDatabase() (string, bool) {
+	return s.current, s.selected
+}
+
+// Table returns the table with the given name, or nil. An unqualified name
+// refers to the current database.
+func (s *Schema) Table(name sqlparser.TableName) *Table {
+	qualifier, err := s.qualifier(name)
+	if err != nil || s.databases[qualifier] == nil {
+		return nil
+	}
+	return s.databases[qualifier].tables[name.Name.String()]
+}
+
+// View returns the view with the given name, or nil. An unqualified name
+// refers to the current database.
+func (s *Schema) View(name sqlparser.TableName) *View {
+	qualifier, err := s.qualifier(name)
+	if err != nil || s.databases[qualifier] == nil {
+		return nil
+	}
+	return s.databases[qualifier].views[name.Name.String()]
+}
+
+// Clone returns a deep copy of the schema.
+func (s *Schema) Clone() *Schema {
+	clone := &Schema{
+		databases: make(map[string]*Database, len(s.databases)),
+		current:   s.current,
+		selected:  s.selected,
+	}
+	for name, db := range s.databases {
+		clone.databases[name] = db.clone()
+	}
+	return clone
+}
+
+// Name returns the name of the database.
+func (db *Database) Name() string {
+	return db.name
+}
+
+// Options returns the options the database was created or altered with.
+func (db *Database) Options() []sqlparser.DatabaseOption {
+	return db.options
+}
+
+// Tables returns the tables of the database sorted by name.
+func (db *Database) Tables() []*Table {
+	tables := make([]*Table, 0, len(db.tables))
+	for _, table := range db.tables {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name() < tables[j].Name()
+	})
+	return tables
+}
+
+// Table returns the table with the given name, or nil.
+func (db *Database) Table(name string) *Table {
+	return db.tables[name]
+}
+
+// Views returns the views of the database sorted by name.
+func (db *Database) Views() []*View {
+	views := make([]*View, 0, len(db.views))
+	for _, view := range db.views {
+		views = append(views, view)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name() < views[j].Name()
+	})
+	return views
+}
+
+// View returns the view with the given name, or nil.
+func (db *Database) View(name string) *View {
+	return db.views[name]
+}
+
+func (db *Database) clone() *Database {
+	clone := &Database{
+		name:    db.name,
+		options: append([]sqlparser.DatabaseOption(nil), db.options...),
+		tables:  make(map[string]*Table, len(db.tables)),
+		views:   make(map[string]*View, len(db.views)),
+	}
+	for name, table := range db.tables {
+		clone.tables[name] = &Table{create: sqlparser.CloneRefOfCreateTable(table.create)}
+	}
+	for name, view := range db.views {
+		clone.views[name] = &View{create: sqlparser.CloneRefOfCreateView(view.create)}
+	}
+	return clone
+}
+
+// exists reports whether a table or view with the given name exists.
+func (db *Database) exists(name string) bool {
+	return db.tables[name] != nil || db.views[name] != nil
+}
+
+// Name returns the name of the view.
+func (v *View) Name() string {
+	return v.create.ViewName.Name.String()
+}
+
+// CreateView returns the definition of the view. It must not be modified.
+func (v *View) CreateView() *sqlparser.CreateView {
+	return v.create
+}
+
+func (s *Schema) use(name string) error {
+	if s.databases[name] == nil {
+		return &UnknownDatabaseError{Database: name}
+	}
+	s.current = name
+	s.selected = true
+	return nil
+}
+
+func (s *Schema) createDatabase(stmt *sqlparser.CreateDatabase) error {
+	name := stmt.DBName.String()
+	if s.databases[name] != nil {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return &DatabaseExistsError{Database: name}
+	}
+	s.databases[name] = newDatabase(name, stmt.CreateOptions)
+	return nil
+}
+
+func newDatabase(name string, options []sqlparser.DatabaseOption) *Database {
+	return &Database{
+		name:    name,
+		options: append([]sqlparser.DatabaseOption(nil), options...),
+		tables:  map[string]*Table{},
+		views:   map[string]*View{},
+	}
+}
+
+func (s *Schema) alterDatabase(stmt *sqlparser.AlterDatabase) error {
+	name := stmt.DBName.String()
+	if !stmt.DBName.NotEmpty() {
+		if !s.selected {
+			return &NoDatabaseSelectedError{}
+		}
+		name = s.current
+	}
+	db := s.databases[name]
+	if db == nil {
+		return &UnknownDatabaseError{Database: name}
+	}
+	for _, option := range stmt.AlterOptions {
+		replaced := false
+		for i := range db.options {
+			if db.options[i].Type == option.Type {
+				db.options[i] = option
+				replaced = true
+			}
+		}
+		if !replaced {
+			db.options = append(db.options, option)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) dropDatabase(stmt *sqlparser.DropDatabase) error {
+	name := stmt.DBName.String()
+	if s.databases[name] == nil {
+		if stmt.IfExists {
+			return nil
+		}
+		return &UnknownDatabaseError{Database: name}
+	}
+	delete(s.databases, name)
+	if s.selected && s.current == name {
+		s.selected = false
+	}
+	return nil
+}
+
+// qualifier returns the name of the database a possibly unqualified name
+// refers to.
+func (s *Schema) qualifier(name sqlparser.TableName) (string, error) {
+	if name.Qualifier.IsEmpty() {
+		if !s.selected {
+			return "", &NoDatabaseSelectedError{}
+		}
+		return s.current, nil
+	}
+	return name.Qualifier.String(), nil
+}
+
+// database returns the database a possibly unqualified name refers to. The
+// implicit database with an empty name is created on first use.
+func (s *Schema) database(name sqlparser.TableName) (*Database, error) {
+	dbName, err := s.qualifier(name)
+	if err != nil {
+		return nil, err
+	}
+	db := s.databases[dbName]
+	if db == nil {
+		if dbName != "" {
+			return nil, &UnknownDatabaseError{Database: dbName}
+		}
+		db = newDatabase("", nil)
+		s.databases[""] = db
+	}
+	return db, nil
+}
+
+// table returns an existing table and its database.
+func (s *Schema) table(name sqlparser.TableName) (*Database, *Table, error) {
+	db, err := s.database(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	table := db.tables[name.Name.String()]
+	if table == nil {
+		return nil, nil, &UnknownTableError{Database: db.name, Table: name.Name.String()}
+	}
+	return db, table, nil
+}
+
+func (s *Schema) createTable(stmt *sqlparser.CreateTable) error {
+	if !stmt.FullyParsed {
+		return unsupportedStatement(stmt)
+	}
+	db, err := s.database(stmt.Table)
+	if err != nil {
+		return err
+	}
+	name := stmt.Table.Name.String()
+	if db.exists(name) {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return &TableExistsError{Database: db.name, Table: name}
+	}
+
+	create := sqlparser.CloneRefOfCreateTable(stmt)
+	create.Table = sqlparser.TableName{Name: stmt.Table.Name}
+	create.IfNotExists = false
+	create.Comments = nil
+	if like := create.OptLike; like != nil {
+		_, source, err := s.table(like.LikeTable)
+		if err != nil {
+			return err
+		}
+		create.OptLike = nil
+		create.TableSpec = likeSpec(source.create.TableSpec)
+	}
+	if create.TableSpec == nil {
+		return unsupportedStatement(stmt)
+	}
+
+	table := &Table{create: create}
+	if err := table.normalize(); err != nil {
+		return err
+	}
+	db.tables[name] = table
+	return nil
+}
+
+// likeSpec returns the definition CREATE TABLE ... LIKE creates from the
+// given table: everything except the foreign keys.
+func likeSpec(spec *sqlparser.TableSpec) *sqlparser.TableSpec {
+	clone := sqlparser.CloneRefOfTableSpec(spec)
+	clone.Constraints = nil
+	for _, constraint := range spec.Constraints {
+		if _, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition); !ok {
+			clone.Constraints = append(clone.Constraints, sqlparser.CloneRefOfConstraintDefinition(constraint))
+		}
+	}
+	return clone
+}
+
+func (s *Schema) dropTables(stmt *sqlparser.DropTable) error {
+	// All tables are checked first, so that a failing statement drops nothing.
+	var dbs []*Database
+	var names []string
+	for _, name := range stmt.FromTables {
+		db, table, err := s.table(name)
+		if err != nil {
+			if stmt.IfExists {
+				var unknown *UnknownTableError
+				var unknownDB *UnknownDatabaseError
+				if errors.As(err, &unknown) || errors.As(err, &unknownDB) {
+					continue
+				}
+			}
+			return err
+		}
+		dbs = append(dbs, db)
+		names = append(names, table.Name())
+	}
+	for i, db := range dbs {
+		delete(db.tables, names[i])
+	}
+	return nil
+}
+
+func (s *Schema) renameTables(stmt *sqlparser.RenameTable) error {
+	// Pairs are applied one at a time, since later pairs may refer to names
+	// freed by earlier ones. On error, the applied pairs are reverted.
+	type renamed struct {
+		from, to *Database
+		fromName string
+		toName   string
+	}
+	var done []renamed
+	revert := func() {
+		for i := len(done) - 1; i >= 0; i-- {
+			r := done[i]
+			table := r.to.tables[r.toName]
+			delete(r.to.tables, r.toName)
+			table.create.Table.Name = sqlparser.NewIdentifierCS(r.fromName)
+			r.from.tables[r.fromName] = table
+		}
+	}
+	for _, pair := range stmt.TablePairs {
+		from, table, err := s.table(pair.FromTable)
+		if err != nil {
+			revert()
+			return err
+		}
+		to, err := s.database(pair.ToTable)
+		if err != nil {
+			revert()
+			return err
+		}
+		toName := pair.ToTable.Name.String()
+		if to.exists(toName) {
+			revert()
+			return &TableExistsError{Database: to.name, Table: toName}
+		}
+		delete(from.tables, table.Name())
+		done = append(done, renamed{from: from, to: to, fromName: table.Name(), toName: toName})
+		table.create.Table.Name = pair.ToTable.Name
+		to.tables[toName] = table
+	}
+	for _, r := range done {
+		s.updateReferences(r.from.name, r.fromName, r.to.name, r.toName, nil)
+	}
+	return nil
+}
+
+func (s *Schema) createView(stmt *sqlparser.CreateView) error {
+	db, err := s.database(stmt.ViewName)
+	if err != nil {
+		return err
+	}
+	name := stmt.ViewName.Name.String()
+	if db.tables[name] != nil || (db.views[name] != nil && !stmt.IsReplace) {
+		return &TableExistsError{Database: db.name, Table: name}
+	}
+	create := sqlparser.CloneRefOfCreateView(stmt)
+	create.ViewName = sqlparser.TableName{Name: stmt.ViewName.Name}
+	create.IsReplace = false
+	create.Comments = nil
+	db.views[name] = &View{create: create}
+	return nil
+}
+
+func (s *Schema) alterView(stmt *sqlparser.AlterView) error {
+	db, err := s.database(stmt.ViewName)
+	if err != nil {
+		return err
+	}
+	name := stmt.ViewName.Name.String()
+	if db.views[name] == nil {
+		return &UnknownViewError{Database: db.name, View: name}
+	}
+	db.views[name] = &View{create: &sqlparser.CreateView{
+		ViewName:    sqlparser.TableName{Name: stmt.ViewName.Name},
+		Algorithm:   stmt.Algorithm,
+		Definer:     sqlparser.CloneRefOfDefiner(stmt.Definer),
+		Security:    stmt.Security,
+		Columns:     sqlparser.CloneColumns(stmt.Columns),
+		Select:      sqlparser.CloneSelectStatement(stmt.Select),
+		CheckOption: stmt.CheckOption,
+	}}
+	return nil
+}
+
+func (s *Schema) dropViews(stmt *sqlparser.DropView) error {
+	var dbs []*Database
+	var names []string
+	for _, name := range stmt.FromTables {
+		db, err := s.database(name)
+		if err != nil {
+			if stmt.IfExists {
+				continue
+			}
+			return err
+		}
+		view := name.Name.String()
+		if db.views[view] == nil {
+			if stmt.IfExists {
+				continue
+			}
+			return &UnknownViewError{Database: db.name, View: view}
+		}
+		dbs = append(dbs, db)
+		names = append(names, view)
+	}
+	for i, db := range dbs {
+		delete(db.views, names[i])
+	}
+	return nil
+}
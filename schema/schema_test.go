@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func tableNames(db *Database) []string {
+	var names []string
+	for _, table := range db.Tables() {
+		names = append(names, table.Name())
+	}
+	return names
+}
+
+func TestCreateTableNormalization(t *testing.T) {
+	testcases := []struct {
+		name string
+		sql  string
+		out  string
+	}{{
+		name: "column keys",
+		sql:  "create table t (id int primary key, email varchar(100) unique, doc text, fulltext (doc))",
+		out:  "create table t (\n\tid int not null,\n\temail varchar(100),\n\tdoc text,\n\tprimary key (id),\n\tunique key email (email),\n\tfulltext key doc (doc)\n)",
+	}, {
+		name: "generated index names",
+		sql:  "create table t (a int, b int, key (a), key (a, b), unique key (b), key ((a + b)))",
+		out:  "create table t (\n\ta int,\n\tb int,\n\tkey a (a),\n\tkey a_2 (a, b),\n\tunique key b (b),\n\tkey functional_index ((a + b))\n)",
+	}, {
+		name: "constraint names",
+		sql:  "create table t (id int, p int, constraint u unique (p), foreign key (p) references p (id), check (id > 0), constraint t_chk_5 check (p > 0), check (p < 10))",
+		out:  "create table t (\n\tid int,\n\tp int,\n\tunique key u (p),\n\tconstraint t_ibfk_1 foreign key (p) references p (id),\n\tconstraint t_chk_1 check (id > 0),\n\tconstraint t_chk_5 check (p > 0),\n\tconstraint t_chk_6 check (p < 10)\n)",
+	}, {
+		name: "foreign key index",
+		sql:  "create table t (id int, a int, b int, constraint fk_a foreign key (a) references p (id), foreign key ix_b (b) references p (id), foreign key (a, b) references p (x, y))",
+		out:  "create table t (\n\tid int,\n\ta int,\n\tb int,\n\tkey fk_a (a),\n\tkey ix_b (b),\n\tkey a (a, b),\n\tconstraint fk_a foreign key (a) references p (id),\n\tconstraint t_ibfk_1 foreign key (b) references p (id),\n\tconstraint t_ibfk_2 foreign key (a, b) references p (x, y)\n)",
+	}, {
+		name: "foreign key using existing index",
+		sql:  "create table t (id int, a int, key ab (a, id), foreign key (a) references p (id))",
+		out:  "create table t (\n\tid int,\n\ta int,\n\tkey ab (a, id),\n\tconstraint t_ibfk_1 foreign key (a) references p (id)\n)",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			s, err := NewFromSQL(parser, tcase.sql)
+			require.NoError(t, err)
+			table := s.Database("").Table("t")
+			require.NotNil(t, table)
+			assert.Equal(t, tcase.out, sqlparser.String(table.CreateTable()))
+		})
+	}
+}
+
+func TestCreateTableErrors(t *testing.T) {
+	testcases := []struct {
+		sql string
+		err string
+	}{{
+		sql: "create table t (a int, A int)",
+		err: "Duplicate column name 'A' in table 't'",
+	}, {
+		sql: "create table t (a int primary key, b int, primary key (b))",
+		err: "Multiple primary key defined in table 't'",
+	}, {
+		sql: "create table t (a int, key k (a), unique key K (a))",
+		err: "Duplicate key name 'K' in table 't'",
+	}, {
+		sql: "create table t (a int, key (b))",
+		err: "Unknown column 'b' in table 't'",
+	}, {
+		sql: "create table t (a int, b int as (c + 1))",
+		err: "Unknown column 'c' in table 't'",
+	}, {
+		sql: "create table t (a int, check (b > 0))",
+		err: "Unknown column 'b' in table 't'",
+	}, {
+		sql: "create table t (a int, constraint c check (a > 0), constraint c foreign key (a) references p (id))",
+		err: "Duplicate key name 'c' in table 't'",
+	}, {
+		sql: "create table t (a int); create table t (b int)",
+		err: "Table '.t' already exists",
+	}, {
+		sql: "create table t like u",
+		err: "Table '.u' doesn't exist",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			_, err := NewFromSQL(parser, tcase.sql)
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	s, err := NewFromSQL(sqlparser.NewTestParser(), `
+create database shop default character set utf8mb4;
+create database if not exists shop;
+create database archive;
+use shop;
+create table customers (id int primary key, name varchar(50));
+create table orders (id int primary key, customer_id int, foreign key (customer_id) references customers (id));
+create table if not exists orders (x int);
+create table archive.orders like orders;
+create view big_customers as select id from customers;
+create or replace view big_customers as select id, name from customers;
+rename table customers to clients, orders to purchases;
+drop table if exists missing, shop.nothing;
+alter database shop collate utf8mb4_bin;
+insert into clients values (1, 'Ann');
+`)
+	require.NoError(t, err)
+
+	current, selected := s.CurrentDatabase()
+	assert.Equal(t, "shop", current)
+	assert.True(t, selected)
+
+	require.Len(t, s.Databases(), 2)
+	shop := s.Database("shop")
+	assert.Equal(t, []string{"clients", "purchases"}, tableNames(shop))
+	assert.Len(t, shop.Options(), 2)
+
+	purchases := shop.Table("purchases")
+	require.Len(t, purchases.ForeignKeys(), 1)
+	ref := purchases.ForeignKeys()[0].Details.(*sqlparser.ForeignKeyDefinition).ReferenceDefinition
+	assert.Equal(t, "clients", sqlparser.String(ref.ReferencedTable))
+
+	archived := s.Table(sqlparser.NewTableNameWithQualifier("orders", "archive"))
+	require.NotNil(t, archived)
+	assert.Empty(t, archived.ForeignKeys())
+	assert.NotNil(t, archived.Index("customer_id"))
+
+	view := s.View(sqlparser.NewTableName("big_customers"))
+	require.NotNil(t, view)
+	assert.Equal(t, "select id, `name` from customers", sqlparser.String(view.CreateView().Select))
+
+	clone := s.Clone()
+	require.NoError(t, s.Apply(&sqlparser.DropDatabase{DBName: sqlparser.NewIdentifierCS("shop")}))
+	assert.Nil(t, s.Database("shop"))
+	assert.NotNil(t, clone.Database("shop"))
+
+	_, selected = s.CurrentDatabase()
+	assert.False(t, selected)
+	err = s.ApplySQL(sqlparser.NewTestParser(), "create table t (id int)")
+	assert.EqualError(t, err, "No database selected")
+}
+
+func TestSchemaStatementErrors(t *testing.T) {
+	testcases := []struct {
+		sql string
+		err string
+	}{{
+		sql: "use shop",
+		err: "Unknown database 'shop'",
+	}, {
+		sql: "create table shop.t (a int)",
+		err: "Unknown database 'shop'",
+	}, {
+		sql: "create database a; create database a",
+		err: "Can't create database 'a'; database exists",
+	}, {
+		sql: "drop database a",
+		err: "Unknown database 'a'",
+	}, {
+		sql: "create table t (a int); drop table t, u",
+		err: "Table '.u' doesn't exist",
+	}, {
+		sql: "create table t (a int); create table u (a int); rename table t to v, u to v",
+		err: "Table '.v' already exists",
+	}, {
+		sql: "create table t (a int); create view t as select 1",
+		err: "Table '.t' already exists",
+	}, {
+		sql: "alter view v as select 1",
+		err: "Unknown view '.v'",
+	}, {
+		sql: "drop view v",
+		err: "Unknown view '.v'",
+	}, {
+		sql: "truncate table t",
+		err: "Table '.t' doesn't exist",
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			_, err := NewFromSQL(parser, tcase.sql)
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
+
+func TestSchemaFailedStatementsAreAtomic(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	s, err := NewFromSQL(parser, "create table t (a int); create table u (a int)")
+	require.NoError(t, err)
+
+	require.Error(t, s.ApplySQL(parser, "drop table t, missing"))
+	assert.Equal(t, []string{"t", "u"}, tableNames(s.Database("")))
+
+	require.Error(t, s.ApplySQL(parser, "rename table t to x, u to x"))
+	assert.Equal(t, []string{"t", "u"}, tableNames(s.Database("")))
+}
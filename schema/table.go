@@ -0,0 +1,432 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+// PrimaryKeyName is the name MySQL gives to the primary key of a table.
+const PrimaryKeyName = "PRIMARY"
+
+// Table is a table definition. Its definition is normalized: all keys are
+// held in the index list and all indexes and constraints are named.
+type Table struct {
+	create *sqlparser.CreateTable
+}
+
+// Name returns the name of the table.
+func (t *Table) Name() string {
+	return t.create.Table.Name.String()
+}
+
+// CreateTable returns the definition of the table. It must not be modified.
+func (t *Table) CreateTable() *sqlparser.CreateTable {
+	return t.create
+}
+
+// Columns returns the columns of the table in order.
+func (t *Table) Columns() []*sqlparser.ColumnDefinition {
+	return t.create.TableSpec.Columns
+}
+
+// Column returns the column with the given name, or nil. Column names are
+// case-insensitive.
+func (t *Table) Column(name string) *sqlparser.ColumnDefinition {
+	if i := t.columnIndex(name); i >= 0 {
+		return t.create.TableSpec.Columns[i]
+	}
+	return nil
+}
+
+// Indexes returns the indexes of the table, including the primary key.
+func (t *Table) Indexes() []*sqlparser.IndexDefinition {
+	return t.create.TableSpec.Indexes
+}
+
+// Index returns the index with the given name, or nil. Index names are
+// case-insensitive.
+func (t *Table) Index(name string) *sqlparser.IndexDefinition {
+	if i := t.indexIndex(name); i >= 0 {
+		return t.create.TableSpec.Indexes[i]
+	}
+	return nil
+}
+
+// PrimaryKey returns the primary key of the table, or nil.
+func (t *Table) PrimaryKey() *sqlparser.IndexDefinition {
+	for _, index := range t.create.TableSpec.Indexes {
+		if index.Info.Type == sqlparser.IndexTypePrimary {
+			return index
+		}
+	}
+	return nil
+}
+
+// ForeignKeys returns the foreign key constraints of the table.
+func (t *Table) ForeignKeys() []*sqlparser.ConstraintDefinition {
+	var fks []*sqlparser.ConstraintDefinition
+	for _, constraint := range t.create.TableSpec.Constraints {
+		if _, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition); ok {
+			fks = append(fks, constraint)
+		}
+	}
+	return fks
+}
+
+// Checks returns the check constraints of the table.
+func (t *Table) Checks() []*sqlparser.ConstraintDefinition {
+	var checks []*sqlparser.ConstraintDefinition
+	for _, constraint := range t.create.TableSpec.Constraints {
+		if _, ok := constraint.Details.(*sqlparser.CheckConstraintDefinition); ok {
+			checks = append(checks, constraint)
+		}
+	}
+	return checks
+}
+
+// Constraint returns the foreign key or check constraint with the given
+// name, or nil. Constraint names are case-insensitive.
+func (t *Table) Constraint(name string) *sqlparser.ConstraintDefinition {
+	if i := t.constraintIndex(name); i >= 0 {
+		return t.create.TableSpec.Constraints[i]
+	}
+	return nil
+}
+
+// Options returns the table options.
+func (t *Table) Options() sqlparser.TableOptions {
+	return t.create.TableSpec.Options
+}
+
+// Partitioning returns the partitioning of the table, or nil if the table is
+// not partitioned.
+func (t *Table) Partitioning() *sqlparser.PartitionOption {
+	return t.create.TableSpec.PartitionOption
+}
+
+func (t *Table) columnIndex(name string) int {
+	for i, col := range t.create.TableSpec.Columns {
+		if col.Name.EqualString(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *Table) indexIndex(name string) int {
+	for i, index := range t.create.TableSpec.Indexes {
+		if index.Info.Name.EqualString(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *Table) constraintIndex(name string) int {
+	for i, constraint := range t.create.TableSpec.Constraints {
+		if constraint.Name.EqualString(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalize rewrites a new table definition into the form MySQL would show
+// for it, and validates it.
+func (t *Table) normalize() error {
+	spec := t.create.TableSpec
+	indexes := spec.Indexes
+	spec.Indexes = nil
+	for _, col := range spec.Columns {
+		if err := t.addColumnKey(col); err != nil {
+			return err
+		}
+	}
+	for _, index := range indexes {
+		if err := t.addIndex(index); err != nil {
+			return err
+		}
+	}
+
+	constraints := spec.Constraints
+	spec.Constraints = nil
+	for _, constraint := range constraints {
+		if err := t.addConstraint(constraint); err != nil {
+			return err
+		}
+	}
+	return t.validate()
+}
+
+// addColumnKey moves a key defined on a column, such as PRIMARY KEY or
+// UNIQUE, to the index list.
+func (t *Table) addColumnKey(col *sqlparser.ColumnDefinition) error {
+	if col.Type.Options == nil {
+		return nil
+	}
+	info := &sqlparser.IndexInfo{}
+	switch col.Type.Options.KeyOpt {
+	case sqlparser.ColKeyNone:
+		return nil
+	case sqlparser.ColKeyPrimary, sqlparser.ColKey:
+		info.Type = sqlparser.IndexTypePrimary
+	case sqlparser.ColKeyUnique, sqlparser.ColKeyUniqueKey:
+		info.Type = sqlparser.IndexTypeUnique
+	case sqlparser.ColKeySpatialKey:
+		info.Type = sqlparser.IndexTypeSpatial
+	case sqlparser.ColKeyFulltextKey:
+		info.Type = sqlparser.IndexTypeFullText
+	}
+	col.Type.Options.KeyOpt = sqlparser.ColKeyNone
+	return t.addIndex(&sqlparser.IndexDefinition{
+		Info:    info,
+		Columns: []*sqlparser.IndexColumn{{Column: col.Name}},
+	})
+}
+
+// addIndex names the given index and adds it to the table. The primary key is
+// always kept first.
+func (t *Table) addIndex(index *sqlparser.IndexDefinition) error {
+	spec := t.create.TableSpec
+	if index.Info.Type == sqlparser.IndexTypePrimary {
+		if t.PrimaryKey() != nil {
+			return &MultiplePrimaryKeysError{Table: t.Name()}
+		}
+		index.Info.Name = sqlparser.NewIdentifierCI(PrimaryKeyName)
+		index.Info.ConstraintName = sqlparser.IdentifierCI{}
+		for _, part := range index.Columns {
+			if col := t.Column(part.Column.String()); col != nil {
+				setNotNull(col)
+			}
+		}
+		spec.Indexes = append([]*sqlparser.IndexDefinition{index}, spec.Indexes...)
+		return nil
+	}
+
+	if index.Info.Name.IsEmpty() {
+		index.Info.Name = index.Info.ConstraintName
+	}
+	index.Info.ConstraintName = sqlparser.IdentifierCI{}
+	if index.Info.Name.IsEmpty() {
+		index.Info.Name = sqlparser.NewIdentifierCI(t.indexName(index))
+	}
+	if index.Info.Name.EqualString(PrimaryKeyName) {
+		return &DuplicateKeyError{Table: t.Name(), Key: index.Info.Name.String()}
+	}
+	if t.Index(index.Info.Name.String()) != nil {
+		return &DuplicateKeyError{Table: t.Name(), Key: index.Info.Name.String()}
+	}
+	spec.Indexes = append(spec.Indexes, index)
+	return nil
+}
+
+// indexName returns the name MySQL generates for an unnamed index: the name
+// of its first column, with a _2, _3, ... suffix if that name is taken.
+func (t *Table) indexName(index *sqlparser.IndexDefinition) string {
+	base := "functional_index"
+	if len(index.Columns) > 0 && index.Columns[0].Expression == nil {
+		base = index.Columns[0].Column.String()
+	}
+	name := base
+	for i := 2; t.Index(name) != nil || strings.EqualFold(name, PrimaryKeyName); i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
+}
+
+// addConstraint names the given constraint and adds it to the table. Foreign
+// keys get a supporting index if no existing index can be used.
+func (t *Table) addConstraint(constraint *sqlparser.ConstraintDefinition) error {
+	switch details := constraint.Details.(type) {
+	case *sqlparser.ForeignKeyDefinition:
+		if t.foreignKeyIndex(details.Source, "") == nil {
+			// The index is named after the constraint, else after the index
+			// name of the foreign key, else after its first column.
+			name := constraint.Name
+			if name.IsEmpty() {
+				name = details.IndexName
+			}
+			index := &sqlparser.IndexDefinition{Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeDefault}}
+			if t.Index(name.String()) == nil {
+				index.Info.Name = name
+			}
+			for _, col := range details.Source {
+				index.Columns = append(index.Columns, &sqlparser.IndexColumn{Column: col})
+			}
+			if err := t.addIndex(index); err != nil {
+				return err
+			}
+		}
+		details.IndexName = sqlparser.IdentifierCI{}
+		if constraint.Name.IsEmpty() {
+			constraint.Name = sqlparser.NewIdentifierCI(t.constraintName("ibfk"))
+		}
+	case *sqlparser.CheckConstraintDefinition:
+		if constraint.Name.IsEmpty() {
+			constraint.Name = sqlparser.NewIdentifierCI(t.constraintName("chk"))
+		}
+	}
+	if t.Constraint(constraint.Name.String()) != nil {
+		return &DuplicateKeyError{Table: t.Name(), Key: constraint.Name.String()}
+	}
+	t.create.TableSpec.Constraints = append(t.create.TableSpec.Constraints, constraint)
+	return nil
+}
+
+// constraintName returns the name MySQL generates for an unnamed constraint,
+// such as t_ibfk_1 or t_chk_1. The number follows the highest number used by
+// the existing constraints.
+func (t *Table) constraintName(kind string) string {
+	prefix := fmt.Sprintf("%s_%s_", t.Name(), kind)
+	n := 0
+	for _, constraint := range t.create.TableSpec.Constraints {
+		name := constraint.Name.String()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if i, err := strconv.Atoi(name[len(prefix):]); err == nil && i > n {
+			n = i
+		}
+	}
+	return prefix + strconv.Itoa(n+1)
+}
+
+// foreignKeyIndex returns an index, other than the one named skip, whose
+// leading columns are the given columns, so that it can be used by a foreign
+// key on them.
+func (t *Table) foreignKeyIndex(cols sqlparser.Columns, skip string) *sqlparser.IndexDefinition {
+	for _, index := range t.create.TableSpec.Indexes {
+		if skip != "" && index.Info.Name.EqualString(skip) {
+			continue
+		}
+		if len(index.Columns) < len(cols) {
+			continue
+		}
+		usable := true
+		for i, col := range cols {
+			part := index.Columns[i]
+			if part.Expression != nil || part.Length != nil || !part.Column.Equal(col) {
+				usable = false
+				break
+			}
+		}
+		if usable {
+			return index
+		}
+	}
+	return nil
+}
+
+// validate checks that the table is consistent: names are unique and all
+// column references can be resolved.
+func (t *Table) validate() error {
+	spec := t.create.TableSpec
+	seen := map[string]bool{}
+	for _, col := range spec.Columns {
+		if seen[col.Name.Lowered()] {
+			return &DuplicateColumnError{Table: t.Name(), Column: col.Name.String()}
+		}
+		seen[col.Name.Lowered()] = true
+	}
+
+	for _, col := range spec.Columns {
+		if col.Type.Options != nil && col.Type.Options.As != nil {
+			if err := t.validateExpr(col.Type.Options.As); err != nil {
+				return err
+			}
+		}
+	}
+	for _, index := range spec.Indexes {
+		for _, part := range index.Columns {
+			if part.Expression != nil {
+				if err := t.validateExpr(part.Expression); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := t.validateColumn(part.Column); err != nil {
+				return err
+			}
+		}
+	}
+	for _, constraint := range spec.Constraints {
+		switch details := constraint.Details.(type) {
+		case *sqlparser.ForeignKeyDefinition:
+			for _, col := range details.Source {
+				if err := t.validateColumn(col); err != nil {
+					return err
+				}
+			}
+		case *sqlparser.CheckConstraintDefinition:
+			if err := t.validateExpr(details.Expr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *Table) validateColumn(name sqlparser.IdentifierCI) error {
+	if t.columnIndex(name.String()) < 0 {
+		return &UnknownColumnError{Table: t.Name(), Column: name.String()}
+	}
+	return nil
+}
+
+func (t *Table) validateExpr(expr sqlparser.Expr) error {
+	for _, col := range columnsOf(expr) {
+		if err := t.validateColumn(col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnsOf returns the columns referenced by an expression.
+func columnsOf(expr sqlparser.Expr) []sqlparser.IdentifierCI {
+	var cols []sqlparser.IdentifierCI
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			cols = append(cols, col.Name)
+		}
+		return true, nil
+	}, expr)
+	return cols
+}
+
+// references reports whether an expression references the given column.
+func references(expr sqlparser.Expr, name string) bool {
+	for _, col := range columnsOf(expr) {
+		if col.EqualString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func setNotNull(col *sqlparser.ColumnDefinition) {
+	if col.Type.Options == nil {
+		col.Type.Options = &sqlparser.ColumnTypeOptions{}
+	}
+	notNull := false
+	col.Type.Options.Null = &notNull
+}
@@ -0,0 +1,750 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package semantics resolves the names of SQL statements against a schema.
+package semantics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// Bindings holds the tables and columns the names of a statement refer to.
+type Bindings struct {
+	columns map[*sqlparser.ColName]*Column
+	tables  map[sqlparser.TableExpr]*Table
+	results map[sqlparser.SelectStatement][]*Column
+	ordered []*Table
+}
+
+// Column returns the column a column reference refers to, or nil if the
+// reference is not part of the bound statement. References to the alias of
+// a select expression return a result column of the query.
+func (b *Bindings) Column(col *sqlparser.ColName) *Column {
+	return b.columns[col]
+}
+
+// Table returns the table of an *AliasedTableExpr or *JSONTableExpr, or nil
+// if the expression is not part of the bound statement. The DUAL table has
+// no table.
+func (b *Bindings) Table(expr sqlparser.TableExpr) *Table {
+	return b.tables[expr]
+}
+
+// Tables returns the tables of the statement in the order they appear,
+// including the tables of subqueries and of the views it reads from.
+func (b *Bindings) Tables() []*Table {
+	return b.ordered
+}
+
+// Result returns the result columns of a query of the statement, with the
+// columns a star stands for expanded.
+func (b *Bindings) Result(stmt sqlparser.SelectStatement) []*Column {
+	return b.results[stmt]
+}
+
+// Bind resolves the table and column references of a statement. It reports
+// the errors MySQL reports for unknown or ambiguous names, such as
+// ER_BAD_FIELD_ERROR and ER_NON_UNIQ_ERROR, as *sqlerror.SQLError.
+//
+// SELECT, INSERT, REPLACE, UPDATE and DELETE statements are bound, as are
+// the queries of CREATE VIEW, ALTER VIEW and EXPLAIN statements. Other
+// statements have no bindings.
+func Bind(s *schema.Schema, stmt sqlparser.Statement) (*Bindings, error) {
+	b := &binder{
+		schema: s,
+		bindings: &Bindings{
+			columns: map[*sqlparser.ColName]*Column{},
+			tables:  map[sqlparser.TableExpr]*Table{},
+			results: map[sqlparser.SelectStatement][]*Column{},
+		},
+		views:  map[*schema.View][]*Column{},
+		scopes: map[*sqlparser.Select]*scope{},
+	}
+	if err := b.statement(stmt); err != nil {
+		return nil, err
+	}
+	return b.bindings, nil
+}
+
+// scope holds the names a query block can refer to.
+type scope struct {
+	tables []*Table
+	// coalesced holds the columns joined with USING or NATURAL to a column of
+	// a table on the left, which unqualified references do not refer to.
+	coalesced map[*Column]bool
+	ctes      map[string]*cte
+	// results are the result columns of the query block, which GROUP BY,
+	// HAVING and ORDER BY can refer to by alias.
+	results []*Column
+	parent  *scope
+}
+
+// cte is a common table expression of a WITH clause.
+type cte struct {
+	expr    *sqlparser.CommonTableExpr
+	results []*Column
+}
+
+func (sc *scope) cte(name string) *cte {
+	for ; sc != nil; sc = sc.parent {
+		if cte, ok := sc.ctes[name]; ok {
+			return cte
+		}
+	}
+	return nil
+}
+
+// lookup returns the columns of the tables of the scope a column reference
+// can refer to.
+func (sc *scope) lookup(col *sqlparser.ColName) []*Column {
+	var found []*Column
+	for _, table := range sc.tables {
+		if col.Qualifier.NonEmpty() && !table.matches(col.Qualifier) {
+			continue
+		}
+		c := table.Column(col.Name.String())
+		if c == nil || col.Qualifier.IsEmpty() && sc.coalesced[c] {
+			continue
+		}
+		found = append(found, c)
+	}
+	return found
+}
+
+// result returns the result column with the alias of an unqualified column
+// reference, or nil.
+func (sc *scope) result(col *sqlparser.ColName) *Column {
+	if col.Qualifier.NonEmpty() {
+		return nil
+	}
+	for _, result := range sc.results {
+		if strings.EqualFold(result.Name, col.Name.String()) {
+			return result
+		}
+	}
+	return nil
+}
+
+type binder struct {
+	schema   *schema.Schema
+	bindings *Bindings
+	// views holds the result columns of the views bound so far.
+	views map[*schema.View][]*Column
+	// scopes holds the scopes of the query blocks, which ON DUPLICATE KEY
+	// UPDATE clauses of INSERT ... SELECT statements can refer to.
+	scopes map[*sqlparser.Select]*scope
+}
+
+func (b *binder) statement(stmt sqlparser.Statement) error {
+	switch stmt := stmt.(type) {
+	case sqlparser.SelectStatement:
+		_, err := b.selectStatement(stmt, nil)
+		return err
+	case *sqlparser.Insert:
+		return b.insert(stmt)
+	case *sqlparser.Update:
+		return b.update(stmt)
+	case *sqlparser.Delete:
+		return b.delete(stmt)
+	case *sqlparser.CreateView:
+		return b.view(stmt.Select, stmt.Columns)
+	case *sqlparser.AlterView:
+		return b.view(stmt.Select, stmt.Columns)
+	case *sqlparser.ExplainStmt:
+		return b.statement(stmt.Statement)
+	}
+	return nil
+}
+
+// view binds the query of a view definition.
+func (b *binder) view(stmt sqlparser.SelectStatement, names sqlparser.Columns) error {
+	results, err := b.selectStatement(stmt, nil)
+	if err != nil {
+		return err
+	}
+	_, err = derive(nil, results, names)
+	return err
+}
+
+// selectStatement binds a query and returns its result columns.
+func (b *binder) selectStatement(stmt sqlparser.SelectStatement, parent *scope) ([]*Column, error) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		return b.selectBlock(stmt, parent)
+	case *sqlparser.Union:
+		sc := &scope{parent: parent}
+		if err := b.with(stmt.With, sc); err != nil {
+			return nil, err
+		}
+		return b.union(stmt, sc, nil)
+	}
+	return nil, fmt.Errorf("unsupported query: %s", sqlparser.String(stmt))
+}
+
+// union binds a UNION in a scope holding its common table expressions. The
+// result columns of its left side are given when they are bound already.
+func (b *binder) union(stmt *sqlparser.Union, sc *scope, left []*Column) ([]*Column, error) {
+	var err error
+	if left == nil {
+		if left, err = b.selectStatement(stmt.Left, sc); err != nil {
+			return nil, err
+		}
+	}
+	right, err := b.selectStatement(stmt.Right, sc)
+	if err != nil {
+		return nil, err
+	}
+	if len(left) != len(right) {
+		return nil, wrongNumberOfColumnsError()
+	}
+	results := make([]*Column, len(left))
+	for i, col := range left {
+		exprs := append(col.Exprs[:len(col.Exprs):len(col.Exprs)], right[i].Exprs...)
+		results[i] = &Column{Name: col.Name, Exprs: exprs}
+	}
+	b.bindings.results[stmt] = results
+
+	// The ORDER BY clause of a UNION can only refer to its result columns.
+	order := &scope{results: results, parent: sc.parent, ctes: sc.ctes}
+	for _, o := range stmt.OrderBy {
+		if err := b.expr(o.Expr, order, orderClause); err != nil {
+			return nil, err
+		}
+	}
+	return results, b.limit(stmt.Limit, sc)
+}
+
+func (b *binder) selectBlock(stmt *sqlparser.Select, parent *scope) ([]*Column, error) {
+	sc := &scope{parent: parent}
+	b.scopes[stmt] = sc
+	if err := b.with(stmt.With, sc); err != nil {
+		return nil, err
+	}
+	for _, expr := range stmt.From {
+		if err := b.tableExpr(expr, sc); err != nil {
+			return nil, err
+		}
+	}
+	if stmt.Where != nil {
+		if err := b.expr(stmt.Where.Expr, sc, whereClause); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []*Column
+	for _, expr := range stmt.SelectExprs {
+		switch expr := expr.(type) {
+		case *sqlparser.StarExpr:
+			cols, err := b.star(expr, sc)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, cols...)
+		case *sqlparser.AliasedExpr:
+			if err := b.expr(expr.Expr, sc, fieldList); err != nil {
+				return nil, err
+			}
+			results = append(results, &Column{Name: expr.ColumnName(), Exprs: []sqlparser.Expr{expr.Expr}})
+		}
+	}
+	sc.results = results
+	b.bindings.results[stmt] = results
+
+	for _, named := range stmt.Windows {
+		for _, def := range named.Windows {
+			if err := b.expr(def.WindowSpec, sc, fieldList); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if stmt.GroupBy != nil {
+		for _, expr := range stmt.GroupBy.Exprs {
+			if err := b.expr(expr, sc, groupClause); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if stmt.Having != nil {
+		if err := b.expr(stmt.Having.Expr, sc, havingClause); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range stmt.OrderBy {
+		if err := b.expr(o.Expr, sc, orderClause); err != nil {
+			return nil, err
+		}
+	}
+	return results, b.limit(stmt.Limit, sc)
+}
+
+// star returns the columns a star of a select list stands for. The columns
+// are produced by column references bound to the columns of the tables.
+func (b *binder) star(expr *sqlparser.StarExpr, sc *scope) ([]*Column, error) {
+	if len(sc.tables) == 0 && expr.TableName.IsEmpty() {
+		return nil, noTablesUsedError()
+	}
+	var results []*Column
+	found := false
+	for _, table := range sc.tables {
+		if expr.TableName.NonEmpty() && !table.matches(expr.TableName) {
+			continue
+		}
+		found = true
+		for _, col := range table.Columns {
+			if expr.TableName.IsEmpty() && sc.coalesced[col] {
+				continue
+			}
+			ref := &sqlparser.ColName{Name: sqlparser.NewIdentifierCI(col.Name), Qualifier: table.Name}
+			b.bindings.columns[ref] = col
+			results = append(results, &Column{Name: col.Name, Exprs: []sqlparser.Expr{ref}})
+		}
+	}
+	if !found {
+		return nil, badTableError(tableName(expr.TableName))
+	}
+	return results, nil
+}
+
+// with adds the common table expressions of a WITH clause to a scope.
+func (b *binder) with(with *sqlparser.With, sc *scope) error {
+	if with == nil {
+		return nil
+	}
+	sc.ctes = map[string]*cte{}
+	for _, expr := range with.CTEs {
+		name := expr.ID.String()
+		if _, ok := sc.ctes[name]; ok {
+			return nonUniqTableError(name)
+		}
+		def := &cte{expr: expr}
+		union, ok := expr.Subquery.(*sqlparser.Union)
+		if !with.Recursive || !ok {
+			results, err := b.selectStatement(expr.Subquery, sc)
+			if err != nil {
+				return err
+			}
+			def.results = results
+			sc.ctes[name] = def
+			continue
+		}
+
+		// A recursive common table expression refers to itself in the
+		// right side of its UNION, so its columns are those of the left
+		// side.
+		inner := &scope{parent: sc}
+		if err := b.with(union.With, inner); err != nil {
+			return err
+		}
+		left, err := b.selectStatement(union.Left, inner)
+		if err != nil {
+			return err
+		}
+		def.results = left
+		sc.ctes[name] = def
+		results, err := b.union(union, inner, left)
+		if err != nil {
+			return err
+		}
+		def.results = results
+	}
+	return nil
+}
+
+// tableExpr adds the tables of a table expression to a scope.
+func (b *binder) tableExpr(expr sqlparser.TableExpr, sc *scope) error {
+	start := len(sc.tables)
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		table, err := b.table(expr, sc)
+		if err != nil || table == nil {
+			return err
+		}
+		return b.add(table, sc)
+	case *sqlparser.JSONTableExpr:
+		return b.jsonTable(expr, sc)
+	case *sqlparser.ParenTableExpr:
+		for _, expr := range expr.Exprs {
+			if err := b.tableExpr(expr, sc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *sqlparser.JoinTableExpr:
+		if err := b.tableExpr(expr.LeftExpr, sc); err != nil {
+			return err
+		}
+		middle := len(sc.tables)
+		if err := b.tableExpr(expr.RightExpr, sc); err != nil {
+			return err
+		}
+		left, right := sc.tables[start:middle], sc.tables[middle:]
+		switch expr.Join {
+		case sqlparser.LeftJoinType, sqlparser.NaturalLeftJoinType:
+			for _, table := range right {
+				table.Nullable = true
+			}
+		case sqlparser.RightJoinType, sqlparser.NaturalRightJoinType:
+			for _, table := range left {
+				table.Nullable = true
+			}
+		}
+		switch expr.Join {
+		case sqlparser.NaturalJoinType, sqlparser.NaturalLeftJoinType, sqlparser.NaturalRightJoinType:
+			for _, table := range right {
+				for _, col := range table.Columns {
+					name := &sqlparser.ColName{Name: sqlparser.NewIdentifierCI(col.Name)}
+					if !sc.coalesced[col] && len((&scope{tables: left, coalesced: sc.coalesced}).lookup(name)) > 0 {
+						b.coalesce(col, sc)
+					}
+				}
+			}
+		}
+		if expr.Condition == nil {
+			return nil
+		}
+		for _, name := range expr.Condition.Using {
+			col := &sqlparser.ColName{Name: name}
+			leftCols := (&scope{tables: left, coalesced: sc.coalesced}).lookup(col)
+			rightCols := (&scope{tables: right, coalesced: sc.coalesced}).lookup(col)
+			if len(leftCols) == 0 || len(rightCols) == 0 {
+				return badFieldError(name.String(), fromClause)
+			}
+			if len(leftCols) > 1 || len(rightCols) > 1 {
+				return nonUniqError(name.String(), fromClause)
+			}
+			b.coalesce(rightCols[0], sc)
+		}
+		if expr.Condition.On != nil {
+			// The ON clause of a join refers to the tables it joins.
+			on := &scope{tables: sc.tables[start:], coalesced: sc.coalesced, ctes: sc.ctes, parent: sc.parent}
+			return b.expr(expr.Condition.On, on, onClause)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported table expression: %s", sqlparser.String(expr))
+}
+
+func (b *binder) coalesce(col *Column, sc *scope) {
+	if sc.coalesced == nil {
+		sc.coalesced = map[*Column]bool{}
+	}
+	sc.coalesced[col] = true
+}
+
+// add adds a table to a scope, checking that its name is unique. Tables
+// without an alias are only the same if their databases are the same.
+func (b *binder) add(table *Table, sc *scope) error {
+	for _, other := range sc.tables {
+		if other.matches(table.Name) || table.matches(other.Name) {
+			return nonUniqTableError(table.Name.Name.String())
+		}
+	}
+	sc.tables = append(sc.tables, table)
+	b.bindings.tables[table.Expr] = table
+	b.bindings.ordered = append(b.bindings.ordered, table)
+	return nil
+}
+
+// table returns the table of a table, view, common table expression or
+// derived table. It returns nil for the DUAL table.
+func (b *binder) table(expr *sqlparser.AliasedTableExpr, sc *scope) (*Table, error) {
+	table := &Table{Expr: expr}
+	switch name := expr.Expr.(type) {
+	case sqlparser.TableName:
+		if name.Qualifier.IsEmpty() && strings.EqualFold(name.Name.String(), "dual") {
+			return nil, nil
+		}
+		if def := sc.cte(name.Name.String()); def != nil && name.Qualifier.IsEmpty() {
+			table.Kind = CommonTableExpression
+			table.Name = name
+			table.Select = def.expr.Subquery
+			return table, b.columns(table, expr, def.results, def.expr.Columns)
+		}
+
+		db, selected := b.schema.CurrentDatabase()
+		if name.Qualifier.NotEmpty() {
+			db = name.Qualifier.String()
+		} else if !selected {
+			return nil, noDBError()
+		}
+		table.Database = db
+		table.Name = sqlparser.NewTableNameWithQualifier(name.Name.String(), db)
+		if table.Schema = b.schema.Table(name); table.Schema != nil {
+			table.Kind = BaseTable
+			for _, def := range table.Schema.Columns() {
+				table.Columns = append(table.Columns, &Column{Table: table, Name: def.Name.String(), Definition: def})
+			}
+			if expr.As.NotEmpty() {
+				table.Name = sqlparser.NewTableName(expr.As.String())
+			}
+			return table, nil
+		}
+		if table.View = b.schema.View(name); table.View != nil {
+			table.Kind = View
+			table.Select = table.View.CreateView().Select
+			results, ok := b.views[table.View]
+			if !ok {
+				// The query of a view refers to the tables of its own
+				// database.
+				var err error
+				if results, err = b.selectStatement(table.Select, nil); err != nil {
+					return nil, err
+				}
+				b.views[table.View] = results
+			}
+			return table, b.columns(table, expr, results, table.View.CreateView().Columns)
+		}
+		return nil, noSuchTableError(db, name.Name.String())
+	case *sqlparser.DerivedTable:
+		// Only lateral derived tables refer to the tables before them.
+		parent := &scope{ctes: sc.ctes, parent: sc.parent}
+		if name.Lateral {
+			parent = sc
+		}
+		results, err := b.selectStatement(name.Select, parent)
+		if err != nil {
+			return nil, err
+		}
+		table.Kind = DerivedTable
+		table.Select = name.Select
+		return table, b.columns(table, expr, results, nil)
+	}
+	return nil, fmt.Errorf("unsupported table expression: %s", sqlparser.String(expr))
+}
+
+// columns sets the name and the columns of a table made of the result
+// columns of a query.
+func (b *binder) columns(table *Table, expr *sqlparser.AliasedTableExpr, results []*Column, names sqlparser.Columns) error {
+	if expr.As.NotEmpty() {
+		table.Name = sqlparser.NewTableName(expr.As.String())
+	}
+	if len(expr.Columns) > 0 {
+		names = expr.Columns
+	}
+	var err error
+	table.Columns, err = derive(table, results, names)
+	return err
+}
+
+// jsonTable adds the table of a JSON_TABLE table function to a scope. Its
+// document can refer to the tables before it.
+func (b *binder) jsonTable(expr *sqlparser.JSONTableExpr, sc *scope) error {
+	if err := b.expr(expr.Expr, sc, fromClause); err != nil {
+		return err
+	}
+	table := &Table{Kind: JSONTable, Name: sqlparser.NewTableName(expr.Alias.String()), Expr: expr}
+	var add func(defs []*sqlparser.JtColumnDefinition)
+	add = func(defs []*sqlparser.JtColumnDefinition) {
+		for _, def := range defs {
+			switch {
+			case def.JtOrdinal != nil:
+				table.Columns = append(table.Columns, &Column{Table: table, Name: def.JtOrdinal.Name.String()})
+			case def.JtPath != nil:
+				table.Columns = append(table.Columns, &Column{Table: table, Name: def.JtPath.Name.String()})
+			case def.JtNestedPath != nil:
+				add(def.JtNestedPath.Columns)
+			}
+		}
+	}
+	add(expr.Columns)
+	return b.add(table, sc)
+}
+
+// expr binds the column references of an expression, or of another node
+// made of expressions.
+func (b *binder) expr(node sqlparser.SQLNode, sc *scope, c clause) error {
+	return sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Subquery:
+			_, err := b.selectStatement(node.Select, sc)
+			return false, err
+		case *sqlparser.ColName:
+			return false, b.column(node, sc, c)
+		}
+		return true, nil
+	}, node)
+}
+
+// column binds a column reference of a clause. References are looked up in
+// the tables of the query block first, and then in those of the enclosing
+// query blocks, which makes the subquery correlated. ORDER BY refers to the
+// aliases of the select list before the columns of the tables, and GROUP BY
+// and HAVING after them.
+func (b *binder) column(col *sqlparser.ColName, sc *scope, c clause) error {
+	if c == orderClause {
+		if result := sc.result(col); result != nil {
+			b.bindings.columns[col] = result
+			return nil
+		}
+	}
+	for s := sc; s != nil; s = s.parent {
+		switch found := s.lookup(col); {
+		case len(found) == 1:
+			b.bindings.columns[col] = found[0]
+			return nil
+		case len(found) > 1:
+			return nonUniqError(columnName(col), c)
+		}
+		if s == sc && (c == groupClause || c == havingClause) {
+			if result := sc.result(col); result != nil {
+				b.bindings.columns[col] = result
+				return nil
+			}
+		}
+	}
+	return badFieldError(columnName(col), c)
+}
+
+// limit binds the expressions of a LIMIT clause.
+func (b *binder) limit(limit *sqlparser.Limit, sc *scope) error {
+	if limit == nil {
+		return nil
+	}
+	return b.expr(limit, sc, fieldList)
+}
+
+// insert binds an INSERT or REPLACE statement.
+func (b *binder) insert(stmt *sqlparser.Insert) error {
+	sc := &scope{}
+	table, err := b.table(stmt.Table, sc)
+	if err != nil {
+		return err
+	}
+	if table == nil {
+		return noSuchTableError("", "dual")
+	}
+	if err := b.add(table, sc); err != nil {
+		return err
+	}
+	for _, name := range stmt.Columns {
+		if table.Column(name.String()) == nil {
+			return badFieldError(name.String(), fieldList)
+		}
+	}
+
+	switch rows := stmt.Rows.(type) {
+	case sqlparser.Values:
+		for _, row := range rows {
+			for _, expr := range row {
+				if err := b.expr(expr, sc, fieldList); err != nil {
+					return err
+				}
+			}
+		}
+	case sqlparser.SelectStatement:
+		if _, err := b.selectStatement(rows, nil); err != nil {
+			return err
+		}
+		// ON DUPLICATE KEY UPDATE can refer to the tables of the query.
+		if sel, ok := rows.(*sqlparser.Select); ok {
+			sc.parent = &scope{tables: b.scopes[sel].tables}
+		}
+	}
+
+	// ON DUPLICATE KEY UPDATE can refer to the inserted row by its alias.
+	// Unqualified columns refer to the table, so the alias is looked up
+	// last.
+	if stmt.RowAlias != nil {
+		alias := &Table{Kind: table.Kind, Name: sqlparser.NewTableName(stmt.RowAlias.TableName.String()), Expr: table.Expr, Schema: table.Schema, View: table.View, Database: table.Database}
+		if len(stmt.RowAlias.Columns) > 0 && len(stmt.RowAlias.Columns) != len(table.Columns) {
+			return viewWrongListError()
+		}
+		for i, col := range table.Columns {
+			name := col.Name
+			if len(stmt.RowAlias.Columns) > 0 {
+				name = stmt.RowAlias.Columns[i].String()
+			}
+			alias.Columns = append(alias.Columns, &Column{Table: alias, Name: name, Definition: col.Definition, Exprs: col.Exprs})
+		}
+		sc.parent = &scope{tables: []*Table{alias}, parent: sc.parent}
+	}
+	for _, expr := range stmt.OnDup {
+		if err := b.set(expr, sc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update binds an UPDATE statement.
+func (b *binder) update(stmt *sqlparser.Update) error {
+	sc := &scope{}
+	if err := b.with(stmt.With, sc); err != nil {
+		return err
+	}
+	for _, expr := range stmt.TableExprs {
+		if err := b.tableExpr(expr, sc); err != nil {
+			return err
+		}
+	}
+	for _, expr := range stmt.Exprs {
+		if err := b.set(expr, sc); err != nil {
+			return err
+		}
+	}
+	return b.filter(stmt.Where, stmt.OrderBy, stmt.Limit, sc)
+}
+
+// delete binds a DELETE statement.
+func (b *binder) delete(stmt *sqlparser.Delete) error {
+	sc := &scope{}
+	if err := b.with(stmt.With, sc); err != nil {
+		return err
+	}
+	for _, expr := range stmt.TableExprs {
+		if err := b.tableExpr(expr, sc); err != nil {
+			return err
+		}
+	}
+	for _, target := range stmt.Targets {
+		found := false
+		for _, table := range sc.tables {
+			found = found || table.matches(target)
+		}
+		if !found {
+			return unknownTableError(target.Name.String(), "MULTI DELETE")
+		}
+	}
+	return b.filter(stmt.Where, stmt.OrderBy, stmt.Limit, sc)
+}
+
+// set binds an assignment of an UPDATE or ON DUPLICATE KEY UPDATE clause.
+func (b *binder) set(expr *sqlparser.UpdateExpr, sc *scope) error {
+	if err := b.column(expr.Name, sc, fieldList); err != nil {
+		return err
+	}
+	return b.expr(expr.Expr, sc, fieldList)
+}
+
+// filter binds the WHERE, ORDER BY and LIMIT clauses of an UPDATE or DELETE
+// statement.
+func (b *binder) filter(where *sqlparser.Where, orderBy sqlparser.OrderBy, limit *sqlparser.Limit, sc *scope) error {
+	if where != nil {
+		if err := b.expr(where.Expr, sc, whereClause); err != nil {
+			return err
+		}
+	}
+	for _, o := range orderBy {
+		if err := b.expr(o.Expr, sc, orderClause); err != nil {
+			return err
+		}
+	}
+	return b.limit(limit, sc)
+}
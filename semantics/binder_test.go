@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+func testSchema(t *testing.T) *schema.Schema {
+	t.Helper()
+	s, err := schema.NewFromSQL(sqlparser.NewTestParser(), `
+		create database shop;
+		use shop;
+		create table customer (id int primary key, name varchar(50), email varchar(100));
+		create table orders (id bigint primary key, customer_id int not null, total decimal(10,2), created_at datetime);
+		create table item (id bigint primary key, order_id bigint, name varchar(50), price decimal(10,2));
+		create view big_orders (order_id, amount) as select id, total from orders where total > 100;
+		create database crm;
+		create table crm.note (id int primary key, customer_id int, body text);
+		use shop;
+	`)
+	require.NoError(t, err)
+	return s
+}
+
+// columnBindings returns the columns the column references of a statement
+// are bound to, in order.
+func columnBindings(b *Bindings, stmt sqlparser.Statement) []string {
+	var bound []string
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			bound = append(bound, fmt.Sprintf("%s: %v", columnName(col), b.Column(col)))
+		}
+		return true, nil
+	}, stmt)
+	return bound
+}
+
+func TestBind(t *testing.T) {
+	testcases := []struct {
+		sql     string
+		columns []string
+	}{{
+		sql:     "select id, name from customer where email like '%@example.com'",
+		columns: []string{"id: shop.customer.id", "name: shop.customer.name", "email: shop.customer.email"},
+	}, {
+		sql:     "select c.name, o.total from customer c join orders o on o.customer_id = c.id",
+		columns: []string{"o.customer_id: o.customer_id", "c.id: c.id", "c.name: c.name", "o.total: o.total"},
+	}, {
+		sql:     "select shop.customer.name, crm.note.body from customer join crm.note on note.customer_id = customer.id",
+		columns: []string{"note.customer_id: crm.note.customer_id", "customer.id: shop.customer.id", "shop.customer.name: shop.customer.name", "crm.note.body: crm.note.body"},
+	}, {
+		sql:     "select id, customer_id from orders join crm.note using (id, customer_id)",
+		columns: []string{"id: shop.orders.id", "customer_id: shop.orders.customer_id"},
+	}, {
+		sql:     "select id, note.id, body from orders natural join crm.note",
+		columns: []string{"id: shop.orders.id", "note.id: crm.note.id", "body: crm.note.body"},
+	}, {
+		sql:     "select x.n, orders.total from (select name as n, id from customer) as x join orders on orders.customer_id = x.id",
+		columns: []string{"name: shop.customer.name", "id: shop.customer.id", "orders.customer_id: shop.orders.customer_id", "x.id: x.id", "x.n: x.n", "orders.total: shop.orders.total"},
+	}, {
+		sql:     "select c.id, l.total from customer c, lateral (select sum(total) as total from orders where customer_id = c.id) as l",
+		columns: []string{"total: shop.orders.total", "customer_id: shop.orders.customer_id", "c.id: c.id", "c.id: c.id", "l.total: l.total"},
+	}, {
+		sql:     "with recent (cid) as (select customer_id from orders where created_at > now()) select name from customer where id in (select cid from recent)",
+		columns: []string{"customer_id: shop.orders.customer_id", "created_at: shop.orders.created_at", "name: shop.customer.name", "id: shop.customer.id", "cid: recent.cid"},
+	}, {
+		sql:     "with recursive seq (n) as (select 1 union all select n + 1 from seq where n < 10) select n from seq",
+		columns: []string{"n: seq.n", "n: seq.n", "n: seq.n"},
+	}, {
+		sql:     "select name from customer c where exists (select 1 from orders where customer_id = c.id and id > 10)",
+		columns: []string{"name: c.name", "customer_id: shop.orders.customer_id", "c.id: c.id", "id: shop.orders.id"},
+	}, {
+		sql:     "select order_id, amount from big_orders",
+		columns: []string{"order_id: shop.big_orders.order_id", "amount: shop.big_orders.amount"},
+	}, {
+		sql:     "select customer_id as cid, count(*) as n from orders group by cid having n > 1 order by total",
+		columns: []string{"customer_id: shop.orders.customer_id", "cid: cid", "n: n", "total: shop.orders.total"},
+	}, {
+		sql:     "select id as total from orders group by total order by total",
+		columns: []string{"id: shop.orders.id", "total: shop.orders.total", "total: total"},
+	}, {
+		sql:     "select id from customer union select id from orders order by id",
+		columns: []string{"id: shop.customer.id", "id: shop.orders.id", "id: id"},
+	}, {
+		sql:     "select row_number() over w, id from orders window w as (partition by customer_id order by created_at)",
+		columns: []string{"id: shop.orders.id", "customer_id: shop.orders.customer_id", "created_at: shop.orders.created_at"},
+	}, {
+		sql:     "select j.sku from orders, json_table(orders.created_at, '$[*]' columns (sku varchar(10) path '$.sku')) as j",
+		columns: []string{"orders.created_at: shop.orders.created_at", "j.sku: j.sku"},
+	}, {
+		sql:     "insert into orders (id, customer_id, total) values (1, 2, 3) as new on duplicate key update total = new.total + total",
+		columns: []string{"total: shop.orders.total", "new.total: new.total", "total: shop.orders.total"},
+	}, {
+		sql:     "insert into orders (id, customer_id) select id, id from customer on duplicate key update customer_id = customer.id",
+		columns: []string{"id: shop.customer.id", "id: shop.customer.id", "customer_id: shop.orders.customer_id", "customer.id: shop.customer.id"},
+	}, {
+		sql:     "update orders o join customer c on c.id = o.customer_id set o.total = 0 where c.email is null",
+		columns: []string{"c.id: c.id", "o.customer_id: o.customer_id", "o.total: o.total", "c.email: c.email"},
+	}, {
+		sql:     "delete o from orders o join customer c on c.id = o.customer_id where c.name = 'x'",
+		columns: []string{"c.id: c.id", "o.customer_id: o.customer_id", "c.name: c.name"},
+	}}
+
+	s := testSchema(t)
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := parser.Parse(tcase.sql)
+			require.NoError(t, err)
+			b, err := Bind(s, stmt)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.columns, columnBindings(b, stmt))
+		})
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	testcases := []struct {
+		sql string
+		err string
+	}{{
+		sql: "select nope from customer",
+		err: "Unknown column 'nope' in 'field list' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "select id from customer join orders on customer.id = orders.customer_id",
+		err: "Column 'id' in field list is ambiguous (errno 1052) (sqlstate 23000)",
+	}, {
+		sql: "select customer.id from customer c",
+		err: "Unknown column 'customer.id' in 'field list' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "select c.name from customer c join orders o on o.customer_id = c.id order by shop.o.id",
+		err: "Unknown column 'shop.o.id' in 'order clause' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "select 1 from customer c, orders o join item i on i.order_id = c.id",
+		err: "Unknown column 'c.id' in 'on clause' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "select 1 from customer where id in (select id from orders where total > 0) and name = total",
+		err: "Unknown column 'total' in 'where clause' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "select 1 from customer, (select id from orders where customer_id = customer.id) x",
+		err: "Unknown column 'customer.id' in 'where clause' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "select 1 from customer join item using (id) group by name",
+		err: "Column 'name' in group statement is ambiguous (errno 1052) (sqlstate 23000)",
+	}, {
+		sql: "select 1 from customer join orders using (email)",
+		err: "Unknown column 'email' in 'from clause' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "select * from missing",
+		err: "Table 'shop.missing' doesn't exist (errno 1146) (sqlstate 42S02)",
+	}, {
+		sql: "select o.* from customer",
+		err: "Unknown table 'o' (errno 1051) (sqlstate 42S02)",
+	}, {
+		sql: "select * from customer, orders customer",
+		err: "Not unique table/alias: 'customer' (errno 1066) (sqlstate 42000)",
+	}, {
+		sql: "select * from (select c.id, o.id from customer c, orders o) x",
+		err: "Duplicate column name 'id' (errno 1060) (sqlstate 42S21)",
+	}, {
+		sql: "select id from customer union select id, name from customer",
+		err: "The used SELECT statements have a different number of columns (errno 1222) (sqlstate 21000)",
+	}, {
+		sql: "select id from customer union select id from orders order by customer.id",
+		err: "Unknown column 'customer.id' in 'order clause' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "with c (a, b) as (select id from customer) select * from c",
+		err: "In definition of view, derived table or common table expression, SELECT list and column names list have different column counts (errno 1353) (sqlstate HY000)",
+	}, {
+		sql: "insert into orders (id, nope) values (1, 2)",
+		err: "Unknown column 'nope' in 'field list' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "update orders set nope = 1",
+		err: "Unknown column 'nope' in 'field list' (errno 1054) (sqlstate 42S22)",
+	}, {
+		sql: "delete x from orders o",
+		err: "Unknown table 'x' in MULTI DELETE (errno 1109) (sqlstate 42S02)",
+	}}
+
+	s := testSchema(t)
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := parser.Parse(tcase.sql)
+			require.NoError(t, err)
+			_, err = Bind(s, stmt)
+			assert.EqualError(t, err, tcase.err)
+		})
+	}
+}
+
+func TestBindTables(t *testing.T) {
+	stmt, err := sqlparser.NewTestParser().Parse(`with recent as (select * from orders)
+		select * from customer c left join recent r on r.customer_id = c.id, (select 1 as one) d, big_orders`)
+	require.NoError(t, err)
+	b, err := Bind(testSchema(t), stmt)
+	require.NoError(t, err)
+
+	var tables []string
+	for _, table := range b.Tables() {
+		tables = append(tables, fmt.Sprintf("%s %s nullable=%t", table.Kind, tableName(table.Name), table.Nullable))
+	}
+	assert.Equal(t, []string{
+		"base table shop.orders nullable=false",
+		"base table c nullable=false",
+		"common table expression r nullable=true",
+		"derived table d nullable=false",
+		"base table shop.orders nullable=false",
+		"view shop.big_orders nullable=false",
+	}, tables)
+
+	var results []string
+	for _, col := range b.Result(stmt.(*sqlparser.Select)) {
+		results = append(results, fmt.Sprintf("%s <- %s", col.Name, sqlparser.String(col.Exprs[0])))
+	}
+	assert.Equal(t, []string{
+		"id <- c.id", "name <- c.`name`", "email <- c.email",
+		"id <- r.id", "customer_id <- r.customer_id", "total <- r.total", "created_at <- r.created_at",
+		"one <- d.one",
+		"order_id <- shop.big_orders.order_id", "amount <- shop.big_orders.amount",
+	}, results)
+}
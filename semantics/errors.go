@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/mysql/sqlerror"
+)
+
+// The errors reported by the binder are MySQL errors, with the error
+// numbers, SQL states and messages MySQL reports for the same statements.
+
+// clause is the part of a statement an expression appears in, as named by
+// MySQL error messages.
+type clause string
+
+const (
+	fieldList    clause = "field list"
+	fromClause   clause = "from clause"
+	onClause     clause = "on clause"
+	whereClause  clause = "where clause"
+	groupClause  clause = "group statement"
+	havingClause clause = "having clause"
+	orderClause  clause = "order clause"
+)
+
+// columnName returns the name of a column reference as written.
+func columnName(col *sqlparser.ColName) string {
+	var parts []string
+	if col.Qualifier.Qualifier.NotEmpty() {
+		parts = append(parts, col.Qualifier.Qualifier.String())
+	}
+	if col.Qualifier.Name.NotEmpty() {
+		parts = append(parts, col.Qualifier.Name.String())
+	}
+	return strings.Join(append(parts, col.Name.String()), ".")
+}
+
+// tableName returns the name of a table as written.
+func tableName(name sqlparser.TableName) string {
+	if name.Qualifier.IsEmpty() {
+		return name.Name.String()
+	}
+	return name.Qualifier.String() + "." + name.Name.String()
+}
+
+func badFieldError(column string, c clause) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERBadFieldError, sqlerror.SSBadFieldError, "Unknown column '%s' in '%s'", column, c)
+}
+
+func nonUniqError(column string, c clause) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERNonUniq, sqlerror.SSConstraintViolation, "Column '%s' in %s is ambiguous", column, c)
+}
+
+func noSuchTableError(db, table string) error {
+	if db == "" {
+		// The unnamed default database of a schema is not printed.
+		return sqlerror.NewSQLErrorf(sqlerror.ERNoSuchTable, sqlerror.SSUnknownTable, "Table '%s' doesn't exist", table)
+	}
+	return sqlerror.NewSQLErrorf(sqlerror.ERNoSuchTable, sqlerror.SSUnknownTable, "Table '%s.%s' doesn't exist", db, table)
+}
+
+func noDBError() error {
+	return sqlerror.NewSQLError(sqlerror.ERNoDb, sqlerror.SSNoDB, "No database selected")
+}
+
+func badTableError(table string) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERBadTable, sqlerror.SSUnknownTable, "Unknown table '%s'", table)
+}
+
+func unknownTableError(table, statement string) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERUnknownTable, sqlerror.SSUnknownTable, "Unknown table '%s' in %s", table, statement)
+}
+
+func nonUniqTableError(table string) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERNonUniqTable, sqlerror.SSClientError, "Not unique table/alias: '%s'", table)
+}
+
+func dupFieldNameError(column string) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERDupFieldName, sqlerror.SSDupFieldName, "Duplicate column name '%s'", column)
+}
+
+func noTablesUsedError() error {
+	return sqlerror.NewSQLError(sqlerror.ERNoTablesUsed, sqlerror.SSUnknownSQLState, "No tables used")
+}
+
+func wrongNumberOfColumnsError() error {
+	return sqlerror.NewSQLError(sqlerror.ERWrongNumberOfColumnsInSelect, sqlerror.SSWrongNumberOfColumns, "The used SELECT statements have a different number of columns")
+}
+
+func viewWrongListError() error {
+	return sqlerror.NewSQLError(sqlerror.ERViewWrongList, sqlerror.SSUnknownSQLState, "In definition of view, derived table or common table expression, SELECT list and column names list have different column counts")
+}
@@ -0,0 +1,428 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// LineageKind is how a source column contributes to an output column.
+type LineageKind int
+
+const (
+	// Direct is a copy of the source column.
+	Direct LineageKind = iota
+	// Transformation is the value of an expression of the source column.
+	Transformation
+	// Aggregation is an aggregate of the values of the source column.
+	Aggregation
+	// Filter is a source column the rows are filtered, joined or grouped by,
+	// which does not contribute to the values.
+	Filter
+)
+
+func (k LineageKind) String() string {
+	switch k {
+	case Direct:
+		return "direct"
+	case Transformation:
+		return "transformation"
+	case Aggregation:
+		return "aggregation"
+	case Filter:
+		return "filter"
+	}
+	return "unknown"
+}
+
+// Source is a column of a base table an output column depends on.
+type Source struct {
+	// Table is the qualified name of the base table.
+	Table  sqlparser.TableName
+	Column string
+	Kind   LineageKind
+}
+
+func (s Source) String() string {
+	return fmt.Sprintf("%s.%s (%s)", tableName(s.Table), s.Column, s.Kind)
+}
+
+// ColumnLineage is the lineage of an output column.
+type ColumnLineage struct {
+	Name string
+	// Sources are the base table columns the column depends on, in the order
+	// they are found. A column that contributes in several ways appears once
+	// for each kind.
+	Sources []Source
+}
+
+// Lineage is the column lineage of a statement.
+type Lineage struct {
+	// Target is the table or view the statement writes. It is empty for
+	// queries.
+	Target sqlparser.TableName
+	// Columns are the output columns in order.
+	Columns []*ColumnLineage
+}
+
+// Column returns the lineage of the output column with the given name, or
+// nil.
+func (l *Lineage) Column(name string) *ColumnLineage {
+	for _, col := range l.Columns {
+		if strings.EqualFold(col.Name, name) {
+			return col
+		}
+	}
+	return nil
+}
+
+// ErrCreateTableSelect is returned for CREATE TABLE ... SELECT statements,
+// whose query the parser does not keep. QueryLineage gives their lineage
+// from the query parsed on its own.
+var ErrCreateTableSelect = errors.New("the query of CREATE TABLE ... SELECT is not part of the parsed statement")
+
+// StatementLineage returns the column lineage of a query, an INSERT or a
+// CREATE VIEW or ALTER VIEW statement. Views are traced through to their
+// base tables.
+func StatementLineage(s *schema.Schema, stmt sqlparser.Statement) (*Lineage, error) {
+	b, err := Bind(s, stmt)
+	if err != nil {
+		return nil, err
+	}
+	l := newLineager(b)
+	switch stmt := stmt.(type) {
+	case sqlparser.SelectStatement:
+		return l.query(sqlparser.TableName{}, nil, stmt), nil
+	case *sqlparser.CreateView:
+		return l.query(qualify(s, stmt.ViewName), stmt.Columns, stmt.Select), nil
+	case *sqlparser.AlterView:
+		return l.query(qualify(s, stmt.ViewName), stmt.Columns, stmt.Select), nil
+	case *sqlparser.Insert:
+		return l.insert(stmt), nil
+	case *sqlparser.CreateTable:
+		return nil, ErrCreateTableSelect
+	}
+	return nil, fmt.Errorf("unsupported statement: %s", sqlparser.CanonicalString(stmt))
+}
+
+// QueryLineage returns the column lineage of a query writing to a table,
+// such as the query of CREATE TABLE ... SELECT. The names rename the result
+// columns of the query if given.
+func QueryLineage(s *schema.Schema, target sqlparser.TableName, names sqlparser.Columns, query sqlparser.SelectStatement) (*Lineage, error) {
+	b, err := Bind(s, query)
+	if err != nil {
+		return nil, err
+	}
+	return newLineager(b).query(qualify(s, target), names, query), nil
+}
+
+// qualify returns a table name qualified with the current database.
+func qualify(s *schema.Schema, name sqlparser.TableName) sqlparser.TableName {
+	if db, ok := s.CurrentDatabase(); ok && name.Qualifier.IsEmpty() && name.NonEmpty() {
+		name.Qualifier = sqlparser.NewIdentifierCS(db)
+	}
+	return name
+}
+
+// lineager traces the output columns of a bound statement to the columns
+// of base tables.
+type lineager struct {
+	bindings *Bindings
+	// sources collects the sources of the output column being traced.
+	sources []Source
+	seen    map[Source]bool
+	// columns and queries hold the columns and queries being traced, which
+	// recursive common table expressions refer to again.
+	columns map[*Column]bool
+	queries map[sqlparser.SelectStatement]bool
+}
+
+func newLineager(b *Bindings) *lineager {
+	return &lineager{bindings: b}
+}
+
+// output returns the lineage of an output column with the given sources.
+func (l *lineager) output(name string, trace func()) *ColumnLineage {
+	l.sources, l.seen = nil, map[Source]bool{}
+	l.columns, l.queries = map[*Column]bool{}, map[sqlparser.SelectStatement]bool{}
+	trace()
+	return &ColumnLineage{Name: name, Sources: l.sources}
+}
+
+func (l *lineager) query(target sqlparser.TableName, names sqlparser.Columns, stmt sqlparser.SelectStatement) *Lineage {
+	lineage := &Lineage{Target: target}
+	for i, col := range l.bindings.Result(stmt) {
+		name := col.Name
+		if i < len(names) {
+			name = names[i].String()
+		}
+		lineage.Columns = append(lineage.Columns, l.output(name, func() {
+			l.column(col, Direct)
+			l.filters(stmt, Direct)
+		}))
+	}
+	return lineage
+}
+
+// insert returns the lineage of the columns an INSERT writes, which are
+// given by the rows of its query or VALUES.
+func (l *lineager) insert(stmt *sqlparser.Insert) *Lineage {
+	table := l.bindings.Table(stmt.Table)
+	lineage := &Lineage{Target: table.Name}
+	if table.Schema != nil {
+		lineage.Target = sqlparser.NewTableNameWithQualifier(table.Schema.Name(), table.Database)
+	}
+	var names []string
+	for _, col := range stmt.Columns {
+		names = append(names, col.String())
+	}
+	if len(names) == 0 {
+		for _, col := range table.Columns {
+			names = append(names, col.Name)
+		}
+	}
+
+	switch rows := stmt.Rows.(type) {
+	case sqlparser.SelectStatement:
+		results := l.bindings.Result(rows)
+		for i, name := range names {
+			if i >= len(results) {
+				break
+			}
+			lineage.Columns = append(lineage.Columns, l.output(name, func() {
+				l.column(results[i], Direct)
+				l.filters(rows, Direct)
+			}))
+		}
+	case sqlparser.Values:
+		for i, name := range names {
+			lineage.Columns = append(lineage.Columns, l.output(name, func() {
+				for _, row := range rows {
+					if i < len(row) {
+						l.expr(row[i], Direct)
+					}
+				}
+			}))
+		}
+	}
+	return lineage
+}
+
+// add adds a source of the output column.
+func (l *lineager) add(col *Column, kind LineageKind) {
+	source := Source{
+		Table:  sqlparser.NewTableNameWithQualifier(col.Table.Schema.Name(), col.Table.Database),
+		Column: col.Name,
+		Kind:   kind,
+	}
+	if !l.seen[source] {
+		l.seen[source] = true
+		l.sources = append(l.sources, source)
+	}
+}
+
+// column traces a column. The kind is how the column contributes to the
+// output column.
+func (l *lineager) column(col *Column, kind LineageKind) {
+	if l.columns[col] {
+		return
+	}
+	l.columns[col] = true
+	defer delete(l.columns, col)
+
+	table := col.Table
+	switch {
+	case table != nil && table.Kind == BaseTable:
+		l.add(col, kind)
+		return
+	case table != nil && table.Kind == JSONTable:
+		// The columns of JSON_TABLE are extracted from its document.
+		l.expr(table.Expr.(*sqlparser.JSONTableExpr).Expr, max(kind, Transformation))
+		return
+	}
+	for _, expr := range col.Exprs {
+		l.expr(expr, kind)
+	}
+	if table != nil && table.Select != nil {
+		l.filters(table.Select, kind)
+	}
+}
+
+// expr traces the columns of an expression. A column reference is a copy
+// of the column, other expressions transform the columns they refer to.
+func (l *lineager) expr(expr sqlparser.Expr, kind LineageKind) {
+	if col, ok := expr.(*sqlparser.ColName); ok {
+		if bound := l.bindings.Column(col); bound != nil {
+			l.column(bound, kind)
+		}
+		return
+	}
+	l.walk(expr, max(kind, Transformation))
+}
+
+// walk traces the columns of an expression with the given kind, which
+// aggregate functions turn into Aggregation.
+func (l *lineager) walk(node sqlparser.SQLNode, kind LineageKind) {
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			if bound := l.bindings.Column(node); bound != nil {
+				l.column(bound, kind)
+			}
+		case *sqlparser.Subquery:
+			for _, col := range l.bindings.Result(node.Select) {
+				l.column(col, kind)
+			}
+			l.filters(node.Select, kind)
+			return false, nil
+		case *sqlparser.OverClause:
+			// The rows of a window transform the value of the function
+			// whatever the function is.
+			if node.WindowSpec != nil {
+				l.walk(node.WindowSpec, max(kind, Transformation))
+			}
+			return false, nil
+		case sqlparser.AggrFunc:
+			if kind < Aggregation {
+				l.aggregate(node, kind)
+				return false, nil
+			}
+		}
+		return true, nil
+	}, node)
+}
+
+// aggregate traces the arguments of an aggregate function, and the rows of
+// its window with the given kind.
+func (l *lineager) aggregate(aggr sqlparser.AggrFunc, kind LineageKind) {
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.OverClause:
+			l.walk(node, kind)
+			return false, nil
+		case sqlparser.Expr:
+			if node != aggr {
+				l.walk(node, Aggregation)
+				return false, nil
+			}
+		}
+		return true, nil
+	}, aggr)
+}
+
+// filters traces the columns a query filters, joins and groups its rows by.
+func (l *lineager) filters(stmt sqlparser.SelectStatement, kind LineageKind) {
+	if l.queries[stmt] {
+		return
+	}
+	l.queries[stmt] = true
+	defer delete(l.queries, stmt)
+
+	kind = max(kind, Filter)
+	switch stmt := stmt.(type) {
+	case *sqlparser.Union:
+		l.filters(stmt.Left, kind)
+		l.filters(stmt.Right, kind)
+	case *sqlparser.Select:
+		for _, expr := range stmt.From {
+			l.joins(expr, kind)
+		}
+		if stmt.Where != nil {
+			l.walk(stmt.Where.Expr, kind)
+		}
+		if stmt.GroupBy != nil {
+			for _, expr := range stmt.GroupBy.Exprs {
+				l.walk(expr, kind)
+			}
+		}
+		if stmt.Having != nil {
+			l.walk(stmt.Having.Expr, kind)
+		}
+	}
+}
+
+// joins traces the columns the tables of a FROM clause are joined by.
+func (l *lineager) joins(expr sqlparser.TableExpr, kind LineageKind) {
+	switch expr := expr.(type) {
+	case *sqlparser.ParenTableExpr:
+		for _, expr := range expr.Exprs {
+			l.joins(expr, kind)
+		}
+	case *sqlparser.AliasedTableExpr:
+		// Only the rows of a derived table that pass its filters are joined.
+		if table := l.bindings.Table(expr); table != nil && table.Select != nil {
+			l.filters(table.Select, kind)
+		}
+	case *sqlparser.JoinTableExpr:
+		l.joins(expr.LeftExpr, kind)
+		l.joins(expr.RightExpr, kind)
+		left, right := l.tables(expr.LeftExpr), l.tables(expr.RightExpr)
+		var using []string
+		switch expr.Join {
+		case sqlparser.NaturalJoinType, sqlparser.NaturalLeftJoinType, sqlparser.NaturalRightJoinType:
+			for _, table := range right {
+				for _, col := range table.Columns {
+					using = append(using, col.Name)
+				}
+			}
+		}
+		if expr.Condition != nil {
+			for _, name := range expr.Condition.Using {
+				using = append(using, name.String())
+			}
+			if expr.Condition.On != nil {
+				l.walk(expr.Condition.On, kind)
+			}
+		}
+		for _, name := range using {
+			var cols []*Column
+			for _, tables := range [][]*Table{left, right} {
+				for _, table := range tables {
+					if col := table.Column(name); col != nil {
+						cols = append(cols, col)
+					}
+				}
+			}
+			if len(cols) > 1 {
+				for _, col := range cols {
+					l.column(col, kind)
+				}
+			}
+		}
+	}
+}
+
+// tables returns the tables of a table expression.
+func (l *lineager) tables(expr sqlparser.TableExpr) []*Table {
+	var tables []*Table
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.AliasedTableExpr, *sqlparser.JSONTableExpr:
+			if table := l.bindings.Table(node.(sqlparser.TableExpr)); table != nil {
+				tables = append(tables, table)
+			}
+			return false, nil
+		}
+		return true, nil
+	}, expr)
+	return tables
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestStatementLineage(t *testing.T) {
+	testcases := []struct {
+		sql     string
+		target  string
+		columns map[string][]string
+	}{{
+		sql: "select c.name as customer, o.total * 2 as doubled from customer c join orders o on o.customer_id = c.id where o.total > 10",
+		columns: map[string][]string{
+			"customer": {"shop.customer.name (direct)", "shop.orders.customer_id (filter)", "shop.customer.id (filter)", "shop.orders.total (filter)"},
+			"doubled":  {"shop.orders.total (transformation)", "shop.orders.customer_id (filter)", "shop.customer.id (filter)", "shop.orders.total (filter)"},
+		},
+	}, {
+		sql: "select customer_id, sum(total) as spent, count(*) as n from orders group by customer_id",
+		columns: map[string][]string{
+			"customer_id": {"shop.orders.customer_id (direct)", "shop.orders.customer_id (filter)"},
+			"spent":       {"shop.orders.total (aggregation)", "shop.orders.customer_id (filter)"},
+			"n":           {"shop.orders.customer_id (filter)"},
+		},
+	}, {
+		sql: "with recursive ids (n) as (select id from customer union all select n + 1 from ids where n < 10) select x.n from (select n from ids) as x",
+		columns: map[string][]string{
+			"n": {"shop.customer.id (direct)", "shop.customer.id (transformation)", "shop.customer.id (filter)"},
+		},
+	}, {
+		sql: "select *, row_number() over (partition by customer_id order by created_at) as rn from big_orders join orders on orders.id = big_orders.order_id",
+		columns: map[string][]string{
+			"order_id":    {"shop.orders.id (direct)", "shop.orders.total (filter)", "shop.orders.id (filter)"},
+			"amount":      {"shop.orders.total (direct)", "shop.orders.total (filter)", "shop.orders.id (filter)"},
+			"id":          {"shop.orders.id (direct)", "shop.orders.total (filter)", "shop.orders.id (filter)"},
+			"customer_id": {"shop.orders.customer_id (direct)", "shop.orders.total (filter)", "shop.orders.id (filter)"},
+			"total":       {"shop.orders.total (direct)", "shop.orders.total (filter)", "shop.orders.id (filter)"},
+			"created_at":  {"shop.orders.created_at (direct)", "shop.orders.total (filter)", "shop.orders.id (filter)"},
+			"rn":          {"shop.orders.customer_id (transformation)", "shop.orders.created_at (transformation)", "shop.orders.total (filter)", "shop.orders.id (filter)"},
+		},
+	}, {
+		sql: "select name from customer union select body from crm.note",
+		columns: map[string][]string{
+			"name": {"shop.customer.name (direct)", "crm.note.body (direct)"},
+		},
+	}, {
+		sql: "select sum(total) over (partition by customer_id) as running, (select max(price) from item where item.order_id = orders.id) as top from orders",
+		columns: map[string][]string{
+			"running": {"shop.orders.total (aggregation)", "shop.orders.customer_id (transformation)"},
+			"top":     {"shop.item.price (aggregation)", "shop.item.order_id (filter)", "shop.orders.id (filter)"},
+		},
+	}, {
+		sql:    "insert into crm.note (id, body) select id, concat(name, email) from customer where id in (select customer_id from orders)",
+		target: "crm.note",
+		columns: map[string][]string{
+			"id":   {"shop.customer.id (direct)", "shop.customer.id (filter)", "shop.orders.customer_id (filter)"},
+			"body": {"shop.customer.name (transformation)", "shop.customer.email (transformation)", "shop.customer.id (filter)", "shop.orders.customer_id (filter)"},
+		},
+	}, {
+		sql:    "insert into item (id, name) values (1, 'a'), (2, (select name from customer limit 1))",
+		target: "shop.item",
+		columns: map[string][]string{
+			"id":   nil,
+			"name": {"shop.customer.name (transformation)"},
+		},
+	}, {
+		sql:    "create view names (n, m) as select name, upper(name) from customer join crm.note using (id)",
+		target: "shop.names",
+		columns: map[string][]string{
+			"n": {"shop.customer.name (direct)", "shop.customer.id (filter)", "crm.note.id (filter)"},
+			"m": {"shop.customer.name (transformation)", "shop.customer.id (filter)", "crm.note.id (filter)"},
+		},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := sqlparser.NewTestParser().Parse(tcase.sql)
+			require.NoError(t, err)
+			lineage, err := StatementLineage(testSchema(t), stmt)
+			require.NoError(t, err)
+
+			assert.Equal(t, tcase.target, tableName(lineage.Target))
+			columns := map[string][]string{}
+			for _, col := range lineage.Columns {
+				var sources []string
+				for _, source := range col.Sources {
+					sources = append(sources, source.String())
+				}
+				columns[col.Name] = sources
+			}
+			assert.Equal(t, tcase.columns, columns)
+		})
+	}
+}
+
+func TestQueryLineage(t *testing.T) {
+	s := testSchema(t)
+	parser := sqlparser.NewTestParser()
+	stmt, err := parser.Parse("create table totals as select customer_id, sum(total) from orders group by customer_id")
+	require.NoError(t, err)
+	_, err = StatementLineage(s, stmt)
+	assert.ErrorIs(t, err, ErrCreateTableSelect)
+
+	stmt, err = parser.Parse("select customer_id, sum(total) from orders group by customer_id")
+	require.NoError(t, err)
+	lineage, err := QueryLineage(s, sqlparser.NewTableName("totals"), sqlparser.Columns{sqlparser.NewIdentifierCI("customer"), sqlparser.NewIdentifierCI("amount")}, stmt.(sqlparser.SelectStatement))
+	require.NoError(t, err)
+	assert.Equal(t, "shop.totals", tableName(lineage.Target))
+	require.NotNil(t, lineage.Column("amount"))
+	assert.Equal(t, []Source{
+		{Table: sqlparser.NewTableNameWithQualifier("orders", "shop"), Column: "total", Kind: Aggregation},
+		{Table: sqlparser.NewTableNameWithQualifier("orders", "shop"), Column: "customer_id", Kind: Filter},
+	}, lineage.Column("amount").Sources)
+	assert.Nil(t, lineage.Column("total"))
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"regexp"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// Parameter describes a bind variable of a statement.
+type Parameter struct {
+	// Name is the name of the bind variable. Positional placeholders are
+	// named v1, v2 and so on.
+	Name string
+	// Suggested is a name for the parameter. It is the name of the column
+	// the parameter is compared with or assigned to for positional
+	// placeholders, and the name of the bind variable otherwise.
+	Suggested string
+	// Type is the type of the values expected for the parameter, with
+	// sqltypes.Unknown if the context does not tell.
+	Type Type
+}
+
+// positional matches the names the parser gives to ? placeholders.
+var positional = regexp.MustCompile(`^v[0-9]+$`)
+
+// Parameters returns the bind variables of a statement in order, with the
+// types and names their context suggests. Without a schema, the types come
+// from the literals the parameters are compared with.
+func Parameters(s *schema.Schema, stmt sqlparser.Statement) ([]*Parameter, error) {
+	p := &parameters{
+		params: map[string]*Parameter{},
+		names:  sqlparser.NewReservedVars("v", sqlparser.BindVars{}),
+	}
+	if s != nil {
+		types, err := Infer(s, stmt)
+		if err != nil {
+			return nil, err
+		}
+		p.types = types
+	}
+
+	// Formatting visits the bind variables in the order of the query text.
+	var params []*Parameter
+	buf := sqlparser.NewTrackedBuffer(func(buf *sqlparser.TrackedBuffer, node sqlparser.SQLNode) {
+		if arg, ok := node.(*sqlparser.Argument); ok && p.params[arg.Name] == nil {
+			param := &Parameter{Name: arg.Name, Type: unknown()}
+			if arg.Type != sqltypes.Unknown {
+				param.Type = Type{Type: arg.Type, Collation: binaryCollation, Size: int(arg.Size), Scale: int(arg.Scale)}
+			}
+			p.params[arg.Name] = param
+			params = append(params, param)
+		}
+		node.Format(buf)
+	})
+	buf.Myprintf("%v", stmt)
+	for _, param := range params {
+		if !positional.MatchString(param.Name) {
+			p.names.ReserveAll(param.Name)
+			param.Suggested = param.Name
+		}
+	}
+
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		p.node(node)
+		return true, nil
+	}, stmt)
+	for _, param := range params {
+		if param.Suggested == "" {
+			param.Suggested = p.names.ReserveVariable(param.Name)
+		}
+	}
+	return params, nil
+}
+
+// parameters infers the types and names of the bind variables of a
+// statement.
+type parameters struct {
+	// types is nil without a schema.
+	types  *Types
+	params map[string]*Parameter
+	names  *sqlparser.ReservedVars
+}
+
+// node infers the types of the bind variables that are operands of a node.
+func (p *parameters) node(node sqlparser.SQLNode) {
+	switch node := node.(type) {
+	case *sqlparser.ComparisonExpr:
+		like := false
+		switch node.Operator {
+		case sqlparser.LikeOp, sqlparser.NotLikeOp, sqlparser.RegexpOp, sqlparser.NotRegexpOp:
+			like = true
+		}
+		if tuple, ok := node.Right.(sqlparser.ValTuple); ok {
+			p.infer(node.Left, tuple...)
+			for _, expr := range tuple {
+				p.infer(expr, append(sqlparser.Exprs{node.Left}, tuple...)...)
+			}
+			return
+		}
+		for _, side := range []sqlparser.Expr{node.Left, node.Right} {
+			other := node.Right
+			if side == node.Right {
+				other = node.Left
+			}
+			if like {
+				// Patterns are strings.
+				p.infer(side, &sqlparser.CastExpr{Expr: other, Type: &sqlparser.ConvertType{Type: "char"}})
+			}
+			p.infer(side, other)
+		}
+	case *sqlparser.BetweenExpr:
+		p.infer(node.Left, node.From, node.To)
+		p.infer(node.From, node.Left, node.To)
+		p.infer(node.To, node.Left, node.From)
+	case *sqlparser.BinaryExpr:
+		p.infer(node.Left, node.Right)
+		p.infer(node.Right, node.Left)
+	case *sqlparser.Limit:
+		p.fixed(node.Rowcount, "limit")
+		p.fixed(node.Offset, "offset")
+	case *sqlparser.Insert:
+		p.insert(node)
+	case *sqlparser.UpdateExpr:
+		p.assign(node.Expr, node.Name.CompliantName(), p.exprType(node.Name))
+	case *sqlparser.SetExpr:
+		p.assign(node.Expr, node.Var.Name.CompliantName(), unknown())
+	}
+}
+
+// infer records the type and name of a bind variable from the first of its
+// peers whose type is known. Comparisons do not expect NULL.
+func (p *parameters) infer(expr sqlparser.Expr, peers ...sqlparser.Expr) {
+	param := p.param(expr)
+	if param == nil {
+		return
+	}
+	for _, peer := range peers {
+		if col, ok := peer.(*sqlparser.ColName); ok {
+			p.suggest(param, col.CompliantName())
+		}
+		if param.Type.Type != sqltypes.Unknown {
+			continue
+		}
+		if typ := p.exprType(peer); typ.Type != sqltypes.Unknown && typ.Type != sqltypes.Null && typ.Type != sqltypes.Tuple {
+			typ.Nullable = false
+			param.Type = typ
+		}
+	}
+}
+
+// fixed records a bind variable of a LIMIT clause.
+func (p *parameters) fixed(expr sqlparser.Expr, name string) {
+	if param := p.param(expr); param != nil {
+		p.suggest(param, name)
+		if param.Type.Type == sqltypes.Unknown {
+			param.Type = integer(sqltypes.Uint64, false)
+		}
+	}
+}
+
+// assign records a bind variable assigned to a column or variable.
+func (p *parameters) assign(expr sqlparser.Expr, name string, typ Type) {
+	if param := p.param(expr); param != nil {
+		p.suggest(param, name)
+		if param.Type.Type == sqltypes.Unknown {
+			param.Type = typ
+		}
+	}
+}
+
+// insert records the bind variables of the rows of an INSERT, whose types
+// are the types of the columns, or of the literals of other rows.
+func (p *parameters) insert(stmt *sqlparser.Insert) {
+	rows, ok := stmt.Rows.(sqlparser.Values)
+	if !ok {
+		return
+	}
+	var table *Table
+	if p.types != nil {
+		table = p.types.bindings.Table(stmt.Table)
+	}
+	names := make([]string, len(stmt.Columns))
+	for i, col := range stmt.Columns {
+		names[i] = col.String()
+	}
+	if len(names) == 0 && table != nil {
+		for _, col := range table.Columns {
+			names = append(names, col.Name)
+		}
+	}
+
+	for _, row := range rows {
+		for i, expr := range row {
+			param := p.param(expr)
+			if param == nil || i >= len(names) {
+				continue
+			}
+			typ := unknown()
+			if table != nil {
+				if col := table.Column(names[i]); col != nil {
+					typ = p.types.Column(col)
+				}
+			}
+			for _, other := range rows {
+				if typ.Type != sqltypes.Unknown {
+					break
+				}
+				if i < len(other) {
+					if lit, ok := other[i].(*sqlparser.Literal); ok {
+						typ = literal(lit)
+					}
+				}
+			}
+			p.assign(expr, sqlparser.NewIdentifierCI(names[i]).CompliantName(), typ)
+		}
+	}
+}
+
+// param returns the parameter of a bind variable, or nil if the expression
+// is not one.
+func (p *parameters) param(expr sqlparser.Expr) *Parameter {
+	if arg, ok := expr.(*sqlparser.Argument); ok {
+		return p.params[arg.Name]
+	}
+	return nil
+}
+
+// suggest names a positional parameter after the first column it is
+// compared with or assigned to.
+func (p *parameters) suggest(param *Parameter, name string) {
+	if param.Suggested == "" {
+		param.Suggested = p.names.ReserveVariable(name)
+	}
+}
+
+// exprType returns the type of an expression, which is only known for
+// literals without a schema.
+func (p *parameters) exprType(expr sqlparser.Expr) Type {
+	if p.types != nil {
+		return p.types.Expr(expr)
+	}
+	switch expr := expr.(type) {
+	case *sqlparser.Literal:
+		return literal(expr)
+	case *sqlparser.CastExpr:
+		return convertType(expr.Type, p.exprType(expr.Expr))
+	}
+	return unknown()
+}
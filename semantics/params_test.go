@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		params   []string
+		noSchema []string
+	}{{
+		sql:      "select id from orders where created_at > ? and total between ? and 100.5 limit ?, ?",
+		params:   []string{"v1 created_at: datetime not null", "v2 total: decimal(10,2) not null", "v3 offset: uint64(20) not null", "v4 limit: uint64(20) not null"},
+		noSchema: []string{"v1 created_at: unknown", "v2 total: decimal(4,1) not null", "v3 offset: uint64(20) not null", "v4 limit: uint64(20) not null"},
+	}, {
+		sql:      "select * from customer c where c.name like ? and c.id in (?, ?) and c.id = :id",
+		params:   []string{"v1 c_name: varchar(50) collate utf8mb4_0900_ai_ci not null", "v2 c_id: int32(10) not null", "v3 c_id1: int32(10) not null", "id id: int32(10) not null"},
+		noSchema: []string{"v1 c_name: varchar collate utf8mb4_0900_ai_ci not null", "v2 c_id: unknown", "v3 c_id1: unknown", "id id: unknown"},
+	}, {
+		sql:      "insert into orders (id, customer_id, total) values (?, ?, ?), (?, 2, 3.5)",
+		params:   []string{"v1 id: int64(19) not null", "v2 customer_id: int32(10) not null", "v3 total: decimal(10,2)", "v4 id1: int64(19) not null"},
+		noSchema: []string{"v1 id: unknown", "v2 customer_id: int64(1) not null", "v3 total: decimal(2,1) not null", "v4 id1: unknown"},
+	}, {
+		sql:      "update orders set total = ?, created_at = now() where id = ? and customer_id = ? + 1",
+		params:   []string{"v1 total: decimal(10,2)", "v2 id: int64(19) not null", "v3 v3: int64(1) not null"},
+		noSchema: []string{"v1 total: unknown", "v2 id: unknown", "v3 v3: int64(1) not null"},
+	}, {
+		sql:      "select :total * 2, :total > 3",
+		params:   []string{"total total: int64(1) not null"},
+		noSchema: []string{"total total: int64(1) not null"},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := sqlparser.NewTestParser().Parse(tcase.sql)
+			require.NoError(t, err)
+			format := func(params []*Parameter) []string {
+				var result []string
+				for _, param := range params {
+					result = append(result, fmt.Sprintf("%s %s: %s", param.Name, param.Suggested, param.Type))
+				}
+				return result
+			}
+
+			params, err := Parameters(testSchema(t), stmt)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.params, format(params))
+
+			params, err = Parameters(nil, stmt)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.noSchema, format(params))
+		})
+	}
+}
+
+func TestParametersErrors(t *testing.T) {
+	stmt, err := sqlparser.NewTestParser().Parse("select * from orders where missing = ?")
+	require.NoError(t, err)
+	_, err = Parameters(testSchema(t), stmt)
+	assert.EqualError(t, err, "Unknown column 'missing' in 'where clause' (errno 1054) (sqlstate 42S22)")
+}
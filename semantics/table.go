@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// TableKind is the kind of a table a query reads from.
+type TableKind int
+
+const (
+	// BaseTable is a table of the schema.
+	BaseTable TableKind = iota
+	// View is a view of the schema.
+	View
+	// DerivedTable is a subquery of a FROM clause.
+	DerivedTable
+	// CommonTableExpression is a reference to a common table expression of a
+	// WITH clause.
+	CommonTableExpression
+	// JSONTable is a JSON_TABLE table function.
+	JSONTable
+)
+
+func (k TableKind) String() string {
+	switch k {
+	case BaseTable:
+		return "base table"
+	case View:
+		return "view"
+	case DerivedTable:
+		return "derived table"
+	case CommonTableExpression:
+		return "common table expression"
+	case JSONTable:
+		return "json table"
+	}
+	return "unknown"
+}
+
+// Table is a table of a FROM clause, or the target of an INSERT.
+type Table struct {
+	Kind TableKind
+	// Name is the name the table is referred to by: its alias if it has one,
+	// and its qualified name otherwise.
+	Name sqlparser.TableName
+	// Expr is the table expression the table comes from.
+	Expr sqlparser.TableExpr
+	// Schema is the definition of a base table.
+	Schema *schema.Table
+	// View is the definition of a view.
+	View *schema.View
+	// Database is the database of a base table or view.
+	Database string
+	// Select is the query of a derived table, common table expression or
+	// view.
+	Select sqlparser.SelectStatement
+	// Columns are the columns of the table in order.
+	Columns []*Column
+	// Nullable is set for the inner tables of outer joins, whose columns are
+	// NULL for the rows without a match.
+	Nullable bool
+}
+
+// Column returns the column with the given name, or nil.
+func (t *Table) Column(name string) *Column {
+	for _, col := range t.Columns {
+		if strings.EqualFold(col.Name, name) {
+			return col
+		}
+	}
+	return nil
+}
+
+// matches returns whether a column qualifier refers to the table. A
+// qualifier with a database only matches tables without an alias.
+func (t *Table) matches(qualifier sqlparser.TableName) bool {
+	return qualifier.Name == t.Name.Name && (qualifier.Qualifier.IsEmpty() || qualifier.Qualifier == t.Name.Qualifier)
+}
+
+// Column is a column of a table, or of the result of a query.
+type Column struct {
+	// Table is the table of the column. It is nil for the result columns of
+	// a query, which ORDER BY, GROUP BY and HAVING can refer to by alias.
+	Table *Table
+	Name  string
+	// Definition is the definition of a base table column.
+	Definition *sqlparser.ColumnDefinition
+	// Exprs are the expressions producing the column of a query, a derived
+	// table, a common table expression or a view: one for each SELECT of a
+	// UNION. The columns a star stands for are produced by column references
+	// made up by the binder.
+	Exprs []sqlparser.Expr
+}
+
+func (col *Column) String() string {
+	if col.Table == nil {
+		return col.Name
+	}
+	return tableName(col.Table.Name) + "." + col.Name
+}
+
+// derive returns the columns of a table made of the result columns of a
+// query, renamed with the column list of the table if it has one.
+func derive(t *Table, results []*Column, names sqlparser.Columns) ([]*Column, error) {
+	if len(names) > 0 && len(names) != len(results) {
+		return nil, viewWrongListError()
+	}
+	cols := make([]*Column, len(results))
+	seen := map[string]bool{}
+	for i, result := range results {
+		name := result.Name
+		if len(names) > 0 {
+			name = names[i].String()
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, dupFieldNameError(name)
+		}
+		seen[strings.ToLower(name)] = true
+		cols[i] = &Column{Table: t, Name: name, Exprs: result.Exprs}
+	}
+	return cols, nil
+}
@@ -0,0 +1,1075 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// Collations of values that are not columns.
+const (
+	// binaryCollation is the collation of numbers, temporal values and
+	// binary strings.
+	binaryCollation = "binary"
+	// defaultCollation is the collation of string literals, which is the
+	// default collation of the connection.
+	defaultCollation = "utf8mb4_0900_ai_ci"
+)
+
+// Limits of DECIMAL values.
+const (
+	maxPrecision = 65
+	maxScale     = 30
+	// divScale is the number of digits division adds to the scale of its
+	// dividend, the default of div_precision_increment.
+	divScale = 4
+)
+
+// Type is the type of the value of an expression.
+type Type struct {
+	// Type is the SQL type, or sqltypes.Unknown if it cannot be inferred.
+	Type     sqltypes.Type
+	Nullable bool
+	// Collation is the collation of character strings, and "binary" for
+	// other values.
+	Collation string
+	// Size is the maximum number of characters of a string, or the
+	// precision of a number. It is zero if unknown.
+	Size int
+	// Scale is the number of digits after the decimal point of a DECIMAL,
+	// or the fractional seconds precision of a temporal value.
+	Scale int
+}
+
+// String returns the type as in "decimal(10,2) not null": the SQL type, its
+// size or precision and scale, the collation of character strings and
+// whether it is nullable.
+func (t Type) String() string {
+	var b strings.Builder
+	switch t.Type {
+	case sqltypes.Unknown:
+		b.WriteString("unknown")
+	case sqltypes.Null:
+		b.WriteString("null")
+	default:
+		b.WriteString(strings.ToLower(t.Type.String()))
+	}
+	switch {
+	case t.Scale > 0 && sqltypes.IsDateOrTime(t.Type):
+		// Temporal types only have a fractional seconds precision.
+		fmt.Fprintf(&b, "(%d)", t.Scale)
+	case t.Scale > 0:
+		fmt.Fprintf(&b, "(%d,%d)", t.Size, t.Scale)
+	case t.Size > 0:
+		fmt.Fprintf(&b, "(%d)", t.Size)
+	}
+	if isString(t.Type) && t.Collation != binaryCollation {
+		fmt.Fprintf(&b, " collate %s", t.Collation)
+	}
+	if !t.Nullable {
+		b.WriteString(" not null")
+	}
+	return b.String()
+}
+
+// ResultColumn is a column of the result set of a query.
+type ResultColumn struct {
+	Name string
+	Type Type
+}
+
+// Types infers the types of the expressions of a bound statement.
+type Types struct {
+	schema   *schema.Schema
+	bindings *Bindings
+	columns  map[*Column]Type
+	// tables holds the normalized definitions of the base tables, whose
+	// columns have their character set and collation resolved.
+	tables map[*schema.Table]*sqlparser.CreateTable
+}
+
+// NewTypes returns the types of the expressions of a statement bound
+// against the schema.
+func NewTypes(s *schema.Schema, b *Bindings) *Types {
+	return &Types{
+		schema:   s,
+		bindings: b,
+		columns:  map[*Column]Type{},
+		tables:   map[*schema.Table]*sqlparser.CreateTable{},
+	}
+}
+
+// Infer binds a statement and returns the types of its expressions.
+func Infer(s *schema.Schema, stmt sqlparser.Statement) (*Types, error) {
+	b, err := Bind(s, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypes(s, b), nil
+}
+
+// Result returns the result columns of a query of the statement with their
+// types.
+func (t *Types) Result(stmt sqlparser.SelectStatement) []ResultColumn {
+	var results []ResultColumn
+	for _, col := range t.bindings.Result(stmt) {
+		results = append(results, ResultColumn{Name: col.Name, Type: t.Column(col)})
+	}
+	return results
+}
+
+// Column returns the type of a column of a table or query.
+func (t *Types) Column(col *Column) Type {
+	if typ, ok := t.columns[col]; ok {
+		return typ
+	}
+	// A recursive common table expression refers to its own columns.
+	t.columns[col] = Type{Type: sqltypes.Unknown, Nullable: true, Collation: binaryCollation}
+
+	var typ Type
+	switch {
+	case col.Definition != nil:
+		typ = t.definition(col.Table, col.Definition)
+	case col.Table != nil && col.Table.Kind == JSONTable:
+		typ = t.jsonTableColumn(col)
+	default:
+		types := make([]Type, len(col.Exprs))
+		for i, expr := range col.Exprs {
+			types[i] = t.Expr(expr)
+		}
+		typ = unify(types...)
+	}
+	if col.Table != nil && col.Table.Nullable {
+		typ.Nullable = true
+	}
+	t.columns[col] = typ
+	return typ
+}
+
+// definition returns the type of a base table column.
+func (t *Types) definition(table *Table, def *sqlparser.ColumnDefinition) Type {
+	collation := ""
+	if create := t.normalized(table); create != nil {
+		for _, col := range create.TableSpec.Columns {
+			if col.Name.Equal(def.Name) {
+				def = col
+			}
+		}
+		for _, option := range create.TableSpec.Options {
+			if option.Name == "collate" {
+				collation = option.String
+			}
+		}
+	}
+	typ := columnType(def.Type, collation)
+	if def.Type.Options != nil && def.Type.Options.Null != nil {
+		typ.Nullable = *def.Type.Options.Null
+	}
+	return typ
+}
+
+// normalized returns the normalized definition of a base table, or nil if
+// it cannot be normalized.
+func (t *Types) normalized(table *Table) *sqlparser.CreateTable {
+	if create, ok := t.tables[table.Schema]; ok {
+		return create
+	}
+	n, err := schema.NewNormalizer("8.0.40")
+	if err != nil {
+		return nil
+	}
+	if db := t.schema.Database(table.Database); db != nil {
+		for _, option := range db.Options() {
+			switch option.Type {
+			case sqlparser.CharacterSetType:
+				n.Charset, n.Collation = option.Value, ""
+			case sqlparser.CollateType:
+				n.Charset, n.Collation = "", option.Value
+			}
+		}
+	}
+	create, err := n.Normalize(table.Schema.CreateTable())
+	if err != nil {
+		create = nil
+	}
+	t.tables[table.Schema] = create
+	return create
+}
+
+// textSizes holds the maximum length of the TEXT and BLOB types.
+var textSizes = map[string]int{
+	"tinytext": 255, "text": 65535, "mediumtext": 16777215, "longtext": 4294967295,
+	"tinyblob": 255, "blob": 65535, "mediumblob": 16777215, "longblob": 4294967295,
+}
+
+// columnType returns the type of a column of a normalized table, whose
+// collation defaults to the one of the table. The column is nullable.
+func columnType(ct *sqlparser.ColumnType, collation string) Type {
+	typ := Type{Type: ct.SQLType(), Nullable: true, Collation: binaryCollation}
+	if ct.Length != nil {
+		typ.Size = *ct.Length
+	}
+	if ct.Scale != nil {
+		typ.Scale = *ct.Scale
+	}
+	name := strings.ToLower(ct.Type)
+	switch {
+	case sqltypes.IsIntegral(typ.Type) && typ.Type != sqltypes.Year:
+		typ.Size = integerDigits(typ.Type)
+	case typ.Type == sqltypes.Year:
+		typ.Size = 4
+	case typ.Type == sqltypes.Decimal && ct.Length == nil:
+		typ.Size = 10
+	case sqltypes.IsDateOrTime(typ.Type):
+		typ.Size, typ.Scale = 0, typ.Size
+	case textSizes[name] > 0:
+		typ.Size = textSizes[name]
+	case typ.Type == sqltypes.Enum || typ.Type == sqltypes.Set:
+		for _, value := range ct.EnumValues {
+			member, err := sqltypes.DecodeStringSQL(value)
+			if err != nil {
+				continue
+			}
+			if typ.Type == sqltypes.Enum {
+				typ.Size = max(typ.Size, len(member))
+			} else {
+				typ.Size += len(member) + 1
+			}
+		}
+	}
+	if isString(typ.Type) && !sqltypes.IsBinary(typ.Type) {
+		typ.Collation = collation
+		if ct.Options != nil && ct.Options.Collate != "" {
+			typ.Collation = ct.Options.Collate
+		}
+		if typ.Collation == "" {
+			typ.Collation = defaultCollation
+		}
+	}
+	return typ
+}
+
+// jsonTableColumn returns the type of a column of a JSON_TABLE.
+func (t *Types) jsonTableColumn(col *Column) Type {
+	var find func(defs []*sqlparser.JtColumnDefinition) Type
+	find = func(defs []*sqlparser.JtColumnDefinition) Type {
+		for _, def := range defs {
+			switch {
+			case def.JtOrdinal != nil && def.JtOrdinal.Name.EqualString(col.Name):
+				return Type{Type: sqltypes.Uint32, Collation: binaryCollation, Size: 10}
+			case def.JtPath != nil && def.JtPath.Name.EqualString(col.Name):
+				return columnType(def.JtPath.Type, "")
+			case def.JtNestedPath != nil:
+				if typ := find(def.JtNestedPath.Columns); typ.Type != sqltypes.Unknown {
+					return typ
+				}
+			}
+		}
+		return unknown()
+	}
+	return find(col.Table.Expr.(*sqlparser.JSONTableExpr).Columns)
+}
+
+// integerDigits returns the number of decimal digits of the largest value of
+// an integer type.
+func integerDigits(typ sqltypes.Type) int {
+	switch typ {
+	case sqltypes.Int8, sqltypes.Uint8:
+		return 3
+	case sqltypes.Int16, sqltypes.Uint16:
+		return 5
+	case sqltypes.Int24:
+		return 7
+	case sqltypes.Uint24:
+		return 8
+	case sqltypes.Int32, sqltypes.Uint32:
+		return 10
+	case sqltypes.Uint64:
+		return 20
+	}
+	return 19
+}
+
+func isString(typ sqltypes.Type) bool {
+	switch typ {
+	case sqltypes.Char, sqltypes.VarChar, sqltypes.Text, sqltypes.Binary, sqltypes.VarBinary, sqltypes.Blob, sqltypes.Enum, sqltypes.Set:
+		return true
+	}
+	return false
+}
+
+func unknown() Type {
+	return Type{Type: sqltypes.Unknown, Nullable: true, Collation: binaryCollation}
+}
+
+func boolean(nullable bool) Type {
+	return Type{Type: sqltypes.Int64, Nullable: nullable, Collation: binaryCollation, Size: 1}
+}
+
+func integer(typ sqltypes.Type, nullable bool) Type {
+	return Type{Type: typ, Nullable: nullable, Collation: binaryCollation, Size: integerDigits(typ)}
+}
+
+func double(nullable bool) Type {
+	return Type{Type: sqltypes.Float64, Nullable: nullable, Collation: binaryCollation}
+}
+
+func decimal(precision, scale int, nullable bool) Type {
+	scale = min(scale, maxScale)
+	return Type{Type: sqltypes.Decimal, Nullable: nullable, Collation: binaryCollation, Size: min(max(precision, scale), maxPrecision), Scale: scale}
+}
+
+func temporal(typ sqltypes.Type, scale int, nullable bool) Type {
+	return Type{Type: typ, Nullable: nullable, Collation: binaryCollation, Scale: scale}
+}
+
+func text(typ sqltypes.Type, collation string, size int, nullable bool) Type {
+	if collation == "" {
+		collation = defaultCollation
+	}
+	if sqltypes.IsBinary(typ) {
+		collation = binaryCollation
+	}
+	return Type{Type: typ, Nullable: nullable, Collation: collation, Size: size}
+}
+
+// nullable returns whether any of the types is nullable.
+func nullable(types ...Type) bool {
+	for _, typ := range types {
+		if typ.Nullable {
+			return true
+		}
+	}
+	return false
+}
+
+// Expr returns the type of an expression of the statement.
+func (t *Types) Expr(expr sqlparser.Expr) Type {
+	switch expr := expr.(type) {
+	case *sqlparser.ColName:
+		if col := t.bindings.Column(expr); col != nil {
+			return t.Column(col)
+		}
+		return unknown()
+	case *sqlparser.Literal:
+		return literal(expr)
+	case *sqlparser.NullVal:
+		return Type{Type: sqltypes.Null, Nullable: true, Collation: binaryCollation}
+	case sqlparser.BoolVal:
+		return boolean(false)
+	case *sqlparser.Argument, *sqlparser.Variable, *sqlparser.Default:
+		return unknown()
+	case sqlparser.ValTuple, sqlparser.ListArg:
+		return Type{Type: sqltypes.Tuple, Nullable: true, Collation: binaryCollation}
+	case *sqlparser.Subquery:
+		// A scalar subquery is NULL when it returns no rows.
+		if results := t.Result(expr.Select); len(results) == 1 {
+			typ := results[0].Type
+			typ.Nullable = true
+			return typ
+		}
+		return Type{Type: sqltypes.Tuple, Nullable: true, Collation: binaryCollation}
+
+	case *sqlparser.AndExpr, *sqlparser.OrExpr, *sqlparser.XorExpr, *sqlparser.NotExpr, *sqlparser.ComparisonExpr,
+		*sqlparser.BetweenExpr, *sqlparser.MemberOfExpr, *sqlparser.MatchExpr:
+		return boolean(t.operandsNullable(expr))
+	case *sqlparser.IsExpr, *sqlparser.ExistsExpr:
+		return boolean(false)
+	case *sqlparser.BinaryExpr:
+		return arithmetic(expr.Operator, t.Expr(expr.Left), t.Expr(expr.Right))
+	case *sqlparser.UnaryExpr:
+		return t.unary(expr)
+	case *sqlparser.IntroducerExpr:
+		typ := t.Expr(expr.Expr)
+		charset := strings.ToLower(strings.TrimPrefix(expr.CharacterSet, "_"))
+		if charset == "binary" {
+			return text(sqltypes.VarBinary, "", typ.Size, typ.Nullable)
+		}
+		return text(sqltypes.VarChar, charsetCollation(charset), typ.Size, typ.Nullable)
+	case *sqlparser.CollateExpr:
+		typ := t.Expr(expr.Expr)
+		typ.Collation = strings.ToLower(expr.Collation)
+		return typ
+	case *sqlparser.CastExpr:
+		return convertType(expr.Type, t.Expr(expr.Expr))
+	case *sqlparser.ConvertExpr:
+		return convertType(expr.Type, t.Expr(expr.Expr))
+	case *sqlparser.ConvertUsingExpr:
+		typ := t.Expr(expr.Expr)
+		return text(sqltypes.VarChar, charsetCollation(strings.ToLower(expr.Type)), typ.Size, typ.Nullable)
+	case *sqlparser.CaseExpr:
+		var types []Type
+		for _, when := range expr.Whens {
+			types = append(types, t.Expr(when.Val))
+		}
+		if expr.Else != nil {
+			types = append(types, t.Expr(expr.Else))
+		} else {
+			types = append(types, Type{Type: sqltypes.Null, Nullable: true})
+		}
+		return unify(types...)
+
+	case *sqlparser.CountStar, *sqlparser.Count:
+		return integer(sqltypes.Int64, false)
+	case *sqlparser.Sum:
+		return sumType(t.Expr(expr.Arg))
+	case *sqlparser.Avg:
+		return avgType(t.Expr(expr.Arg))
+	case *sqlparser.Min:
+		return withNullable(t.Expr(expr.Arg), true)
+	case *sqlparser.Max:
+		return withNullable(t.Expr(expr.Arg), true)
+	case *sqlparser.AnyValue:
+		return t.Expr(expr.Arg)
+	case *sqlparser.BitAnd, *sqlparser.BitOr, *sqlparser.BitXor:
+		return integer(sqltypes.Uint64, false)
+	case *sqlparser.Std, *sqlparser.StdDev, *sqlparser.StdPop, *sqlparser.StdSamp, *sqlparser.VarPop,
+		*sqlparser.VarSamp, *sqlparser.Variance:
+		return double(true)
+	case *sqlparser.GroupConcatExpr:
+		collation := ""
+		for _, arg := range expr.Exprs {
+			if typ := t.Expr(arg); isString(typ.Type) && collation == "" {
+				collation = typ.Collation
+			}
+		}
+		if collation == binaryCollation {
+			return text(sqltypes.Blob, "", 0, true)
+		}
+		return text(sqltypes.Text, collation, 0, true)
+	case *sqlparser.JSONArrayAgg, *sqlparser.JSONObjectAgg:
+		return Type{Type: sqltypes.TypeJSON, Nullable: true, Collation: binaryCollation}
+
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch expr.Type {
+		case sqlparser.CumeDistExprType, sqlparser.PercentRankExprType:
+			return double(false)
+		}
+		return integer(sqltypes.Uint64, false)
+	case *sqlparser.NtileExpr:
+		return integer(sqltypes.Uint64, false)
+	case *sqlparser.FirstOrLastValueExpr:
+		return withNullable(t.Expr(expr.Expr), true)
+	case *sqlparser.NTHValueExpr:
+		return withNullable(t.Expr(expr.Expr), true)
+	case *sqlparser.LagLeadExpr:
+		typ := withNullable(t.Expr(expr.Expr), true)
+		if expr.Default != nil {
+			typ = unify(typ, t.Expr(expr.Default))
+		}
+		return typ
+
+	case *sqlparser.CurTimeFuncExpr:
+		switch expr.Name.Lowered() {
+		case "curtime", "current_time", "utc_time":
+			return temporal(sqltypes.Time, expr.Fsp, false)
+		}
+		return temporal(sqltypes.Datetime, expr.Fsp, false)
+	case *sqlparser.IntervalDateExpr:
+		return intervalType(t.Expr(expr.Date), expr.Unit)
+	case *sqlparser.ExtractFuncExpr, *sqlparser.TimestampDiffExpr:
+		return integer(sqltypes.Int64, t.operandsNullable(expr))
+	case *sqlparser.SubstrExpr:
+		typ := t.Expr(expr.Name)
+		return text(stringType(typ), typ.Collation, typ.Size, t.operandsNullable(expr))
+	case *sqlparser.TrimFuncExpr:
+		typ := t.Expr(expr.StringArg)
+		return text(stringType(typ), typ.Collation, typ.Size, t.operandsNullable(expr))
+	case *sqlparser.LocateExpr:
+		return integer(sqltypes.Int64, t.operandsNullable(expr))
+	case *sqlparser.CharExpr:
+		if expr.Charset == "" {
+			return text(sqltypes.VarBinary, "", len(expr.Exprs)*4, false)
+		}
+		return text(sqltypes.VarChar, charsetCollation(strings.ToLower(expr.Charset)), len(expr.Exprs)*4, true)
+	case *sqlparser.WeightStringFuncExpr:
+		return text(sqltypes.VarBinary, "", 0, t.operandsNullable(expr))
+	case *sqlparser.ValuesFuncExpr:
+		return t.Expr(expr.Name)
+
+	case *sqlparser.JSONExtractExpr, *sqlparser.JSONKeysExpr, *sqlparser.JSONAttributesExpr,
+		*sqlparser.JSONValueModifierExpr, *sqlparser.JSONValueMergeExpr, *sqlparser.JSONRemoveExpr:
+		return Type{Type: sqltypes.TypeJSON, Nullable: true, Collation: binaryCollation}
+	case *sqlparser.JSONArrayExpr, *sqlparser.JSONObjectExpr:
+		return Type{Type: sqltypes.TypeJSON, Collation: binaryCollation}
+	case *sqlparser.JSONUnquoteExpr, *sqlparser.JSONQuoteExpr, *sqlparser.JSONPrettyExpr:
+		return text(sqltypes.Text, "utf8mb4_bin", 0, true)
+	case *sqlparser.JSONContainsExpr, *sqlparser.JSONContainsPathExpr, *sqlparser.JSONOverlapsExpr,
+		*sqlparser.JSONStorageFreeExpr, *sqlparser.JSONStorageSizeExpr, *sqlparser.JSONSchemaValidFuncExpr:
+		return integer(sqltypes.Int64, true)
+	case *sqlparser.JSONValueExpr:
+		if expr.ReturningType != nil {
+			return convertType(expr.ReturningType, unknown())
+		}
+		return text(sqltypes.VarChar, "utf8mb4_bin", 512, true)
+	case *sqlparser.RegexpLikeExpr, *sqlparser.RegexpInstrExpr:
+		return integer(sqltypes.Int64, true)
+	case *sqlparser.RegexpReplaceExpr:
+		return text(sqltypes.Text, t.Expr(expr.Expr).Collation, 0, true)
+	case *sqlparser.RegexpSubstrExpr:
+		return text(sqltypes.Text, t.Expr(expr.Expr).Collation, 0, true)
+
+	case *sqlparser.FuncExpr:
+		args := make([]Type, len(expr.Exprs))
+		for i, arg := range expr.Exprs {
+			args[i] = t.Expr(arg)
+		}
+		return function(expr.Name.Lowered(), args, expr.Exprs)
+	}
+	if isGeometry(expr) {
+		return Type{Type: sqltypes.Geometry, Nullable: true, Collation: binaryCollation}
+	}
+	return unknown()
+}
+
+// operandsNullable returns whether any expression an expression is made of
+// is nullable. Subqueries make an expression nullable.
+func (t *Types) operandsNullable(expr sqlparser.Expr) bool {
+	result := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if node == expr {
+			return true, nil
+		}
+		if e, ok := node.(sqlparser.Expr); ok {
+			switch e.(type) {
+			case *sqlparser.AndExpr, *sqlparser.OrExpr, *sqlparser.NotExpr, *sqlparser.XorExpr, sqlparser.ValTuple:
+				// The operands of logical operators and tuples decide.
+				return true, nil
+			}
+			result = result || t.Expr(e).Nullable
+			return false, nil
+		}
+		return true, nil
+	}, expr)
+	return result
+}
+
+func withNullable(typ Type, nullable bool) Type {
+	typ.Nullable = typ.Nullable || nullable
+	return typ
+}
+
+// literal returns the type of a literal.
+func literal(lit *sqlparser.Literal) Type {
+	switch lit.Type {
+	case sqlparser.IntVal:
+		digits := len(strings.TrimLeft(lit.Val, "-+0"))
+		switch {
+		case digits < 19:
+			return Type{Type: sqltypes.Int64, Collation: binaryCollation, Size: max(digits, 1)}
+		case digits == 19 || digits == 20 && lit.Val <= "18446744073709551615":
+			return Type{Type: sqltypes.Uint64, Collation: binaryCollation, Size: digits}
+		}
+		return decimal(digits, 0, false)
+	case sqlparser.DecimalVal:
+		whole, fraction, _ := strings.Cut(strings.TrimLeft(lit.Val, "-+"), ".")
+		return decimal(len(strings.TrimLeft(whole, "0"))+len(fraction), len(fraction), false)
+	case sqlparser.FloatVal:
+		return double(false)
+	case sqlparser.StrVal:
+		return text(sqltypes.VarChar, "", len([]rune(lit.Val)), false)
+	case sqlparser.HexVal:
+		return text(sqltypes.VarBinary, "", (len(lit.Val)+1)/2, false)
+	case sqlparser.HexNum:
+		return text(sqltypes.VarBinary, "", (len(lit.Val)-1)/2, false)
+	case sqlparser.BitNum:
+		return text(sqltypes.VarBinary, "", (len(lit.Val)+5)/8, false)
+	case sqlparser.DateVal:
+		return temporal(sqltypes.Date, 0, false)
+	case sqlparser.TimeVal, sqlparser.TimestampVal:
+		scale := 0
+		if _, fraction, ok := strings.Cut(lit.Val, "."); ok {
+			scale = len(fraction)
+		}
+		return temporal(lit.SQLType(), scale, false)
+	}
+	return unknown()
+}
+
+// charsetCollation returns the default collation of a character set.
+func charsetCollation(charset string) string {
+	switch charset {
+	case "", "utf8mb4":
+		return defaultCollation
+	case "utf8", "utf8mb3":
+		return "utf8mb3_general_ci"
+	case "latin1":
+		return "latin1_swedish_ci"
+	case "binary":
+		return binaryCollation
+	}
+	return charset + "_general_ci"
+}
+
+// numeric returns the type a value takes in arithmetic: an integer, a
+// DECIMAL or a DOUBLE. Strings and other values are DOUBLE.
+func numeric(typ Type) Type {
+	switch {
+	case typ.Type == sqltypes.Null, typ.Type == sqltypes.Unknown:
+		return typ
+	case sqltypes.IsIntegral(typ.Type) || typ.Type == sqltypes.Decimal || sqltypes.IsFloat(typ.Type):
+		return typ
+	case typ.Type == sqltypes.Bit, typ.Type == sqltypes.HexNum, typ.Type == sqltypes.HexVal, typ.Type == sqltypes.BitNum:
+		return integer(sqltypes.Uint64, typ.Nullable)
+	case sqltypes.IsDateOrTime(typ.Type):
+		digits := 14
+		switch typ.Type {
+		case sqltypes.Date:
+			digits = 8
+		case sqltypes.Time:
+			digits = 7
+		}
+		if typ.Scale > 0 {
+			return decimal(digits+typ.Scale, typ.Scale, typ.Nullable)
+		}
+		return Type{Type: sqltypes.Int64, Nullable: typ.Nullable, Collation: binaryCollation, Size: digits}
+	}
+	return double(typ.Nullable)
+}
+
+// precision returns the number of digits and the scale of a number.
+func precision(typ Type) (int, int) {
+	if typ.Type == sqltypes.Decimal {
+		return typ.Size, typ.Scale
+	}
+	if typ.Size > 0 {
+		return typ.Size, 0
+	}
+	return integerDigits(typ.Type), 0
+}
+
+// arithmetic returns the type of an arithmetic or bit operation, which
+// follows the MySQL rules for integer and decimal promotion.
+func arithmetic(op sqlparser.BinaryExprOperator, left, right Type) Type {
+	null := nullable(left, right)
+	switch op {
+	case sqlparser.BitAndOp, sqlparser.BitOrOp, sqlparser.BitXorOp, sqlparser.ShiftLeftOp, sqlparser.ShiftRightOp:
+		return integer(sqltypes.Uint64, null)
+	case sqlparser.DivOp, sqlparser.IntDivOp, sqlparser.ModOp:
+		// Division by zero is NULL.
+		null = true
+	}
+	left, right = numeric(left), numeric(right)
+	switch {
+	case left.Type == sqltypes.Null || right.Type == sqltypes.Null:
+		return Type{Type: sqltypes.Null, Nullable: true, Collation: binaryCollation}
+	case left.Type == sqltypes.Unknown || right.Type == sqltypes.Unknown:
+		return unknown()
+	}
+	unsigned := sqltypes.IsUnsigned(left.Type) || sqltypes.IsUnsigned(right.Type)
+	switch {
+	case op == sqlparser.IntDivOp:
+		if unsigned {
+			return integer(sqltypes.Uint64, null)
+		}
+		return integer(sqltypes.Int64, null)
+	case sqltypes.IsFloat(left.Type) || sqltypes.IsFloat(right.Type):
+		return double(null)
+	case sqltypes.IsIntegral(left.Type) && sqltypes.IsIntegral(right.Type) && op != sqlparser.DivOp:
+		if unsigned {
+			return integer(sqltypes.Uint64, null)
+		}
+		return integer(sqltypes.Int64, null)
+	}
+
+	p1, s1 := precision(left)
+	p2, s2 := precision(right)
+	switch op {
+	case sqlparser.MultOp:
+		return decimal(p1+p2, s1+s2, null)
+	case sqlparser.DivOp:
+		return decimal(p1+s2+divScale, s1+divScale, null)
+	case sqlparser.ModOp:
+		scale := max(s1, s2)
+		return decimal(max(p1-s1, p2-s2)+scale, scale, null)
+	}
+	scale := max(s1, s2)
+	return decimal(max(p1-s1, p2-s2)+scale+1, scale, null)
+}
+
+func (t *Types) unary(expr *sqlparser.UnaryExpr) Type {
+	typ := t.Expr(expr.Expr)
+	switch expr.Operator {
+	case sqlparser.UMinusOp:
+		typ = numeric(typ)
+		if typ.Type == sqltypes.Uint64 {
+			return decimal(20, 0, typ.Nullable)
+		}
+		if sqltypes.IsIntegral(typ.Type) {
+			return integer(sqltypes.Int64, typ.Nullable)
+		}
+		return typ
+	case sqlparser.TildaOp:
+		return integer(sqltypes.Uint64, typ.Nullable)
+	case sqlparser.BangOp:
+		return boolean(typ.Nullable)
+	case sqlparser.NStringOp:
+		return text(sqltypes.VarChar, charsetCollation("utf8mb3"), typ.Size, typ.Nullable)
+	}
+	return typ
+}
+
+// unify returns the type of an expression whose value is one of several
+// expressions, such as CASE, COALESCE or the columns of a UNION. It is
+// nullable if any of the types is.
+func unify(types ...Type) Type {
+	result := Type{Type: sqltypes.Null, Nullable: true, Collation: binaryCollation}
+	for i, typ := range types {
+		if i == 0 || result.Type == sqltypes.Null {
+			typ.Nullable = typ.Nullable || i > 0 && result.Nullable
+			result = typ
+			continue
+		}
+		result = merge(result, typ)
+	}
+	return result
+}
+
+// merge returns the type that can hold the values of two types.
+func merge(a, b Type) Type {
+	null := nullable(a, b)
+	if b.Type == sqltypes.Null {
+		return withNullable(a, true)
+	}
+	switch {
+	case b.Type == sqltypes.Unknown:
+		// The type of the value of an argument, or of the recursive part of
+		// a common table expression, is the type of the other values.
+		return withNullable(a, b.Nullable)
+	case a.Type == sqltypes.Unknown:
+		return withNullable(b, a.Nullable)
+	case a.Type == b.Type:
+		a.Size, a.Scale, a.Nullable = max(a.Size, b.Size), max(a.Scale, b.Scale), null
+		if a.Collation != b.Collation && b.Collation == binaryCollation {
+			a.Collation = binaryCollation
+		}
+		return a
+	case sqltypes.IsNumber(a.Type) && sqltypes.IsNumber(b.Type):
+		switch {
+		case sqltypes.IsFloat(a.Type) || sqltypes.IsFloat(b.Type):
+			return double(null)
+		case sqltypes.IsIntegral(a.Type) && sqltypes.IsIntegral(b.Type) && sqltypes.IsUnsigned(a.Type) == sqltypes.IsUnsigned(b.Type):
+			if integerDigits(a.Type) < integerDigits(b.Type) {
+				a = b
+			}
+			return integer(a.Type, null)
+		case sqltypes.IsIntegral(a.Type) && sqltypes.IsIntegral(b.Type) && max(integerDigits(a.Type), integerDigits(b.Type)) < 19:
+			return integer(sqltypes.Int64, null)
+		}
+		p1, s1 := precision(a)
+		p2, s2 := precision(b)
+		scale := max(s1, s2)
+		return decimal(max(p1-s1, p2-s2)+scale, scale, null)
+	case sqltypes.IsDateOrTime(a.Type) && sqltypes.IsDateOrTime(b.Type):
+		return temporal(sqltypes.Datetime, max(a.Scale, b.Scale), null)
+	}
+
+	// Other values are strings, binary if any of them is.
+	collation := a.Collation
+	if !isString(a.Type) || b.Collation == binaryCollation && isString(b.Type) {
+		collation = b.Collation
+	}
+	size := max(a.Size, b.Size)
+	switch {
+	case collation == binaryCollation && (a.Type == sqltypes.Blob || b.Type == sqltypes.Blob || a.Type == sqltypes.Text || b.Type == sqltypes.Text):
+		return text(sqltypes.Blob, "", size, null)
+	case collation == binaryCollation:
+		return text(sqltypes.VarBinary, "", size, null)
+	case a.Type == sqltypes.Text || b.Type == sqltypes.Text:
+		return text(sqltypes.Text, collation, size, null)
+	}
+	return text(sqltypes.VarChar, collation, size, null)
+}
+
+// stringType returns the string type of the result of a string function of
+// a value.
+func stringType(typ Type) sqltypes.Type {
+	switch {
+	case typ.Type == sqltypes.Text || typ.Type == sqltypes.Blob:
+		return typ.Type
+	case sqltypes.IsBinary(typ.Type):
+		return sqltypes.VarBinary
+	}
+	return sqltypes.VarChar
+}
+
+// sumType returns the type of SUM, which is DOUBLE for floating point values
+// and strings, and DECIMAL otherwise.
+func sumType(arg Type) Type {
+	arg = numeric(arg)
+	if sqltypes.IsFloat(arg.Type) {
+		return double(true)
+	}
+	p, s := precision(arg)
+	return decimal(p+22, s, true)
+}
+
+// avgType returns the type of AVG, which is DOUBLE for floating point values
+// and strings, and DECIMAL otherwise. Like division, AVG adds digits to the
+// precision and scale of its argument.
+func avgType(arg Type) Type {
+	arg = numeric(arg)
+	if sqltypes.IsFloat(arg.Type) {
+		return double(true)
+	}
+	p, s := precision(arg)
+	return decimal(p+divScale, s+divScale, true)
+}
+
+// intervalType returns the type of date arithmetic: dates stay dates unless
+// the interval has time parts, and strings stay strings.
+func intervalType(date Type, unit sqlparser.IntervalType) Type {
+	switch date.Type {
+	case sqltypes.Date:
+		if unit.HasTimeParts() {
+			return temporal(sqltypes.Datetime, 0, true)
+		}
+		return temporal(sqltypes.Date, 0, true)
+	case sqltypes.Datetime, sqltypes.Timestamp:
+		return temporal(sqltypes.Datetime, date.Scale, true)
+	case sqltypes.Time:
+		return temporal(sqltypes.Time, date.Scale, true)
+	}
+	return text(sqltypes.VarChar, date.Collation, 29, true)
+}
+
+// convertType returns the type of the target of a CAST or CONVERT of a value
+// of the given type.
+func convertType(ct *sqlparser.ConvertType, from Type) Type {
+	null := from.Nullable
+	length, scale := -1, 0
+	if ct.Length != nil {
+		length = *ct.Length
+	}
+	if ct.Scale != nil {
+		scale = *ct.Scale
+	}
+	switch name := strings.ToLower(strings.Fields(ct.Type)[0]); name {
+	case "signed":
+		return integer(sqltypes.Int64, null)
+	case "unsigned":
+		return integer(sqltypes.Uint64, null)
+	case "char", "nchar", "varchar":
+		if length < 0 {
+			length = from.Size
+		}
+		switch {
+		case ct.Charset.Binary, strings.EqualFold(ct.Charset.Name, "binary"):
+			return text(sqltypes.VarBinary, "", length, null)
+		case name == "nchar":
+			return text(sqltypes.VarChar, charsetCollation("utf8mb3"), length, null)
+		}
+		return text(sqltypes.VarChar, charsetCollation(strings.ToLower(ct.Charset.Name)), length, null)
+	case "binary":
+		if length < 0 {
+			length = from.Size
+		}
+		return text(sqltypes.VarBinary, "", length, null)
+	case "decimal":
+		if length < 0 {
+			length = 10
+		}
+		return decimal(length, scale, null)
+	case "float":
+		if length > 24 {
+			return double(null)
+		}
+		return Type{Type: sqltypes.Float32, Nullable: null, Collation: binaryCollation}
+	case "double", "real":
+		return double(null)
+	case "date":
+		return temporal(sqltypes.Date, 0, true)
+	case "datetime":
+		return temporal(sqltypes.Datetime, max(length, 0), true)
+	case "time":
+		return temporal(sqltypes.Time, max(length, 0), true)
+	case "year":
+		return Type{Type: sqltypes.Year, Nullable: true, Collation: binaryCollation, Size: 4}
+	case "json":
+		return Type{Type: sqltypes.TypeJSON, Nullable: null, Collation: binaryCollation}
+	}
+	return unknown()
+}
+
+// Functions grouped by the type of their result.
+var (
+	stringFunctions = map[string]bool{
+		"concat": true, "concat_ws": true, "lower": true, "upper": true, "lcase": true, "ucase": true,
+		"ltrim": true, "rtrim": true, "replace": true, "left": true, "right": true, "lpad": true,
+		"rpad": true, "repeat": true, "reverse": true, "substring_index": true, "insert": true,
+		"space": true, "elt": true, "quote": true, "soundex": true, "format": true, "date_format": true,
+		"time_format": true, "monthname": true, "dayname": true, "hex": true, "bin": true, "oct": true,
+		"conv": true, "to_base64": true, "inet_ntoa": true, "inet6_ntoa": true, "export_set": true,
+		"make_set": true, "json_type": true, "database": true, "schema": true, "user": true,
+		"current_user": true, "version": true,
+	}
+	integerFunctions = map[string]bool{
+		"length": true, "char_length": true, "character_length": true, "octet_length": true,
+		"bit_length": true, "ascii": true, "ord": true, "instr": true, "position": true,
+		"find_in_set": true, "field": true, "strcmp": true, "year": true, "month": true, "day": true,
+		"dayofmonth": true, "dayofweek": true, "dayofyear": true, "hour": true, "minute": true,
+		"second": true, "microsecond": true, "week": true, "weekday": true, "weekofyear": true,
+		"quarter": true, "yearweek": true, "to_days": true, "to_seconds": true, "datediff": true,
+		"period_add": true, "period_diff": true, "sign": true, "bit_count": true, "isnull": true,
+		"json_length": true, "json_depth": true, "json_valid": true, "inet_aton": true,
+		"is_ipv4": true, "is_ipv6": true, "is_uuid": true, "unix_timestamp": true,
+	}
+	unsignedFunctions = map[string]bool{
+		"crc32": true, "last_insert_id": true, "connection_id": true, "uuid_short": true, "row_count": true,
+		"found_rows": true,
+	}
+	doubleFunctions = map[string]bool{
+		"pi": true, "rand": true, "sqrt": true, "pow": true, "power": true, "exp": true, "ln": true,
+		"log": true, "log2": true, "log10": true, "sin": true, "cos": true, "tan": true, "asin": true,
+		"acos": true, "atan": true, "atan2": true, "cot": true, "radians": true, "degrees": true,
+	}
+	// partialFunctions return NULL for some arguments that are not NULL.
+	partialFunctions = map[string]bool{
+		"sqrt": true, "ln": true, "log": true, "log2": true, "log10": true, "asin": true, "acos": true,
+		"elt": true, "conv": true, "inet_ntoa": true, "inet6_ntoa": true, "inet_aton": true,
+		"from_unixtime": true, "str_to_date": true, "makedate": true, "last_day": true, "convert_tz": true,
+		"unhex": true, "from_base64": true, "sha2": true,
+	}
+	// constantFunctions never return NULL.
+	constantFunctions = map[string]bool{
+		"pi": true, "rand": true, "isnull": true, "field": true, "user": true,
+		"current_user": true, "version": true, "uuid": true, "last_insert_id": true, "connection_id": true,
+		"uuid_short": true, "row_count": true, "found_rows": true,
+	}
+)
+
+// function returns the type of a call of a built-in function. The result
+// of most functions is NULL if any of their arguments is NULL.
+func function(name string, args []Type, exprs sqlparser.Exprs) Type {
+	null := !constantFunctions[name] && (partialFunctions[name] || nullable(args...))
+	if len(args) == 0 && !partialFunctions[name] {
+		null = name == "database" || name == "schema"
+	}
+	first := unknown()
+	if len(args) > 0 {
+		first = args[0]
+	}
+	switch {
+	case stringFunctions[name]:
+		collation, size := "", 0
+		for _, arg := range args {
+			// A binary string argument makes the result binary.
+			if isString(arg.Type) && (collation == "" || arg.Collation == binaryCollation) {
+				collation = arg.Collation
+			}
+			size += arg.Size
+		}
+		typ := sqltypes.VarChar
+		if collation == binaryCollation {
+			typ = sqltypes.VarBinary
+		}
+		return text(typ, collation, size, null)
+	case integerFunctions[name]:
+		return integer(sqltypes.Int64, null)
+	case unsignedFunctions[name]:
+		return integer(sqltypes.Uint64, null)
+	case doubleFunctions[name]:
+		return double(null)
+	}
+
+	switch name {
+	case "coalesce":
+		typ := unify(args...)
+		typ.Nullable = len(args) == 0
+		for _, arg := range args {
+			typ.Nullable = typ.Nullable || arg.Nullable
+			if !arg.Nullable {
+				typ.Nullable = false
+				break
+			}
+		}
+		return typ
+	case "ifnull":
+		typ := unify(args...)
+		typ.Nullable = len(args) == 2 && args[0].Nullable && args[1].Nullable
+		return typ
+	case "nullif":
+		return withNullable(first, true)
+	case "if":
+		if len(args) == 3 {
+			return unify(args[1], args[2])
+		}
+	case "greatest", "least":
+		return withNullable(unify(args...), null)
+	case "abs":
+		return withNullable(numeric(first), null)
+	case "ceil", "ceiling", "floor", "round", "truncate":
+		typ := numeric(first)
+		if typ.Type != sqltypes.Decimal {
+			return withNullable(typ, null)
+		}
+		scale := 0
+		if len(exprs) > 1 {
+			if lit, ok := exprs[1].(*sqlparser.Literal); ok && lit.Type == sqlparser.IntVal {
+				scale, _ = strconv.Atoi(lit.Val)
+			}
+		}
+		scale = max(min(scale, typ.Scale), 0)
+		return decimal(typ.Size-typ.Scale+scale+1, scale, null)
+	case "mod":
+		if len(args) == 2 {
+			return arithmetic(sqlparser.ModOp, args[0], args[1])
+		}
+	case "md5":
+		return text(sqltypes.VarChar, charsetCollation("utf8mb3"), 32, null)
+	case "sha", "sha1":
+		return text(sqltypes.VarChar, charsetCollation("utf8mb3"), 40, null)
+	case "sha2":
+		return text(sqltypes.VarChar, charsetCollation("utf8mb3"), 128, null)
+	case "uuid":
+		return text(sqltypes.VarChar, charsetCollation("utf8mb3"), 36, false)
+	case "unhex", "from_base64", "uuid_to_bin", "inet6_aton", "random_bytes":
+		return text(sqltypes.VarBinary, "", 0, null)
+	case "date", "from_days", "makedate", "last_day", "curdate", "current_date", "utc_date":
+		return temporal(sqltypes.Date, 0, null)
+	case "time", "sec_to_time", "maketime", "timediff":
+		return temporal(sqltypes.Time, first.Scale, null)
+	case "timestamp", "from_unixtime", "str_to_date", "convert_tz":
+		return temporal(sqltypes.Datetime, first.Scale, null)
+	case "addtime", "subtime":
+		return withNullable(first, null)
+	case "json_object", "json_array", "json_merge_patch", "json_merge_preserve", "json_set",
+		"json_insert", "json_replace", "json_remove", "json_extract", "json_keys":
+		return Type{Type: sqltypes.TypeJSON, Nullable: null, Collation: binaryCollation}
+	case "st_geomfromtext", "st_geomfromwkb", "point", "linestring", "polygon":
+		return Type{Type: sqltypes.Geometry, Nullable: true, Collation: binaryCollation}
+	}
+	return unknown()
+}
+
+// isGeometry returns whether an expression is a spatial function returning a
+// geometry.
+func isGeometry(expr sqlparser.Expr) bool {
+	switch expr.(type) {
+	case *sqlparser.PointExpr, *sqlparser.LineStringExpr, *sqlparser.PolygonExpr, *sqlparser.MultiPolygonExpr,
+		*sqlparser.MultiPointExpr, *sqlparser.MultiLinestringExpr, *sqlparser.GeomFromTextExpr,
+		*sqlparser.GeomFromWKBExpr, *sqlparser.GeomFromGeoHashExpr, *sqlparser.GeomFromGeoJSONExpr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestInfer(t *testing.T) {
+	testcases := []struct {
+		sql    string
+		result []string
+	}{{
+		sql:    "select customer.id, name, total from customer join orders on orders.customer_id = customer.id",
+		result: []string{"id: int32(10) not null", "name: varchar(50) collate utf8mb4_0900_ai_ci", "total: decimal(10,2)"},
+	}, {
+		sql:    "select c.id, o.id from customer c left join orders o on o.customer_id = c.id",
+		result: []string{"id: int32(10) not null", "id: int64(19)"},
+	}, {
+		sql:    "select 1, 12.50, 1e3, 'abc', x'ff', null, date'2020-01-01', timestamp'2020-01-01 10:00:00.123'",
+		result: []string{"1: int64(1) not null", "12.50: decimal(4,2) not null", "1e3: float64 not null", "abc: varchar(3) collate utf8mb4_0900_ai_ci not null", "X'ff': varbinary(1) not null", "null: null", "date'2020-01-01': date not null", "timestamp'2020-01-01 10:00:00.123': datetime(3) not null"},
+	}, {
+		sql:    "select id + 1, id * 2.5, total + 1, total * total, total / 3, id / 2, id div 2, total + 1e0, id | 1 from orders",
+		result: []string{"id + 1: int64(19) not null", "id * 2.5: decimal(21,1) not null", "total + 1: decimal(11,2)", "total * total: decimal(20,4)", "total / 3: decimal(14,6)", "id / 2: decimal(23,4)", "id div 2: int64(19)", "total + 1e0: float64", "id | 1: uint64(20) not null"},
+	}, {
+		sql:    "select coalesce(total, 0), ifnull(name, 'none'), if(id > 1, total, id), case when id > 1 then 'big' end, nullif(id, 0) from orders join item using (id)",
+		result: []string{"coalesce(total, 0): decimal(10,2) not null", "ifnull(`name`, 'none'): varchar(50) collate utf8mb4_0900_ai_ci not null", "if(id > 1, total, id): decimal(21,2)", "case when id > 1 then 'big' end: varchar(3) collate utf8mb4_0900_ai_ci", "nullif(id, 0): int64(19)"},
+	}, {
+		sql:    "select cast(id as char(10)), cast(total as signed), convert(name, binary), cast('1.5' as decimal(5,2)), cast(created_at as date), cast(id as unsigned) from orders join customer using (id)",
+		result: []string{"cast(id as char(10)): varchar(10) collate utf8mb4_0900_ai_ci not null", "cast(total as signed): int64(19)", "convert(`name`, binary): varbinary(50)", "cast('1.5' as decimal(5, 2)): decimal(5,2) not null", "cast(created_at as date): date", "cast(id as unsigned): uint64(20) not null"},
+	}, {
+		sql:    "select customer_id, count(*), sum(total), avg(total), avg(customer_id), max(created_at), group_concat(id), sum(id * 1e0) from orders group by customer_id",
+		result: []string{"customer_id: int32(10) not null", "count(*): int64(19) not null", "sum(total): decimal(32,2)", "avg(total): decimal(14,6)", "avg(customer_id): decimal(14,4)", "max(created_at): datetime", "group_concat(id): text collate utf8mb4_0900_ai_ci", "sum(id * 1e0): float64"},
+	}, {
+		sql:    "select row_number() over w, rank() over w, percent_rank() over w, lag(total) over w from orders window w as (order by id)",
+		result: []string{"row_number() over w: uint64(20) not null", "rank() over w: uint64(20) not null", "percent_rank() over w: float64 not null", "lag(total) over w: decimal(10,2)"},
+	}, {
+		sql:    "select concat(name, email), length(name), upper(name), now(3), date(created_at), round(total, 1), abs(id) from customer join orders using (id)",
+		result: []string{"concat(`name`, email): varchar(150) collate utf8mb4_0900_ai_ci", "length(`name`): int64(19)", "upper(`name`): varchar(50) collate utf8mb4_0900_ai_ci", "now(3): datetime(3) not null", "date(created_at): date", "round(total, 1): decimal(10,1)", "abs(id): int32(10) not null"},
+	}, {
+		sql:    "select id, name from customer union select id, total from orders",
+		result: []string{"id: int64(19) not null", "name: varchar(50) collate utf8mb4_0900_ai_ci"},
+	}, {
+		sql:    "select x.n, (select max(total) from orders) from (select name as n from customer) as x",
+		result: []string{"n: varchar(50) collate utf8mb4_0900_ai_ci", "(select max(total) from orders): decimal(10,2)"},
+	}, {
+		sql:    "select name collate utf8mb4_bin, id = 1, id is null, created_at + interval 1 day from customer join orders using (id)",
+		result: []string{"`name` collate utf8mb4_bin: varchar(50) collate utf8mb4_bin", "id = 1: int64(1) not null", "id is null: int64(1) not null", "created_at + interval 1 day: datetime"},
+	}, {
+		sql:    "with recursive r (n) as (select 1 union all select n + 1 from r where n < 5) select n, n + ? from r",
+		result: []string{"n: int64(19) not null", "n + :v1: unknown"},
+	}, {
+		sql:    "select j.v, j.o from json_table('[1]', '$[*]' columns (o for ordinality, v varchar(20) path '$')) as j",
+		result: []string{"v: varchar(20) collate utf8mb4_0900_ai_ci", "o: uint32(10) not null"},
+	}, {
+		sql:    "select body from crm.note",
+		result: []string{"body: text(65535) collate utf8mb4_0900_ai_ci"},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			s := testSchema(t)
+			stmt, err := sqlparser.NewTestParser().Parse(tcase.sql)
+			require.NoError(t, err)
+			types, err := Infer(s, stmt)
+			require.NoError(t, err)
+
+			var result []string
+			for _, col := range types.Result(stmt.(sqlparser.SelectStatement)) {
+				result = append(result, col.Name+": "+col.Type.String())
+			}
+			assert.Equal(t, tcase.result, result)
+		})
+	}
+}
+
+func TestInferCollation(t *testing.T) {
+	s := testSchema(t)
+	parser := sqlparser.NewTestParser()
+	for _, sql := range []string{
+		"create database latin character set latin1",
+		"create table latin.t (a varchar(10), b varchar(10) collate latin1_bin, c blob)",
+	} {
+		stmt, err := parser.Parse(sql)
+		require.NoError(t, err)
+		require.NoError(t, s.Apply(stmt))
+	}
+
+	stmt, err := parser.Parse("select a, b, c, concat(a, c) from latin.t")
+	require.NoError(t, err)
+	types, err := Infer(s, stmt)
+	require.NoError(t, err)
+	var collations []string
+	for _, col := range types.Result(stmt.(sqlparser.SelectStatement)) {
+		collations = append(collations, col.Type.Collation)
+	}
+	assert.Equal(t, []string{"latin1_swedish_ci", "latin1_bin", "binary", "binary"}, collations)
+}
@@ -0,0 +1,480 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser"
+	"github.com/redhajuanda/sqlparser/dependencies/sqltypes"
+	"github.com/redhajuanda/sqlparser/schema"
+)
+
+// UsageKind is the clause or predicate a column is used in.
+type UsageKind int
+
+const (
+	// Equality is an equality predicate with a value: =, <=>, IN or IS NULL.
+	Equality UsageKind = iota
+	// Range is any other predicate with a value, such as <, BETWEEN, LIKE or
+	// IS NOT NULL.
+	Range
+	// Join is a predicate comparing columns of different tables.
+	Join
+	// GroupBy is a GROUP BY expression.
+	GroupBy
+	// OrderBy is an ORDER BY expression.
+	OrderBy
+	// SelectList is an expression of the select list.
+	SelectList
+)
+
+func (k UsageKind) String() string {
+	switch k {
+	case Equality:
+		return "equality"
+	case Range:
+		return "range"
+	case Join:
+		return "join"
+	case GroupBy:
+		return "group by"
+	case OrderBy:
+		return "order by"
+	case SelectList:
+		return "select list"
+	}
+	return "unknown"
+}
+
+// ColumnUsage is a use of a column of a base table.
+type ColumnUsage struct {
+	Column string
+	Kind   UsageKind
+	// Desc is set for columns of ORDER BY sorted in descending order.
+	Desc bool
+	// Sargable is whether an index on the column can serve the use: the
+	// column is not wrapped in a function or expression, and predicates do
+	// not convert it to the type or collation of the value it is compared
+	// with.
+	Sargable bool
+	// Expr is the predicate or expression the column is used in. The
+	// conjuncts of an AND are separate predicates.
+	Expr sqlparser.Expr
+}
+
+func (u ColumnUsage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", u.Column, u.Kind)
+	if u.Desc {
+		b.WriteString(" desc")
+	}
+	if !u.Sargable {
+		b.WriteString(" (not sargable)")
+	}
+	return b.String()
+}
+
+// TableUsage is the column usage of a base table.
+type TableUsage struct {
+	// Table is the qualified name of the table.
+	Table sqlparser.TableName
+	// Columns are the uses of the columns of the table, in the order they
+	// are found. A column is listed once for each distinct use.
+	Columns []ColumnUsage
+}
+
+// ColumnUsages returns how the columns of the base tables of a statement
+// are used by its queries, UPDATE and DELETE, in the order the tables are
+// found.
+func ColumnUsages(s *schema.Schema, stmt sqlparser.Statement) ([]*TableUsage, error) {
+	types, err := Infer(s, stmt)
+	if err != nil {
+		return nil, err
+	}
+	u := &usages{types: types}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			u.selectBlock(node)
+		case *sqlparser.Union:
+			u.order(node.OrderBy)
+		case *sqlparser.Update:
+			u.from(node.TableExprs)
+			u.where(node.Where)
+			u.order(node.OrderBy)
+		case *sqlparser.Delete:
+			u.from(node.TableExprs)
+			u.where(node.Where)
+			u.order(node.OrderBy)
+		}
+		return true, nil
+	}, stmt)
+	return u.tables, nil
+}
+
+// usages collects the column usage of a statement.
+type usages struct {
+	types  *Types
+	tables []*TableUsage
+}
+
+// add records a use of a column, unless it is not a base table column or
+// the same use is known already.
+func (u *usages) add(col *Column, use ColumnUsage) {
+	if col == nil || col.Table == nil || col.Table.Kind != BaseTable {
+		return
+	}
+	name := sqlparser.NewTableNameWithQualifier(col.Table.Schema.Name(), col.Table.Database)
+	var table *TableUsage
+	for _, t := range u.tables {
+		if t.Table == name {
+			table = t
+		}
+	}
+	if table == nil {
+		table = &TableUsage{Table: name}
+		u.tables = append(u.tables, table)
+	}
+	use.Column = col.Name
+	for _, known := range table.Columns {
+		if known.Column == use.Column && known.Kind == use.Kind && known.Desc == use.Desc && known.Sargable == use.Sargable {
+			return
+		}
+	}
+	table.Columns = append(table.Columns, use)
+}
+
+func (u *usages) selectBlock(stmt *sqlparser.Select) {
+	for _, col := range u.types.bindings.Result(stmt) {
+		for _, expr := range col.Exprs {
+			_, bare := expr.(*sqlparser.ColName)
+			for _, bound := range u.columns(expr) {
+				u.add(bound, ColumnUsage{Kind: SelectList, Sargable: bare, Expr: expr})
+			}
+		}
+	}
+	u.from(stmt.From)
+	u.where(stmt.Where)
+	if stmt.GroupBy != nil {
+		for _, expr := range stmt.GroupBy.Exprs {
+			bare := u.bare(expr)
+			for _, col := range u.columns(expr) {
+				u.add(col, ColumnUsage{Kind: GroupBy, Sargable: bare, Expr: expr})
+			}
+		}
+	}
+	u.order(stmt.OrderBy)
+}
+
+func (u *usages) order(orderBy sqlparser.OrderBy) {
+	for _, order := range orderBy {
+		bare := u.bare(order.Expr)
+		for _, col := range u.columns(order.Expr) {
+			u.add(col, ColumnUsage{Kind: OrderBy, Desc: order.Direction == sqlparser.DescOrder, Sargable: bare, Expr: order.Expr})
+		}
+	}
+}
+
+func (u *usages) where(where *sqlparser.Where) {
+	if where == nil {
+		return
+	}
+	for _, conjunct := range sqlparser.SplitAndExpression(nil, where.Expr) {
+		u.predicate(conjunct)
+	}
+}
+
+// from records the join conditions of a FROM clause.
+func (u *usages) from(exprs []sqlparser.TableExpr) {
+	for _, expr := range exprs {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.Subquery, *sqlparser.DerivedTable:
+				// Queries are analyzed on their own.
+				return false, nil
+			case *sqlparser.JoinTableExpr:
+				u.join(node)
+			}
+			return true, nil
+		}, expr)
+	}
+}
+
+func (u *usages) join(expr *sqlparser.JoinTableExpr) {
+	var using []string
+	switch expr.Join {
+	case sqlparser.NaturalJoinType, sqlparser.NaturalLeftJoinType, sqlparser.NaturalRightJoinType:
+		for _, table := range u.tablesOf(expr.RightExpr) {
+			for _, col := range table.Columns {
+				using = append(using, col.Name)
+			}
+		}
+	}
+	if expr.Condition != nil {
+		for _, name := range expr.Condition.Using {
+			using = append(using, name.String())
+		}
+		for _, conjunct := range sqlparser.SplitAndExpression(nil, expr.Condition.On) {
+			u.predicate(conjunct)
+		}
+	}
+
+	left, right := u.tablesOf(expr.LeftExpr), u.tablesOf(expr.RightExpr)
+	for _, name := range using {
+		var cols []*Column
+		for _, tables := range [][]*Table{left, right} {
+			for _, table := range tables {
+				if col := table.Column(name); col != nil {
+					cols = append(cols, col)
+				}
+			}
+		}
+		if len(cols) != 2 {
+			continue
+		}
+		// USING (c) compares the columns like ON left.c = right.c.
+		cmp := &sqlparser.ComparisonExpr{
+			Operator: sqlparser.EqualOp,
+			Left:     sqlparser.NewColNameWithQualifier(name, cols[0].Table.Name),
+			Right:    sqlparser.NewColNameWithQualifier(name, cols[1].Table.Name),
+		}
+		a, b := u.types.Column(cols[0]), u.types.Column(cols[1])
+		u.add(cols[0], ColumnUsage{Kind: Join, Sargable: !converted(a, b, true), Expr: cmp})
+		u.add(cols[1], ColumnUsage{Kind: Join, Sargable: !converted(b, a, true), Expr: cmp})
+	}
+}
+
+// tablesOf returns the tables of a table expression.
+func (u *usages) tablesOf(expr sqlparser.TableExpr) []*Table {
+	var tables []*Table
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.AliasedTableExpr, *sqlparser.JSONTableExpr:
+			if table := u.types.bindings.Table(node.(sqlparser.TableExpr)); table != nil {
+				tables = append(tables, table)
+			}
+			return false, nil
+		}
+		return true, nil
+	}, expr)
+	return tables
+}
+
+// predicate records the columns of a conjunct of a WHERE or ON clause.
+func (u *usages) predicate(expr sqlparser.Expr) {
+	for _, use := range u.uses(expr) {
+		u.add(use.column, use.ColumnUsage)
+	}
+}
+
+// use is a use of a column by a predicate.
+type use struct {
+	ColumnUsage
+	column *Column
+}
+
+// uses returns the uses of the columns of a predicate.
+func (u *usages) uses(expr sqlparser.Expr) []use {
+	switch expr := expr.(type) {
+	case *sqlparser.ComparisonExpr:
+		return u.comparison(expr)
+	case *sqlparser.BetweenExpr:
+		var uses []use
+		_, bare := expr.Left.(*sqlparser.ColName)
+		for _, col := range u.columns(expr.Left) {
+			sargable := bare && expr.IsBetween
+			for _, bound := range []sqlparser.Expr{expr.From, expr.To} {
+				sargable = sargable && !converted(u.types.Column(col), u.types.Expr(bound), len(u.columns(bound)) > 0)
+			}
+			uses = append(uses, use{ColumnUsage{Kind: Range, Sargable: sargable, Expr: expr}, col})
+		}
+		return append(uses, u.other(expr.From, expr)...)
+	case *sqlparser.IsExpr:
+		kind := Range
+		if expr.Right == sqlparser.IsNullOp {
+			kind = Equality
+		}
+		_, bare := expr.Left.(*sqlparser.ColName)
+		sargable := bare && (expr.Right == sqlparser.IsNullOp || expr.Right == sqlparser.IsNotNullOp)
+		var uses []use
+		for _, col := range u.columns(expr.Left) {
+			uses = append(uses, use{ColumnUsage{Kind: kind, Sargable: sargable, Expr: expr}, col})
+		}
+		return uses
+	case *sqlparser.OrExpr:
+		// A disjunction of sargable predicates of the same column can use
+		// an index on it.
+		uses := append(u.uses(expr.Left), u.uses(expr.Right)...)
+		for _, use := range uses {
+			if !use.Sargable || use.Kind == Join || use.column != uses[0].column {
+				return u.other(expr, expr)
+			}
+		}
+		for i := range uses {
+			uses[i].Expr = expr
+			if uses[i].Kind != uses[0].Kind {
+				uses[i].Kind = Range
+			}
+		}
+		return uses
+	}
+	return u.other(expr, expr)
+}
+
+// other returns the uses of the columns of an expression the predicate
+// cannot use an index for.
+func (u *usages) other(expr, predicate sqlparser.Expr) []use {
+	var uses []use
+	for _, col := range u.columns(expr) {
+		uses = append(uses, use{ColumnUsage{Kind: Range, Expr: predicate}, col})
+	}
+	return uses
+}
+
+// comparison returns the uses of the columns of a comparison. Comparing
+// columns of different tables joins them, other comparisons compare the
+// columns of a side with the value of the other side.
+func (u *usages) comparison(expr *sqlparser.ComparisonExpr) []use {
+	kind, sargable := Range, true
+	switch expr.Operator {
+	case sqlparser.EqualOp, sqlparser.NullSafeEqualOp, sqlparser.InOp:
+		kind = Equality
+	case sqlparser.LikeOp:
+		sargable = prefixPattern(expr.Right)
+	case sqlparser.NotLikeOp, sqlparser.RegexpOp, sqlparser.NotRegexpOp:
+		sargable = false
+	}
+
+	left, right := u.columns(expr.Left), u.columns(expr.Right)
+	join := joins(left, right)
+	if join {
+		kind = Join
+	}
+	var uses []use
+	for _, side := range []struct {
+		expr, other sqlparser.Expr
+		cols, peers []*Column
+	}{{expr.Left, expr.Right, left, right}, {expr.Right, expr.Left, right, left}} {
+		_, bare := side.expr.(*sqlparser.ColName)
+		for _, col := range side.cols {
+			use := use{ColumnUsage{Kind: kind, Expr: expr}, col}
+			switch {
+			case join:
+				use.Sargable = bare
+			case len(side.peers) > 0:
+				// Columns of the same table compared with each other are
+				// evaluated row by row.
+			case side.expr == expr.Right && !symmetric(expr.Operator):
+				// Only the left side of IN, LIKE and REGEXP is looked up.
+			default:
+				use.Sargable = sargable && bare
+			}
+			if use.Sargable {
+				use.Sargable = !converted(u.types.Column(col), u.types.Expr(side.other), join)
+			}
+			uses = append(uses, use)
+		}
+	}
+	return uses
+}
+
+// symmetric returns whether the columns of the right side of a comparison
+// can use an index as well as the left side.
+func symmetric(op sqlparser.ComparisonExprOperator) bool {
+	switch op {
+	case sqlparser.EqualOp, sqlparser.NullSafeEqualOp, sqlparser.LessThanOp, sqlparser.GreaterThanOp,
+		sqlparser.LessEqualOp, sqlparser.GreaterEqualOp, sqlparser.NotEqualOp:
+		return true
+	}
+	return false
+}
+
+// joins returns whether two sides of a comparison refer to columns of
+// different tables.
+func joins(left, right []*Column) bool {
+	for _, l := range left {
+		for _, r := range right {
+			if l.Table != r.Table {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prefixPattern returns whether a LIKE pattern is a literal that does not
+// start with a wildcard.
+func prefixPattern(expr sqlparser.Expr) bool {
+	lit, ok := expr.(*sqlparser.Literal)
+	return ok && lit.Type == sqlparser.StrVal && lit.Val != "" && lit.Val[0] != '%' && lit.Val[0] != '_'
+}
+
+// converted returns whether a column is converted to be compared with a
+// value: strings are compared as numbers or temporal values with those, and
+// columns of different collations compare in the collation of the other.
+func converted(col, value Type, column bool) bool {
+	switch {
+	case !isString(col.Type) || value.Type == sqltypes.Unknown || value.Type == sqltypes.Null:
+		return false
+	case sqltypes.IsNumber(value.Type) || sqltypes.IsDateOrTime(value.Type):
+		return true
+	case column && isString(value.Type):
+		return col.Collation != value.Collation
+	}
+	return false
+}
+
+// columns returns the columns an expression refers to, outside of its
+// subqueries.
+func (u *usages) columns(expr sqlparser.Expr) []*Column {
+	var cols []*Column
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Subquery:
+			return false, nil
+		case *sqlparser.ColName:
+			col := u.types.bindings.Column(node)
+			switch {
+			case col == nil:
+			case col.Table == nil:
+				// GROUP BY, HAVING and ORDER BY refer to the columns of
+				// the expressions of the select list by their alias.
+				for _, expr := range col.Exprs {
+					cols = append(cols, u.columns(expr)...)
+				}
+			default:
+				cols = append(cols, col)
+			}
+		}
+		return true, nil
+	}, expr)
+	return cols
+}
+
+// bare returns whether an expression is a column, or the alias of one.
+func (u *usages) bare(expr sqlparser.Expr) bool {
+	col, ok := expr.(*sqlparser.ColName)
+	if !ok {
+		return false
+	}
+	if bound := u.types.bindings.Column(col); bound != nil && bound.Table == nil {
+		return len(bound.Exprs) == 1 && u.bare(bound.Exprs[0])
+	}
+	return true
+}
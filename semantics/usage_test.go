@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhajuanda/sqlparser"
+)
+
+func TestColumnUsages(t *testing.T) {
+	testcases := []struct {
+		sql    string
+		tables map[string][]string
+	}{{
+		sql: "select o.id, c.name from orders o join customer c on c.id = o.customer_id where o.total > 10 and c.email = 'a@b.c' order by o.created_at desc, o.id",
+		tables: map[string][]string{
+			"shop.orders":   {"id select list", "customer_id join", "total range", "created_at order by desc", "id order by"},
+			"shop.customer": {"name select list", "id join", "email equality"},
+		},
+	}, {
+		sql: "select count(*) from orders where date(created_at) = '2024-01-01' and customer_id in (1, 2) and total between 1 and 2 and id + 1 = 3",
+		tables: map[string][]string{
+			"shop.orders": {"created_at equality (not sargable)", "customer_id equality", "total range", "id equality (not sargable)"},
+		},
+	}, {
+		sql: "select name from customer where name = 42 or email like '%x' or id is null",
+		tables: map[string][]string{
+			"shop.customer": {"name select list", "name range (not sargable)", "email range (not sargable)", "id range (not sargable)"},
+		},
+	}, {
+		sql: "select id from customer where (id = 1 or id = 2) and email like 'abc%' and name is not null and 5 < id and 'x' like name",
+		tables: map[string][]string{
+			"shop.customer": {"id select list", "id equality", "email range", "name range", "id range", "name range (not sargable)"},
+		},
+	}, {
+		sql: "select customer_id, sum(total) from orders join crm.note using (customer_id) group by customer_id, year(created_at)",
+		tables: map[string][]string{
+			"shop.orders": {"customer_id select list", "total select list (not sargable)", "customer_id join", "customer_id group by", "created_at group by (not sargable)"},
+			"crm.note":    {"customer_id join"},
+		},
+	}, {
+		sql: "select * from customer c where exists (select 1 from orders o where o.customer_id = c.id and o.total = c.name)",
+		tables: map[string][]string{
+			"shop.customer": {"id select list", "name select list", "email select list", "id join", "name join (not sargable)"},
+			"shop.orders":   {"customer_id join", "total join"},
+		},
+	}, {
+		sql: "select name as n, upper(email) as e from customer group by n, e order by n desc",
+		tables: map[string][]string{
+			"shop.customer": {"name select list", "email select list (not sargable)", "name group by", "email group by (not sargable)", "name order by desc"},
+		},
+	}, {
+		sql: "update orders set total = 0 where customer_id = 3 order by created_at limit 1",
+		tables: map[string][]string{
+			"shop.orders": {"customer_id equality", "created_at order by"},
+		},
+	}, {
+		sql: "delete from item where order_id in (select id from orders where created_at < now())",
+		tables: map[string][]string{
+			"shop.item":   {"order_id equality"},
+			"shop.orders": {"id select list", "created_at range"},
+		},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := sqlparser.NewTestParser().Parse(tcase.sql)
+			require.NoError(t, err)
+			usages, err := ColumnUsages(testSchema(t), stmt)
+			require.NoError(t, err)
+
+			tables := map[string][]string{}
+			for _, table := range usages {
+				for _, use := range table.Columns {
+					tables[tableName(table.Table)] = append(tables[tableName(table.Table)], use.String())
+				}
+			}
+			assert.Equal(t, tcase.tables, tables)
+		})
+	}
+}
@@ -0,0 +1,426 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/redhajuanda/sqlparser/dependencies/vt/vterrors"
+
+	vtrpcpb "github.com/redhajuanda/sqlparser/dependencies/vt/proto/vtrpc"
+)
+
+// DefaultDelimiter is the statement delimiter used by the mysql client
+// until a DELIMITER directive changes it.
+const DefaultDelimiter = ";"
+
+// StatementPiece is a single statement produced by a StatementReader.
+type StatementPiece struct {
+	// Text is the statement text without its terminating delimiter.
+	// When a /*! ... */ comment spans a delimiter, Text is closed with
+	// " */" and the next piece re-opens it with the same version prefix,
+	// so each piece can be parsed on its own.
+	Text string
+	// Start and End are the byte offsets of the statement in the stream.
+	// End points just past the last byte of the statement, before the
+	// delimiter.
+	Start, End int64
+	// Line is the 1-based line at which the statement starts.
+	Line int
+	// Delimiter is the delimiter that was active for this statement.
+	Delimiter string
+	// Command is the client command that terminated the statement, i.e.
+	// `\g` or `\G`. It is empty when the statement ended with the delimiter
+	// or at the end of the stream.
+	Command string
+}
+
+// StatementReader splits a stream of SQL into statements the same way the
+// mysql command line client does. Unlike SplitStatementToPieces it never
+// holds more than one statement in memory, honors the DELIMITER directive
+// and understands the `\g`, `\G`, `\c` and `\d` client commands.
+type StatementReader struct {
+	r         *bufio.Reader
+	pos       int64
+	line      int
+	delimiter string
+
+	// reopen holds the /*!NNNNN prefix of a version comment that was
+	// split by a delimiter and must be re-opened for the next statement.
+	reopen string
+	err    error
+}
+
+// NewStatementReader returns a StatementReader reading from r.
+func NewStatementReader(r io.Reader) *StatementReader {
+	return &StatementReader{
+		r:         bufio.NewReaderSize(r, 64*1024),
+		line:      1,
+		delimiter: DefaultDelimiter,
+	}
+}
+
+// Delimiter returns the delimiter currently in effect.
+func (sr *StatementReader) Delimiter() string {
+	return sr.delimiter
+}
+
+// Offset returns the number of bytes consumed from the underlying reader.
+func (sr *StatementReader) Offset() int64 {
+	return sr.pos
+}
+
+// Next returns the next statement in the stream. Statements that consist
+// only of whitespace and comments are skipped. When there are no more
+// statements, io.EOF is returned.
+func (sr *StatementReader) Next() (*StatementPiece, error) {
+	if sr.err != nil {
+		return nil, sr.err
+	}
+	for {
+		piece, err := sr.scanStatement()
+		if err != nil {
+			sr.err = err
+			if piece != nil && !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+		}
+		if piece != nil {
+			return piece, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// statementScan holds the state of a single call to scanStatement.
+type statementScan struct {
+	buf     strings.Builder
+	start   int64
+	end     int64
+	line    int
+	started bool
+	// hasCode is set once the statement contains anything other than
+	// whitespace and regular comments.
+	hasCode bool
+	// version is the prefix of the /*! comment we are inside of, if any.
+	version string
+}
+
+func (s *statementScan) write(sr *StatementReader, b ...byte) {
+	if !s.started {
+		s.started = true
+		s.start = sr.pos - int64(len(b))
+		s.line = sr.line
+	}
+	s.buf.Write(b)
+	s.end = sr.pos
+}
+
+func (s *statementScan) code(sr *StatementReader, b ...byte) {
+	s.hasCode = true
+	s.write(sr, b...)
+}
+
+// piece builds the StatementPiece for the scanned statement, or returns nil
+// if the statement contains no code.
+func (s *statementScan) piece(sr *StatementReader, delimiter, command string) *StatementPiece {
+	if !s.hasCode {
+		return nil
+	}
+	text := strings.TrimRight(s.buf.String(), " \t\r\n")
+	end := s.end - int64(len(s.buf.String())-len(text))
+	if s.version != "" {
+		text += " */"
+	}
+	return &StatementPiece{
+		Text:      text,
+		Start:     s.start,
+		End:       end,
+		Line:      s.line,
+		Delimiter: delimiter,
+		Command:   command,
+	}
+}
+
+func (sr *StatementReader) readByte() (byte, error) {
+	b, err := sr.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	sr.pos++
+	if b == '\n' {
+		sr.line++
+	}
+	return b, nil
+}
+
+// hasPrefix reports whether the unread input begins with prefix.
+func (sr *StatementReader) hasPrefix(prefix string) bool {
+	buf, _ := sr.r.Peek(len(prefix))
+	return string(buf) == prefix
+}
+
+func (sr *StatementReader) discard(n int) {
+	for i := 0; i < n; i++ {
+		if _, err := sr.readByte(); err != nil {
+			return
+		}
+	}
+}
+
+// readLine consumes and returns the rest of the current line, without the
+// line terminator.
+func (sr *StatementReader) readLine() string {
+	return strings.TrimRight(string(sr.readRawLine()), "\r\n")
+}
+
+// readRawLine consumes and returns the rest of the current line, including
+// the line terminator if there is one.
+func (sr *StatementReader) readRawLine() []byte {
+	var line []byte
+	for {
+		b, err := sr.readByte()
+		if err != nil {
+			break
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	return line
+}
+
+// setDelimiter applies the argument of a DELIMITER or `\d` command.
+func (sr *StatementReader) setDelimiter(arg string, line int) error {
+	arg = strings.TrimSpace(arg)
+	if i := strings.IndexAny(arg, " \t"); i >= 0 {
+		arg = arg[:i]
+	}
+	if len(arg) >= 2 && (arg[0] == '\'' || arg[0] == '"' || arg[0] == '`') && arg[len(arg)-1] == arg[0] {
+		arg = arg[1 : len(arg)-1]
+	}
+	if arg == "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "DELIMITER must be followed by a 'delimiter' character or string at line %d", line)
+	}
+	if strings.Contains(arg, "\\") {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "DELIMITER cannot contain a backslash character at line %d", line)
+	}
+	sr.delimiter = arg
+	return nil
+}
+
+// isDelimiterDirective reports whether the unread input starts with a
+// DELIMITER client command.
+func (sr *StatementReader) isDelimiterDirective() bool {
+	const keyword = "delimiter"
+	buf, _ := sr.r.Peek(len(keyword) + 1)
+	if len(buf) < len(keyword) || !strings.EqualFold(string(buf[:len(keyword)]), keyword) {
+		return false
+	}
+	if len(buf) == len(keyword) {
+		return true
+	}
+	switch buf[len(keyword)] {
+	case ' ', '\t', '\r', '\n':
+		return true
+	}
+	return false
+}
+
+func isLineCommentStart(next []byte) bool {
+	if len(next) < 2 || next[0] != '-' || next[1] != '-' {
+		return false
+	}
+	// MySQL requires "--" to be followed by whitespace or a control character.
+	return len(next) == 2 || next[2] <= ' '
+}
+
+// scanStatement reads a single statement from the stream. It returns a nil
+// piece if the statement was empty, was cleared with `\c`, or was a
+// DELIMITER directive.
+func (sr *StatementReader) scanStatement() (*StatementPiece, error) {
+	var s statementScan
+	if sr.reopen != "" {
+		s.version = sr.reopen
+		s.started = true
+		s.start = sr.pos
+		s.line = sr.line
+		s.buf.WriteString(sr.reopen)
+		// Separate the prefix from the statement unless whitespace already
+		// does.
+		if next, _ := sr.r.Peek(1); len(next) > 0 && next[0] != ' ' && next[0] != '\t' && next[0] != '\r' && next[0] != '\n' {
+			s.buf.WriteByte(' ')
+		}
+		s.end = sr.pos
+		sr.reopen = ""
+	}
+	delimiter := sr.delimiter
+
+	for {
+		if !s.hasCode && s.version == "" && sr.isDelimiterDirective() {
+			line := sr.line
+			directive := sr.readLine()
+			return nil, sr.setDelimiter(directive[len("delimiter"):], line)
+		}
+		if sr.hasPrefix(delimiter) {
+			sr.discard(len(delimiter))
+			if s.version != "" {
+				sr.reopen = s.version
+			}
+			return s.piece(sr, delimiter, ""), nil
+		}
+
+		b, err := sr.readByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return s.piece(sr, delimiter, ""), io.EOF
+			}
+			return nil, err
+		}
+
+		switch {
+		case b == ' ' || b == '\t' || b == '\r' || b == '\n':
+			if s.started {
+				s.write(sr, b)
+			}
+		case b == '\'' || b == '"' || b == '`':
+			s.code(sr, b)
+			if err := sr.scanQuoted(&s, b); err != nil {
+				return nil, err
+			}
+		case b == '#':
+			s.write(sr, b)
+			s.write(sr, sr.readRawLine()...)
+		case b == '-':
+			next, _ := sr.r.Peek(2)
+			if isLineCommentStart(append([]byte{b}, next...)) {
+				s.write(sr, b)
+				s.write(sr, sr.readRawLine()...)
+			} else {
+				s.code(sr, b)
+			}
+		case b == '/' && sr.hasPrefix("*"):
+			sr.discard(1)
+			if err := sr.scanComment(&s); err != nil {
+				return nil, err
+			}
+		case b == '*' && s.version != "" && sr.hasPrefix("/"):
+			sr.discard(1)
+			s.write(sr, '*', '/')
+			s.version = ""
+		case b == '\\':
+			cmd, err := sr.readByte()
+			if err != nil {
+				s.code(sr, b)
+				continue
+			}
+			switch cmd {
+			case 'g', 'G':
+				if s.version != "" {
+					sr.reopen = s.version
+				}
+				return s.piece(sr, delimiter, string([]byte{b, cmd})), nil
+			case 'c':
+				s = statementScan{}
+			case 'd':
+				line := sr.line
+				if err := sr.setDelimiter(sr.readLine(), line); err != nil {
+					return nil, err
+				}
+				delimiter = sr.delimiter
+			default:
+				s.code(sr, b, cmd)
+			}
+		default:
+			s.code(sr, b)
+		}
+	}
+}
+
+// scanQuoted consumes a quoted string or identifier whose opening quote has
+// already been written to s.
+func (sr *StatementReader) scanQuoted(s *statementScan, quote byte) error {
+	start := sr.pos - 1
+	for {
+		b, err := sr.readByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unterminated quoted string starting at offset %d", start)
+			}
+			return err
+		}
+		s.write(sr, b)
+		switch {
+		case b == '\\' && quote != '`':
+			if b, err = sr.readByte(); err == nil {
+				s.write(sr, b)
+			}
+		case b == quote:
+			if !sr.hasPrefix(string(quote)) {
+				return nil
+			}
+			// A doubled quote is an escaped quote.
+			sr.discard(1)
+			s.write(sr, quote)
+		}
+	}
+}
+
+// scanComment consumes a block comment whose leading "/*" has already been
+// read. A /*! comment is not consumed: its prefix is recorded so that its
+// contents are scanned as regular code.
+func (sr *StatementReader) scanComment(s *statementScan) error {
+	start := sr.pos - 2
+	if s.version == "" && sr.hasPrefix("!") {
+		sr.discard(1)
+		prefix := []byte("/*!")
+		for {
+			next, _ := sr.r.Peek(1)
+			if len(next) == 0 || next[0] < '0' || next[0] > '9' {
+				break
+			}
+			sr.discard(1)
+			prefix = append(prefix, next[0])
+		}
+		s.code(sr, prefix...)
+		s.version = string(prefix)
+		return nil
+	}
+
+	s.write(sr, '/', '*')
+	for {
+		b, err := sr.readByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unterminated comment starting at offset %d", start)
+			}
+			return err
+		}
+		s.write(sr, b)
+		if b == '*' && sr.hasPrefix("/") {
+			sr.discard(1)
+			s.write(sr, '/')
+			return nil
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAllPieces(t *testing.T, input string) []*StatementPiece {
+	t.Helper()
+	sr := NewStatementReader(strings.NewReader(input))
+	var pieces []*StatementPiece
+	for {
+		piece, err := sr.Next()
+		if errors.Is(err, io.EOF) {
+			return pieces
+		}
+		require.NoError(t, err)
+		pieces = append(pieces, piece)
+	}
+}
+
+func TestStatementReader(t *testing.T) {
+	testcases := []struct {
+		input  string
+		output []string
+	}{{
+		input:  "select * from table1; \t; \n; \n\t\t ;select * from table1;",
+		output: []string{"select * from table1", "select * from table1"},
+	}, {
+		input:  "select * from table",
+		output: []string{"select * from table"},
+	}, {
+		input:  "select * from /* comment ; */ table;",
+		output: []string{"select * from /* comment ; */ table"},
+	}, {
+		input:  "select * from table where semi = ';' and x = \"a\\\";\" and `b;` = 'it''s;';",
+		output: []string{"select * from table where semi = ';' and x = \"a\\\";\" and `b;` = 'it''s;'"},
+	}, {
+		input:  "select * from table1;-- comment;\nselect * from table2; # another;\n",
+		output: []string{"select * from table1", "-- comment;\nselect * from table2"},
+	}, {
+		input:  "select 1--1;",
+		output: []string{"select 1--1"},
+	}, {
+		input: "DELIMITER $$\nCREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END$$\nDELIMITER ;\nselect 3;",
+		output: []string{
+			"CREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END",
+			"select 3",
+		},
+	}, {
+		input: "delimiter ;;\n/*!50003 CREATE*/ /*!50003 TRIGGER t BEFORE INSERT ON a FOR EACH ROW BEGIN SET NEW.x = 1; END */;;\ndelimiter ;\n",
+		output: []string{
+			"/*!50003 CREATE*/ /*!50003 TRIGGER t BEFORE INSERT ON a FOR EACH ROW BEGIN SET NEW.x = 1; END */",
+		},
+	}, {
+		input:  "delimiter //\nselect 1 // select 2//",
+		output: []string{"select 1", "select 2"},
+	}, {
+		input:  "select 1\\G select 2\\g select 3\\c select 4;",
+		output: []string{"select 1", "select 2", "select 4"},
+	}, {
+		input:  "\\d $$\nselect 1; select 2$$",
+		output: []string{"select 1; select 2"},
+	}, {
+		input:  "/*!40101 SET @a = 1; SET @b = 2 */;",
+		output: []string{"/*!40101 SET @a = 1 */", "/*!40101 SET @b = 2 */"},
+	}, {
+		input:  "/*!40101 SET @a = 1;SET @b = 2 */;",
+		output: []string{"/*!40101 SET @a = 1 */", "/*!40101 SET @b = 2 */"},
+	}, {
+		input:  "/*!40101 SET @a = 1; */;\nselect 1;",
+		output: []string{"/*!40101 SET @a = 1 */", "select 1"},
+	}, {
+		input:  "-- only a comment\n/* and another */;",
+		output: nil,
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.input, func(t *testing.T) {
+			var texts []string
+			for _, piece := range readAllPieces(t, tcase.input) {
+				texts = append(texts, piece.Text)
+			}
+			assert.Equal(t, tcase.output, texts)
+		})
+	}
+}
+
+func TestStatementReaderOffsets(t *testing.T) {
+	input := "select 1;\n  select 2 ;\nDELIMITER $$\nselect 3$$\n\nselect 4\\G"
+	pieces := readAllPieces(t, input)
+	require.Len(t, pieces, 4)
+
+	for _, piece := range pieces {
+		assert.Equal(t, piece.Text, input[piece.Start:piece.End])
+	}
+	assert.Equal(t, []int{1, 2, 4, 6}, []int{pieces[0].Line, pieces[1].Line, pieces[2].Line, pieces[3].Line})
+	assert.Equal(t, ";", pieces[1].Delimiter)
+	assert.Equal(t, "$$", pieces[2].Delimiter)
+	assert.Equal(t, `\G`, pieces[3].Command)
+}
+
+func TestStatementReaderParse(t *testing.T) {
+	input := `DELIMITER ;;
+/*!50001 CREATE ALGORITHM=UNDEFINED */ /*!50001 VIEW v AS select ';;' AS a */;;
+DELIMITER ;
+/*!40101 SET NAMES utf8mb4 */;
+INSERT INTO t VALUES (1, 'a;b'), (2, 'c');
+`
+	parser := NewTestParser()
+	sr := NewStatementReader(strings.NewReader(input))
+	var stmts []Statement
+	for {
+		piece, err := sr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		stmt, err := parser.Parse(piece.Text)
+		require.NoError(t, err, piece.Text)
+		stmts = append(stmts, stmt)
+	}
+	require.Len(t, stmts, 3)
+	assert.IsType(t, &CreateView{}, stmts[0])
+	assert.IsType(t, &Set{}, stmts[1])
+	assert.IsType(t, &Insert{}, stmts[2])
+}
+
+func TestStatementReaderErrors(t *testing.T) {
+	testcases := []struct {
+		input string
+		err   string
+	}{{
+		input: "select 'abc",
+		err:   "unterminated quoted string starting at offset 7",
+	}, {
+		input: "select 1; select /* abc",
+		err:   "unterminated comment starting at offset 17",
+	}, {
+		input: "DELIMITER\nselect 1",
+		err:   "DELIMITER must be followed by a 'delimiter' character or string at line 1",
+	}, {
+		input: "DELIMITER \\\\\nselect 1",
+		err:   "DELIMITER cannot contain a backslash character at line 1",
+	}}
+
+	for _, tcase := range testcases {
+		t.Run(tcase.input, func(t *testing.T) {
+			sr := NewStatementReader(strings.NewReader(tcase.input))
+			var err error
+			for err == nil {
+				_, err = sr.Next()
+			}
+			require.EqualError(t, err, tcase.err)
+
+			// The error is sticky.
+			_, err = sr.Next()
+			require.EqualError(t, err, tcase.err)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"slices"
+)
+
+// AccessKind is how a statement accesses a table.
+type AccessKind int8
+
+const (
+	// ReadAccess reads the rows of a table.
+	ReadAccess AccessKind = iota
+	// InsertAccess inserts rows into a table.
+	InsertAccess
+	// UpdateAccess updates rows of a table.
+	UpdateAccess
+	// DeleteAccess deletes rows of a table.
+	DeleteAccess
+	// DDLAccess creates, alters, renames, truncates or drops a table or view.
+	DDLAccess
+	// LockAccess locks a table with LOCK TABLES.
+	LockAccess
+)
+
+func (k AccessKind) String() string {
+	switch k {
+	case ReadAccess:
+		return "read"
+	case InsertAccess:
+		return "insert"
+	case UpdateAccess:
+		return "update"
+	case DeleteAccess:
+		return "delete"
+	case DDLAccess:
+		return "ddl"
+	case LockAccess:
+		return "lock"
+	}
+	return "unknown"
+}
+
+// TableAccess is a table or view a statement refers to.
+type TableAccess struct {
+	// Name is the name of the table as written, with its qualifier if it
+	// has one.
+	Name TableName
+	// Aliases are the aliases the table is given, in order.
+	Aliases []string
+	// Kinds are the ways the table is accessed, in ascending order.
+	Kinds []AccessKind
+}
+
+// Has returns whether the table is accessed in the given way.
+func (t *TableAccess) Has(kind AccessKind) bool {
+	return slices.Contains(t.Kinds, kind)
+}
+
+// ExtractTableAccess returns the tables and views a statement refers to, in
+// order, with how they are accessed. Unlike ExtractAllTables, it leaves out
+// the names of common table expressions. REPLACE both inserts and deletes,
+// and INSERT ... ON DUPLICATE KEY UPDATE both inserts and updates. Without
+// a schema, the target of an unqualified SET column of a multi-table UPDATE
+// is unknown, so all its tables are updated.
+func ExtractTableAccess(stmt Statement) []*TableAccess {
+	a := &tableAccessor{targets: map[*AliasedTableExpr][]AccessKind{}}
+	a.statement(stmt)
+
+	var ctes [][]string
+	_ = Rewrite(stmt, func(cursor *Cursor) bool {
+		switch node := cursor.Node().(type) {
+		case *Select, *Union, *Update, *Delete:
+			ctes = append(ctes, cteNames(node))
+		case *CommonTableExpr:
+			// A common table expression only sees the ones before it, and
+			// itself if the WITH clause is recursive.
+			with := cursor.Parent().(*With)
+			visible := slices.Index(with.CTEs, node)
+			if with.Recursive {
+				visible++
+			}
+			ctes[len(ctes)-1] = withNames(with)[:visible]
+		case *AliasedTableExpr:
+			name, ok := node.Expr.(TableName)
+			if !ok || isDual(name) || name.Qualifier.IsEmpty() && slices.ContainsFunc(ctes, func(names []string) bool {
+				return slices.Contains(names, name.Name.String())
+			}) {
+				return true
+			}
+			kinds := a.targets[node]
+			if len(kinds) == 0 {
+				kinds = []AccessKind{ReadAccess}
+			}
+			access := a.add(name, kinds...)
+			if alias := node.As.String(); alias != "" && !slices.Contains(access.Aliases, alias) {
+				access.Aliases = append(access.Aliases, alias)
+			}
+		}
+		return true
+	}, func(cursor *Cursor) bool {
+		switch node := cursor.Node().(type) {
+		case *Select, *Union, *Update, *Delete:
+			ctes = ctes[:len(ctes)-1]
+		case *With:
+			ctes[len(ctes)-1] = withNames(node)
+		}
+		return true
+	})
+	return a.tables
+}
+
+// isDual returns whether a table name is the DUAL table of queries without
+// tables.
+func isDual(name TableName) bool {
+	return name.Qualifier.IsEmpty() && name.Name.String() == "dual"
+}
+
+// cteNames returns the names of the common table expressions of the WITH
+// clause of a statement.
+func cteNames(node SQLNode) []string {
+	var with *With
+	switch node := node.(type) {
+	case *Select:
+		with = node.With
+	case *Union:
+		with = node.With
+	case *Update:
+		with = node.With
+	case *Delete:
+		with = node.With
+	}
+	if with == nil {
+		return nil
+	}
+	return withNames(with)
+}
+
+// withNames returns the names of the common table expressions of a WITH
+// clause.
+func withNames(with *With) []string {
+	names := make([]string, 0, len(with.CTEs))
+	for _, cte := range with.CTEs {
+		names = append(names, cte.ID.String())
+	}
+	return names
+}
+
+// tableAccessor collects the tables of a statement.
+type tableAccessor struct {
+	tables []*TableAccess
+	// targets holds how the tables a statement writes are accessed. Other
+	// tables are read.
+	targets map[*AliasedTableExpr][]AccessKind
+}
+
+// add records an access to a table and returns it.
+func (a *tableAccessor) add(name TableName, kinds ...AccessKind) *TableAccess {
+	var access *TableAccess
+	for _, table := range a.tables {
+		if table.Name == name {
+			access = table
+		}
+	}
+	if access == nil {
+		access = &TableAccess{Name: name}
+		a.tables = append(a.tables, access)
+	}
+	for _, kind := range kinds {
+		if !access.Has(kind) {
+			access.Kinds = append(access.Kinds, kind)
+		}
+	}
+	slices.Sort(access.Kinds)
+	return access
+}
+
+// statement finds the tables a statement writes, and adds the tables it
+// locks or defines.
+func (a *tableAccessor) statement(stmt Statement) {
+	switch stmt := stmt.(type) {
+	case *Insert:
+		kinds := []AccessKind{InsertAccess}
+		if stmt.Action == ReplaceAct {
+			kinds = append(kinds, DeleteAccess)
+		}
+		if len(stmt.OnDup) > 0 {
+			kinds = append(kinds, UpdateAccess)
+		}
+		a.targets[stmt.Table] = kinds
+	case *Update:
+		tables := aliasedTables(stmt.TableExprs)
+		if len(tables) == 1 {
+			a.targets[tables[0]] = []AccessKind{UpdateAccess}
+			return
+		}
+		for _, expr := range stmt.Exprs {
+			for _, table := range tables {
+				// Without a schema, any table may have an unqualified
+				// column.
+				if expr.Name.Qualifier.IsEmpty() || referredBy(table, expr.Name.Qualifier) {
+					a.targets[table] = []AccessKind{UpdateAccess}
+				}
+			}
+		}
+	case *Delete:
+		tables := aliasedTables(stmt.TableExprs)
+		if len(stmt.Targets) == 0 {
+			for _, table := range tables {
+				a.targets[table] = []AccessKind{DeleteAccess}
+			}
+			return
+		}
+		for _, target := range stmt.Targets {
+			for _, table := range tables {
+				if referredBy(table, target) {
+					a.targets[table] = []AccessKind{DeleteAccess}
+				}
+			}
+		}
+	case *LockTables:
+		// The tables of LOCK TABLES are not visited by Walk.
+		for _, table := range stmt.Tables {
+			if expr, ok := table.Table.(*AliasedTableExpr); ok {
+				if name, ok := expr.Expr.(TableName); ok {
+					access := a.add(name, LockAccess)
+					if alias := expr.As.String(); alias != "" && !slices.Contains(access.Aliases, alias) {
+						access.Aliases = append(access.Aliases, alias)
+					}
+				}
+			}
+		}
+	case *CreateTable:
+		a.add(stmt.Table, DDLAccess)
+		if stmt.OptLike != nil {
+			a.add(stmt.OptLike.LikeTable, ReadAccess)
+		}
+	case DDLStatement:
+		for _, name := range stmt.AffectedTables() {
+			a.add(name, DDLAccess)
+		}
+	}
+}
+
+// aliasedTables returns the tables of a FROM clause.
+func aliasedTables(exprs []TableExpr) []*AliasedTableExpr {
+	var tables []*AliasedTableExpr
+	for _, expr := range exprs {
+		_ = Walk(func(node SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *AliasedTableExpr:
+				if _, ok := node.Expr.(TableName); ok {
+					tables = append(tables, node)
+				}
+				return false, nil
+			}
+			return true, nil
+		}, expr)
+	}
+	return tables
+}
+
+// referredBy returns whether a name refers to a table of a FROM clause: it
+// is the alias of the table, or its name if it has no alias.
+func referredBy(table *AliasedTableExpr, name TableName) bool {
+	if !table.As.IsEmpty() {
+		return name.Qualifier.IsEmpty() && name.Name == table.As
+	}
+	tableName := table.Expr.(TableName)
+	return name.Name == tableName.Name && (name.Qualifier.IsEmpty() || name.Qualifier == tableName.Qualifier)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTableAccess(t *testing.T) {
+	testcases := []struct {
+		sql    string
+		tables []string
+	}{{
+		sql:    "select * from t1 a join db.t2 b on a.id = b.id where exists (select 1 from t1 c)",
+		tables: []string{"t1 [a c] read", "db.t2 [b] read"},
+	}, {
+		sql:    "with c as (select * from t1), d as (select * from c) select * from c join d join (select 1 from t2) as x",
+		tables: []string{"t1 [] read", "t2 [] read"},
+	}, {
+		sql:    "with t as (select * from t) select * from t",
+		tables: []string{"t [] read"},
+	}, {
+		sql:    "with recursive t as (select 1 union all select * from t) select * from t",
+		tables: nil,
+	}, {
+		sql:    "with c as (select * from d), d as (select * from t1) select * from c",
+		tables: []string{"d [] read", "t1 [] read"},
+	}, {
+		sql:    "select * from c where id in (with c as (select 1) select * from c)",
+		tables: []string{"c [] read"},
+	}, {
+		sql:    "insert into t1 (a) select b from t2 on duplicate key update a = values(a)",
+		tables: []string{"t1 [] insert,update", "t2 [] read"},
+	}, {
+		sql:    "replace into db.t1 values (1)",
+		tables: []string{"db.t1 [] insert,delete"},
+	}, {
+		sql:    "update t1 a join t2 b on a.id = b.id set a.x = b.y where b.z in (select z from t3)",
+		tables: []string{"t1 [a] update", "t2 [b] read", "t3 [] read"},
+	}, {
+		sql:    "update t1 join t2 on t1.id = t2.id set a = 1",
+		tables: []string{"t1 [] update", "t2 [] update"},
+	}, {
+		sql:    "update t1 set x = 1",
+		tables: []string{"t1 [] update"},
+	}, {
+		sql:    "delete a, t2 from t1 a join t2 join t3 on t3.id = a.id",
+		tables: []string{"t1 [a] delete", "t2 [] delete", "t3 [] read"},
+	}, {
+		sql:    "delete from db.t1 where id = 1",
+		tables: []string{"db.t1 [] delete"},
+	}, {
+		sql:    "with old as (select id from t2) delete from t1 where id in (select id from old)",
+		tables: []string{"t2 [] read", "t1 [] delete"},
+	}, {
+		sql:    "lock tables t1 read, db.t2 as b write",
+		tables: []string{"t1 [] lock", "db.t2 [b] lock"},
+	}, {
+		sql:    "lock tables t as a read, t as a write",
+		tables: []string{"t [a] lock"},
+	}, {
+		sql:    "create view v as select * from t1 join t2",
+		tables: []string{"v [] ddl", "t1 [] read", "t2 [] read"},
+	}, {
+		sql:    "create table t3 like db.t1",
+		tables: []string{"t3 [] ddl", "db.t1 [] read"},
+	}, {
+		sql:    "rename table t1 to t2, db.t3 to db.t4",
+		tables: []string{"t1 [] ddl", "t2 [] ddl", "db.t3 [] ddl", "db.t4 [] ddl"},
+	}, {
+		sql:    "drop table if exists t1, t2",
+		tables: []string{"t1 [] ddl", "t2 [] ddl"},
+	}, {
+		sql:    "alter table t1 add column c int",
+		tables: []string{"t1 [] ddl"},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.sql, func(t *testing.T) {
+			stmt, err := NewTestParser().Parse(tcase.sql)
+			require.NoError(t, err)
+			var tables []string
+			for _, table := range ExtractTableAccess(stmt) {
+				kinds := make([]string, len(table.Kinds))
+				for i, kind := range table.Kinds {
+					kinds[i] = kind.String()
+				}
+				tables = append(tables, fmt.Sprintf("%s %v %s", String(table.Name), table.Aliases, strings.Join(kinds, ",")))
+			}
+			assert.Equal(t, tcase.tables, tables)
+		})
+	}
+}